@@ -1,9 +1,13 @@
 package dbmodel
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 )
 
 var (
@@ -15,23 +19,39 @@ var (
 	ErrInvalidDriver = errors.New("Invalid driver")
 	// ErrTableNameEmpty is raised when table name is not given.
 	ErrTableNameEmpty = errors.New("Table name is required.")
+	// ErrNotSupported is raised when provider does not implement interface that is required by option.
+	ErrNotSupported = errors.New("Not supported by provider")
 )
 
+// DefaultConcurrency is default max number of catalog queries that AllTables runs concurrently.
+const DefaultConcurrency = 4
+
 // Client is table meta data loding client.
 type Client struct {
-	dataSource DataSource
-	provider   Provider
-	db         *sql.DB
-	err        error
+	dataSource  DataSource
+	provider    Provider
+	db          *sql.DB
+	err         error
+	concurrency int
+	snapshot    *Snapshot
+	typeMapping TypeMapping
 }
 
 // NewClient returns new Client for connecting to given data source.
+// TypeMapping of data source is merged into copy of default mapping of driver,
+// so that modifying it after NewClient does not affect Client.
 func NewClient(ds DataSource) *Client {
 	p, err := findProvider(ds)
+	m := DefaultTypeMapping(ds.Driver)
+	for k, v := range ds.TypeMapping {
+		m[k] = v
+	}
 	return &Client{
-		dataSource: ds,
-		provider:   p,
-		err:        err,
+		dataSource:  ds,
+		provider:    p,
+		err:         err,
+		concurrency: DefaultConcurrency,
+		typeMapping: m,
 	}
 }
 
@@ -42,15 +62,36 @@ func (c *Client) SetProvider(p Provider) {
 	c.err = nil
 }
 
+// SetConcurrency sets max number of catalog queries that AllTables runs concurrently.
+// If n is less than 1, queries run sequentially.
+func (c *Client) SetConcurrency(n int) {
+	c.concurrency = n
+}
+
 // Connect to database.
+// If version of data source is empty, version of database server is detected,
+// so that SQL that depends on version (eg. row level security) is used.
+// Client created by NewSnapshotClient does not connect.
 func (c *Client) Connect() {
-	if c.err != nil {
+	if c.err != nil || c.snapshot != nil {
 		return
 	}
 
 	c.db, c.err = c.provider.Connect()
 	if c.err != nil {
 		c.db = nil
+		return
+	}
+	if d, ok := c.provider.(versionDetector); ok {
+		p, ver, err := d.detectVersion(c.db)
+		if err != nil {
+			c.db.Close()
+			c.db = nil
+			c.err = err
+			return
+		}
+		c.provider = p
+		c.dataSource.Version = ver
 	}
 }
 
@@ -72,6 +113,9 @@ func (c *Client) AllTableNames(schema string) ([]*Table, error) {
 	if err := c.preCheck(schema); err != nil {
 		return nil, err
 	}
+	if c.snapshot != nil {
+		return c.snapshot.tableNames(schema, NameMatcher{})
+	}
 
 	rows, err := c.db.Query(c.provider.AllTableNamesSQL(), schema)
 	if err != nil {
@@ -84,14 +128,28 @@ func (c *Client) AllTableNames(schema string) ([]*Table, error) {
 
 // TableNames returns table names in given schema.
 // If schema is empty, raise ErrSchemaEmpty.
-// If name is empaty, TableNames returns all table names orderd by table names.
-// If name is given, TableNames returns table names that matches given name.
-func (c *Client) TableNames(schema string, name string) ([]*Table, error) {
+// If pattern of m is empaty, TableNames returns all table names orderd by table names.
+// If pattern of m is given, TableNames returns table names that matches m.
+func (c *Client) TableNames(schema string, m NameMatcher) ([]*Table, error) {
 	if err := c.preCheck(schema); err != nil {
 		return nil, err
 	}
+	if m.Pattern == "" {
+		return c.AllTableNames(schema)
+	}
+	if c.snapshot != nil {
+		return c.snapshot.tableNames(schema, m)
+	}
 
-	rows, err := c.db.Query(c.provider.TableNamesSQL(), schema, name)
+	var query string
+	if p, ok := c.provider.(NameMatchingProvider); ok {
+		query = p.MatchedTableNamesSQL(m)
+	} else if m.Mode == MatchLike && !m.IgnoreCase {
+		query = c.provider.TableNamesSQL()
+	} else {
+		return c.matchedTableNames(schema, m)
+	}
+	rows, err := c.db.Query(query, schema, m.Pattern)
 	if err != nil {
 		return nil, err
 	}
@@ -100,6 +158,25 @@ func (c *Client) TableNames(schema string, name string) ([]*Table, error) {
 	return c.readTableNames(rows), nil
 }
 
+// matchedTableNames returns table names that matches m in names loaded by AllTableNames.
+func (c *Client) matchedTableNames(schema string, m NameMatcher) ([]*Table, error) {
+	match, err := m.Matcher()
+	if err != nil {
+		return nil, err
+	}
+	tbls, err := c.AllTableNames(schema)
+	if err != nil {
+		return nil, err
+	}
+	matched := make([]*Table, 0, len(tbls))
+	for _, tbl := range tbls {
+		if match(tbl.Name()) {
+			matched = append(matched, tbl)
+		}
+	}
+	return matched, nil
+}
+
 // Table returns table meta data.
 // If schema is empty, raise ErrSchemaEmpty.
 // If name is empaty, raise ErrTableNameEmpty.
@@ -110,6 +187,12 @@ func (c *Client) Table(schema string, name string, opt Option) (*Table, error) {
 	if name == "" {
 		return nil, ErrTableNameEmpty
 	}
+	if c.snapshot != nil {
+		return c.snapshotTable(schema, name, opt)
+	}
+	if err := c.supportCheck(opt, false); err != nil {
+		return nil, err
+	}
 
 	rows, err := c.db.Query(c.provider.TableSQL(), schema, name)
 	if err != nil {
@@ -150,33 +233,152 @@ func (c *Client) Table(schema string, name string, opt Option) (*Table, error) {
 		}
 		tbl.constraints = cnss
 	}
+	if opt.Statistics {
+		tsMap, err := c.loadTableStats(tbl.Schema(), tbl.Name())
+		if err != nil {
+			return nil, err
+		}
+		tbl.stats = tsMap[tbl.Name()]
+		isMap, err := c.loadIndexStats(tbl.Schema(), tbl.Name())
+		if err != nil {
+			return nil, err
+		}
+		for _, idx := range tbl.indices {
+			idx.stats = isMap[tbl.Name()][idx.Name()]
+		}
+	}
+	if opt.ColumnStatistics {
+		csMap, err := c.loadColumnStats(tbl.Schema(), tbl.Name())
+		if err != nil {
+			return nil, err
+		}
+		for _, col := range tbl.columns {
+			col.stats = csMap[tbl.Name()][col.Name()]
+		}
+	}
+	if opt.Privileges {
+		owners, grants, err := c.loadTablePrivileges(tbl.Schema(), tbl.Name())
+		if err != nil {
+			return nil, err
+		}
+		tbl.owner = owners[tbl.Name()]
+		tbl.grants = grants[tbl.Name()]
+		colGrants, err := c.loadColumnPrivileges(tbl.Schema(), tbl.Name())
+		if err != nil {
+			return nil, err
+		}
+		for _, col := range tbl.columns {
+			col.grants = colGrants[tbl.Name()][col.Name()]
+		}
+	}
+	if opt.Policies {
+		rlsMap, polMap, err := c.loadPolicies(tbl.Schema(), tbl.Name())
+		if err != nil {
+			return nil, err
+		}
+		setRowSecurity(tbl, rlsMap, polMap)
+	}
+	if opt.Extensions {
+		_, members, err := c.loadExtensionObjects(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		setExtension(tbl, members)
+	}
+	linkTables([]*Table{tbl})
 	return tbl, nil
 }
 
 // AllTables returns table meta data list that are contained in given schema.
+// Loaded tables are restricted by opt.Filter.
+// If provider does not implement FilteringProvider, tables loaded by AllTablesSQL are filtered in memory.
+// Catalog queries run concurrently up to concurrency set by SetConcurrency,
+// and when a query fails, running queries are canceled and first error is returned.
+// Columns of indices, foreign keys and referenced keys are linked to columns of returned tables,
+// and referenced keys share ForeignKey with referencing table's ForeignKeys.
 // If schema is empty, raise ErrSchemaEmpty.
 func (c *Client) AllTables(schema string, opt Option) ([]*Table, error) {
 	if err := c.preCheck(schema); err != nil {
 		return nil, err
 	}
-
-	tbls, err := c.loadTables(schema)
-	if err != nil {
-		return nil, err
+	if c.snapshot != nil {
+		return c.snapshot.loadTables(schema, opt)
 	}
-	idxMap, err := c.loadIndicesMap(opt, schema)
-	if err != nil {
-		return nil, err
-	}
-	fkMap, err := c.loadForeignKeysMap(opt, schema)
-	if err != nil {
-		return nil, err
-	}
-	cnsMap, err := c.loadConstraintsMap(opt, schema)
-	if err != nil {
+	if err := c.supportCheck(opt, true); err != nil {
 		return nil, err
 	}
-	rkMap, err := c.loadReferencedKeysMap(opt, schema)
+
+	var (
+		tbls   []*Table
+		idxMap map[string][]*Index
+		fkMap  map[string][]*ForeignKey
+		cnsMap map[string][]*Constraint
+		rkMap  map[string][]*ForeignKey
+		tsMap  map[string]*TableStats
+		isMap  map[string]map[string]*IndexStats
+		csMap  map[string]map[string]*ColumnStats
+		owners map[string]string
+		tgMap  map[string][]*Grant
+		cgMap  map[string]map[string][]*Grant
+		rlsMap map[string]rowSecurity
+		polMap map[string][]*Policy
+		extMap map[string]string
+	)
+	_, pushDown := c.provider.(FilteringProvider)
+	filterInMemory := !pushDown && !opt.Filter.IsEmpty()
+	needExtensions := opt.Extensions || (filterInMemory && opt.Filter.ExcludeExtensionMembers)
+	err := c.runQueries(
+		func(ctx context.Context) (err error) {
+			tbls, err = c.loadTables(ctx, schema, opt.Filter)
+			return
+		},
+		func(ctx context.Context) (err error) {
+			idxMap, err = c.loadIndicesMap(ctx, opt, schema)
+			return
+		},
+		func(ctx context.Context) (err error) {
+			fkMap, err = c.loadForeignKeysMap(ctx, opt, schema)
+			return
+		},
+		func(ctx context.Context) (err error) {
+			cnsMap, err = c.loadConstraintsMap(ctx, opt, schema)
+			return
+		},
+		func(ctx context.Context) (err error) {
+			rkMap, err = c.loadReferencedKeysMap(ctx, opt, schema)
+			return
+		},
+		func(ctx context.Context) (err error) {
+			tsMap, err = c.loadTableStatsMap(ctx, opt, schema)
+			return
+		},
+		func(ctx context.Context) (err error) {
+			isMap, err = c.loadIndexStatsMap(ctx, opt, schema)
+			return
+		},
+		func(ctx context.Context) (err error) {
+			csMap, err = c.loadColumnStatsMap(ctx, opt, schema)
+			return
+		},
+		func(ctx context.Context) (err error) {
+			owners, tgMap, err = c.loadTablePrivilegesMap(ctx, opt, schema)
+			return
+		},
+		func(ctx context.Context) (err error) {
+			cgMap, err = c.loadColumnPrivilegesMap(ctx, opt, schema)
+			return
+		},
+		func(ctx context.Context) (err error) {
+			rlsMap, polMap, err = c.loadPoliciesMap(ctx, opt, schema)
+			return
+		},
+		func(ctx context.Context) (err error) {
+			if needExtensions {
+				_, extMap, err = c.loadExtensionObjects(ctx)
+			}
+			return
+		},
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -206,10 +408,124 @@ func (c *Client) AllTables(schema string, opt Option) ([]*Table, error) {
 				tbl.constraints = cnss
 			}
 		}
+		if opt.Statistics {
+			tbl.stats = tsMap[tbl.Name()]
+			for _, idx := range tbl.indices {
+				idx.stats = isMap[tbl.Name()][idx.Name()]
+			}
+		}
+		if opt.ColumnStatistics {
+			for _, col := range tbl.columns {
+				col.stats = csMap[tbl.Name()][col.Name()]
+			}
+		}
+		if opt.Privileges {
+			tbl.owner = owners[tbl.Name()]
+			tbl.grants = tgMap[tbl.Name()]
+			for _, col := range tbl.columns {
+				col.grants = cgMap[tbl.Name()][col.Name()]
+			}
+		}
+		if opt.Policies {
+			setRowSecurity(tbl, rlsMap, polMap)
+		}
+		if needExtensions {
+			setExtension(tbl, extMap)
+		}
+	}
+	if filterInMemory {
+		if tbls, err = filterTables(tbls, opt.Filter); err != nil {
+			return nil, err
+		}
 	}
+	linkTables(tbls)
 	return tbls, nil
 }
 
+// filterTables returns tables that match f.
+// Tables that belong to extension are not returned when f excludes them,
+// so that extension set for filtering is not exposed even if Option.Extensions is false.
+func filterTables(tbls []*Table, f TableFilter) ([]*Table, error) {
+	match, err := f.Matcher()
+	if err != nil {
+		return nil, err
+	}
+	filtered := make([]*Table, 0, len(tbls))
+	for _, tbl := range tbls {
+		if match(tbl) {
+			filtered = append(filtered, tbl)
+		}
+	}
+	return filtered, nil
+}
+
+// Roles returns all roles in database.
+func (c *Client) Roles() ([]*Role, error) {
+	if err := c.connCheck(); err != nil {
+		return nil, err
+	}
+	if c.snapshot != nil {
+		return c.snapshot.rolesCopy()
+	}
+
+	p, ok := c.provider.(PrivilegesProvider)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	rows, err := c.db.Query(p.RolesSQL())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return c.readRoles(rows), nil
+}
+
+// Extensions returns installed extensions with objects that belong to them.
+func (c *Client) Extensions() ([]*Extension, error) {
+	if err := c.connCheck(); err != nil {
+		return nil, err
+	}
+	if c.snapshot != nil {
+		return c.snapshot.extensionsCopy()
+	}
+
+	p, ok := c.provider.(ExtensionsProvider)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	rows, err := c.db.Query(p.ExtensionsSQL())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	exts := c.readExtensions(rows)
+
+	objs, _, err := c.loadExtensionObjects(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	for _, ext := range exts {
+		for _, obj := range objs[ext.Name()] {
+			ext.AddObject(obj)
+		}
+	}
+	return exts, nil
+}
+
+func (c *Client) snapshotTable(schema string, name string, opt Option) (*Table, error) {
+	o := opt
+	o.Filter = TableFilter{Names: []string{name}}
+	tbls, err := c.snapshot.loadTables(schema, o)
+	if err != nil {
+		return nil, err
+	}
+	if len(tbls) == 0 {
+		return nil, fmt.Errorf("Table '%v' is not found.", name)
+	}
+	return tbls[0], nil
+}
+
 func (c *Client) preCheck(schema string) error {
 	if c.err != nil {
 		return c.err
@@ -217,6 +533,32 @@ func (c *Client) preCheck(schema string) error {
 	if schema == "" {
 		return ErrSchemaEmpty
 	}
+	return c.connCheck()
+}
+
+// supportCheck returns ErrNotSupported if provider does not implement interface that opt requires.
+// If filter is true, extensions are required for filtering tables in memory.
+func (c *Client) supportCheck(opt Option, filter bool) error {
+	_, stats := c.provider.(StatisticsProvider)
+	_, colStats := c.provider.(ColumnStatisticsProvider)
+	_, privs := c.provider.(PrivilegesProvider)
+	_, pols := c.provider.(PoliciesProvider)
+	_, exts := c.provider.(ExtensionsProvider)
+	_, pushDown := c.provider.(FilteringProvider)
+	needExts := opt.Extensions || filter && !pushDown && opt.Filter.ExcludeExtensionMembers
+	if opt.Statistics && !stats || opt.ColumnStatistics && !colStats || opt.Privileges && !privs || opt.Policies && !pols || needExts && !exts {
+		return ErrNotSupported
+	}
+	return nil
+}
+
+func (c *Client) connCheck() error {
+	if c.err != nil {
+		return c.err
+	}
+	if c.snapshot != nil {
+		return nil
+	}
 	if c.db == nil {
 		return ErrConnNotFound
 	}
@@ -224,6 +566,43 @@ func (c *Client) preCheck(schema string) error {
 	return nil
 }
 
+// runQueries runs given functions in order and concurrently up to c.concurrency.
+// When a function returns error, context given to other functions is canceled
+// and functions not yet started are skipped.
+func (c *Client) runQueries(fns ...func(context.Context) error) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	n := c.concurrency
+	if n < 1 {
+		n = 1
+	}
+	sem := make(chan struct{}, n)
+	errs := make(chan error, len(fns))
+	var wg sync.WaitGroup
+	for _, fn := range fns {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(fn func(context.Context) error) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(ctx); err != nil {
+				errs <- err
+				cancel()
+			}
+		}(fn)
+	}
+	wg.Wait()
+	close(errs)
+	return <-errs
+}
+
 func findProvider(ds DataSource) (Provider, error) {
 	if ds.Driver == "postgres" {
 		return newPostgres(ds), nil
@@ -246,8 +625,9 @@ func (c *Client) readTableNames(rows *sql.Rows) []*Table {
 	return tables
 }
 
-func (c *Client) loadTables(schema string) ([]*Table, error) {
-	rows, err := c.db.Query(c.provider.AllTablesSQL(), schema)
+func (c *Client) loadTables(ctx context.Context, schema string, f TableFilter) ([]*Table, error) {
+	query, args := filteredQuery(schema, f, c.provider.AllTablesSQL, c.filtered(FilteringProvider.FilteredTablesSQL))
+	rows, err := c.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -256,8 +636,31 @@ func (c *Client) loadTables(schema string) ([]*Table, error) {
 	return c.readTables(rows), nil
 }
 
+// filtered returns fn bound to provider if provider implements FilteringProvider, otherwise nil.
+func (c *Client) filtered(fn func(FilteringProvider, TableFilter) (string, []interface{})) func(TableFilter) (string, []interface{}) {
+	p, ok := c.provider.(FilteringProvider)
+	if !ok {
+		return nil
+	}
+	return func(f TableFilter) (string, []interface{}) {
+		return fn(p, f)
+	}
+}
+
+// filteredQuery returns SQL of all and its parameters if filter is empty or filtered is nil, otherwise returns SQL of filtered.
+func filteredQuery(schema string, f TableFilter, all func() string, filtered func(TableFilter) (string, []interface{})) (string, []interface{}) {
+	if f.IsEmpty() || filtered == nil {
+		return all(), []interface{}{schema}
+	}
+	query, args := filtered(f)
+	return query, append([]interface{}{schema}, args...)
+}
+
+// readTables reads tables from rows of AllTablesSQL.
+// Optional columns (table kind and enum type) that rows does not have are left as NULL.
 func (c *Client) readTables(rows *sql.Rows) []*Table {
 	tbls := make([]*Table, 0, 10)
+	cols, _ := rows.Columns()
 	for rows.Next() {
 		var (
 			schema       sql.NullString
@@ -272,11 +675,20 @@ func (c *Client) readTables(rows *sql.Rows) []*Table {
 			nullable     sql.NullString
 			defaultValue sql.NullString
 			pkPosition   sql.NullInt64
+			kind         sql.NullString
+			enum         sql.NullString
 		)
 
-		rows.Scan(&schema, &tblName, &tblComment, &colName, &colComment, &dataType, &length, &precision, &scale, &nullable, &defaultValue, &pkPosition)
+		dest := []interface{}{&schema, &tblName, &tblComment, &colName, &colComment, &dataType, &length, &precision, &scale, &nullable, &defaultValue, &pkPosition, &kind, &enum}
+		if len(cols) < len(dest) {
+			dest = dest[:len(cols)]
+		}
+		rows.Scan(dest...)
 		if len(tbls) == 0 || tbls[len(tbls)-1].Name() != tblName.String {
 			tbl := NewTable(schema.String, tblName.String, tblComment.String)
+			if kind.Valid {
+				tbl.SetKind(TableKind(kind.String))
+			}
 			tbls = append(tbls, &tbl)
 		}
 		col := NewColumn(
@@ -289,6 +701,10 @@ func (c *Client) readTables(rows *sql.Rows) []*Table {
 			nullable.String == "YES",
 			defaultValue.String,
 			pkPosition.Int64)
+		col.logicalType = c.typeMapping.LogicalType(&col)
+		if col.logicalType == LogicalUnknown && enum.String == "YES" {
+			col.logicalType = LogicalEnum
+		}
 		tbls[len(tbls)-1].AddColumn(&col)
 	}
 	return tbls
@@ -303,14 +719,16 @@ func (c *Client) loadIndices(schema string, tblName string) ([]*Index, error) {
 	return c.readIndices(rows), nil
 }
 
-func (c *Client) loadIndicesMap(opt Option, schema string) (map[string][]*Index, error) {
+func (c *Client) loadIndicesMap(ctx context.Context, opt Option, schema string) (map[string][]*Index, error) {
 	if !opt.Indices {
 		return nil, nil
 	}
-	rows, err := c.db.Query(c.provider.AllIndicesSQL(), schema)
+	query, args := filteredQuery(schema, opt.Filter, c.provider.AllIndicesSQL, c.filtered(FilteringProvider.FilteredIndicesSQL))
+	rows, err := c.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	idxMap := make(map[string][]*Index)
 	for _, idx := range c.readIndices(rows) {
@@ -358,14 +776,16 @@ func (c *Client) loadConstraints(schema string, tblName string) ([]*Constraint,
 	return c.readConstraints(rows), nil
 }
 
-func (c *Client) loadConstraintsMap(opt Option, schema string) (map[string][]*Constraint, error) {
+func (c *Client) loadConstraintsMap(ctx context.Context, opt Option, schema string) (map[string][]*Constraint, error) {
 	if !opt.Constraints {
 		return nil, nil
 	}
-	rows, err := c.db.Query(c.provider.AllConstraintsSQL(), schema)
+	query, args := filteredQuery(schema, opt.Filter, c.provider.AllConstraintsSQL, c.filtered(FilteringProvider.FilteredConstraintsSQL))
+	rows, err := c.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	cnsMap := make(map[string][]*Constraint)
 	for _, cns := range c.readConstraints(rows) {
@@ -407,14 +827,16 @@ func (c *Client) loadForeignKeys(schema string, tblName string) ([]*ForeignKey,
 	return c.readForeignKeys(rows), nil
 }
 
-func (c *Client) loadForeignKeysMap(opt Option, schema string) (map[string][]*ForeignKey, error) {
+func (c *Client) loadForeignKeysMap(ctx context.Context, opt Option, schema string) (map[string][]*ForeignKey, error) {
 	if !opt.ForeignKeys {
 		return nil, nil
 	}
-	rows, err := c.db.Query(c.provider.AllForeignKeysSQL(), schema)
+	query, args := filteredQuery(schema, opt.Filter, c.provider.AllForeignKeysSQL, c.filtered(FilteringProvider.FilteredForeignKeysSQL))
+	rows, err := c.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	fkMap := make(map[string][]*ForeignKey)
 	for _, fk := range c.readForeignKeys(rows) {
@@ -470,14 +892,16 @@ func (c *Client) loadReferencedKeys(schema string, tblName string) ([]*ForeignKe
 	return c.readForeignKeys(rows), nil
 }
 
-func (c *Client) loadReferencedKeysMap(opt Option, schema string) (map[string][]*ForeignKey, error) {
+func (c *Client) loadReferencedKeysMap(ctx context.Context, opt Option, schema string) (map[string][]*ForeignKey, error) {
 	if !opt.ReferencedKeys {
 		return nil, nil
 	}
-	rows, err := c.db.Query(c.provider.AllReferencedKeysSQL(), schema)
+	query, args := filteredQuery(schema, opt.Filter, c.provider.AllReferencedKeysSQL, c.filtered(FilteringProvider.FilteredReferencedKeysSQL))
+	rows, err := c.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	rkMap := make(map[string][]*ForeignKey)
 	for _, rk := range c.readForeignKeys(rows) {
@@ -491,3 +915,469 @@ func (c *Client) loadReferencedKeysMap(opt Option, schema string) (map[string][]
 	}
 	return rkMap, nil
 }
+
+func (c *Client) loadTableStats(schema string, tblName string) (map[string]*TableStats, error) {
+	p, ok := c.provider.(StatisticsProvider)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	rows, err := c.db.Query(p.TableStatsSQL(), schema, tblName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return c.readTableStats(rows), nil
+}
+
+func (c *Client) loadTableStatsMap(ctx context.Context, opt Option, schema string) (map[string]*TableStats, error) {
+	if !opt.Statistics {
+		return nil, nil
+	}
+	p, ok := c.provider.(StatisticsProvider)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	query, args := filteredQuery(schema, opt.Filter, p.AllTableStatsSQL, p.FilteredTableStatsSQL)
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return c.readTableStats(rows), nil
+}
+
+func (c *Client) readTableStats(rows *sql.Rows) map[string]*TableStats {
+	tsMap := make(map[string]*TableStats)
+	for rows.Next() {
+		var (
+			schema        sql.NullString
+			tblName       sql.NullString
+			estimatedRows sql.NullInt64
+			totalBytes    sql.NullInt64
+			heapBytes     sql.NullInt64
+			toastBytes    sql.NullInt64
+			indexBytes    sql.NullInt64
+			lastVacuum    *time.Time
+			lastAnalyze   *time.Time
+		)
+		rows.Scan(&schema, &tblName, &estimatedRows, &totalBytes, &heapBytes, &toastBytes, &indexBytes, &lastVacuum, &lastAnalyze)
+		ts := NewTableStats(
+			estimatedRows.Int64,
+			totalBytes.Int64,
+			heapBytes.Int64,
+			toastBytes.Int64,
+			indexBytes.Int64,
+			timeOrZero(lastVacuum),
+			timeOrZero(lastAnalyze))
+		tsMap[tblName.String] = &ts
+	}
+	return tsMap
+}
+
+func (c *Client) loadIndexStats(schema string, tblName string) (map[string]map[string]*IndexStats, error) {
+	p, ok := c.provider.(StatisticsProvider)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	rows, err := c.db.Query(p.IndexStatsSQL(), schema, tblName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return c.readIndexStats(rows), nil
+}
+
+func (c *Client) loadIndexStatsMap(ctx context.Context, opt Option, schema string) (map[string]map[string]*IndexStats, error) {
+	if !opt.Statistics {
+		return nil, nil
+	}
+	p, ok := c.provider.(StatisticsProvider)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	query, args := filteredQuery(schema, opt.Filter, p.AllIndexStatsSQL, p.FilteredIndexStatsSQL)
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return c.readIndexStats(rows), nil
+}
+
+func (c *Client) readIndexStats(rows *sql.Rows) map[string]map[string]*IndexStats {
+	isMap := make(map[string]map[string]*IndexStats)
+	for rows.Next() {
+		var (
+			schema        sql.NullString
+			tblName       sql.NullString
+			name          sql.NullString
+			sizeBytes     sql.NullInt64
+			scans         sql.NullInt64
+			tuplesRead    sql.NullInt64
+			tuplesFetched sql.NullInt64
+		)
+		rows.Scan(&schema, &tblName, &name, &sizeBytes, &scans, &tuplesRead, &tuplesFetched)
+		is := NewIndexStats(sizeBytes.Int64, scans.Int64, tuplesRead.Int64, tuplesFetched.Int64)
+		if _, ok := isMap[tblName.String]; !ok {
+			isMap[tblName.String] = make(map[string]*IndexStats)
+		}
+		isMap[tblName.String][name.String] = &is
+	}
+	return isMap
+}
+
+func (c *Client) loadColumnStats(schema string, tblName string) (map[string]map[string]*ColumnStats, error) {
+	p, ok := c.provider.(ColumnStatisticsProvider)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	rows, err := c.db.Query(p.ColumnStatsSQL(), schema, tblName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return c.readColumnStats(rows), nil
+}
+
+func (c *Client) loadColumnStatsMap(ctx context.Context, opt Option, schema string) (map[string]map[string]*ColumnStats, error) {
+	if !opt.ColumnStatistics {
+		return nil, nil
+	}
+	p, ok := c.provider.(ColumnStatisticsProvider)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	query, args := filteredQuery(schema, opt.Filter, p.AllColumnStatsSQL, p.FilteredColumnStatsSQL)
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return c.readColumnStats(rows), nil
+}
+
+func (c *Client) readColumnStats(rows *sql.Rows) map[string]map[string]*ColumnStats {
+	csMap := make(map[string]map[string]*ColumnStats)
+	for rows.Next() {
+		var (
+			schema       sql.NullString
+			tblName      sql.NullString
+			colName      sql.NullString
+			nullFraction sql.NullFloat64
+			distinct     sql.NullFloat64
+			averageWidth sql.NullInt64
+			mcvs         sql.NullString
+			mcfs         sql.NullString
+			bounds       sql.NullString
+		)
+		rows.Scan(&schema, &tblName, &colName, &nullFraction, &distinct, &averageWidth, &mcvs, &mcfs, &bounds)
+		var (
+			values      []string
+			frequencies []float64
+			histogram   []string
+		)
+		if mcvs.Valid {
+			json.Unmarshal([]byte(mcvs.String), &values)
+		}
+		if mcfs.Valid {
+			json.Unmarshal([]byte(mcfs.String), &frequencies)
+		}
+		if bounds.Valid {
+			json.Unmarshal([]byte(bounds.String), &histogram)
+		}
+		cs := NewColumnStats(nullFraction.Float64, distinct.Float64, averageWidth.Int64, values, frequencies, histogram)
+		if _, ok := csMap[tblName.String]; !ok {
+			csMap[tblName.String] = make(map[string]*ColumnStats)
+		}
+		csMap[tblName.String][colName.String] = &cs
+	}
+	return csMap
+}
+
+func (c *Client) loadTablePrivileges(schema string, tblName string) (map[string]string, map[string][]*Grant, error) {
+	p, ok := c.provider.(PrivilegesProvider)
+	if !ok {
+		return nil, nil, ErrNotSupported
+	}
+	rows, err := c.db.Query(p.TablePrivilegesSQL(), schema, tblName)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	owners, grants := c.readTablePrivileges(rows)
+	return owners, grants, nil
+}
+
+func (c *Client) loadTablePrivilegesMap(ctx context.Context, opt Option, schema string) (map[string]string, map[string][]*Grant, error) {
+	if !opt.Privileges {
+		return nil, nil, nil
+	}
+	p, ok := c.provider.(PrivilegesProvider)
+	if !ok {
+		return nil, nil, ErrNotSupported
+	}
+	query, args := filteredQuery(schema, opt.Filter, p.AllTablePrivilegesSQL, p.FilteredTablePrivilegesSQL)
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	owners, grants := c.readTablePrivileges(rows)
+	return owners, grants, nil
+}
+
+func (c *Client) readTablePrivileges(rows *sql.Rows) (map[string]string, map[string][]*Grant) {
+	owners := make(map[string]string)
+	grants := make(map[string][]*Grant)
+	for rows.Next() {
+		var (
+			schema    sql.NullString
+			tblName   sql.NullString
+			owner     sql.NullString
+			grantee   sql.NullString
+			privilege sql.NullString
+			grantable sql.NullString
+		)
+		rows.Scan(&schema, &tblName, &owner, &grantee, &privilege, &grantable)
+		owners[tblName.String] = owner.String
+		if !privilege.Valid {
+			continue
+		}
+		g := NewGrant(grantee.String, privilege.String, grantable.String == "YES")
+		grants[tblName.String] = append(grants[tblName.String], &g)
+	}
+	return owners, grants
+}
+
+func (c *Client) loadColumnPrivileges(schema string, tblName string) (map[string]map[string][]*Grant, error) {
+	p, ok := c.provider.(PrivilegesProvider)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	rows, err := c.db.Query(p.ColumnPrivilegesSQL(), schema, tblName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return c.readColumnPrivileges(rows), nil
+}
+
+func (c *Client) loadColumnPrivilegesMap(ctx context.Context, opt Option, schema string) (map[string]map[string][]*Grant, error) {
+	if !opt.Privileges {
+		return nil, nil
+	}
+	p, ok := c.provider.(PrivilegesProvider)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	query, args := filteredQuery(schema, opt.Filter, p.AllColumnPrivilegesSQL, p.FilteredColumnPrivilegesSQL)
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return c.readColumnPrivileges(rows), nil
+}
+
+func (c *Client) readColumnPrivileges(rows *sql.Rows) map[string]map[string][]*Grant {
+	cgMap := make(map[string]map[string][]*Grant)
+	for rows.Next() {
+		var (
+			schema    sql.NullString
+			tblName   sql.NullString
+			colName   sql.NullString
+			grantee   sql.NullString
+			privilege sql.NullString
+			grantable sql.NullString
+		)
+		rows.Scan(&schema, &tblName, &colName, &grantee, &privilege, &grantable)
+		if _, ok := cgMap[tblName.String]; !ok {
+			cgMap[tblName.String] = make(map[string][]*Grant)
+		}
+		g := NewGrant(grantee.String, privilege.String, grantable.String == "YES")
+		cgMap[tblName.String][colName.String] = append(cgMap[tblName.String][colName.String], &g)
+	}
+	return cgMap
+}
+
+// rowSecurity is row level security setting of a table.
+type rowSecurity struct {
+	enabled bool
+	forced  bool
+}
+
+func setRowSecurity(tbl *Table, rlsMap map[string]rowSecurity, polMap map[string][]*Policy) {
+	rls := rlsMap[tbl.Name()]
+	tbl.SetRowSecurity(rls.enabled, rls.forced)
+	tbl.policies = polMap[tbl.Name()]
+}
+
+func (c *Client) loadPolicies(schema string, tblName string) (map[string]rowSecurity, map[string][]*Policy, error) {
+	p, ok := c.provider.(PoliciesProvider)
+	if !ok {
+		return nil, nil, ErrNotSupported
+	}
+	rows, err := c.db.Query(p.PoliciesSQL(), schema, tblName)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	rlsMap, polMap := c.readPolicies(rows)
+	return rlsMap, polMap, nil
+}
+
+func (c *Client) loadPoliciesMap(ctx context.Context, opt Option, schema string) (map[string]rowSecurity, map[string][]*Policy, error) {
+	if !opt.Policies {
+		return nil, nil, nil
+	}
+	p, ok := c.provider.(PoliciesProvider)
+	if !ok {
+		return nil, nil, ErrNotSupported
+	}
+	query, args := filteredQuery(schema, opt.Filter, p.AllPoliciesSQL, p.FilteredPoliciesSQL)
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	rlsMap, polMap := c.readPolicies(rows)
+	return rlsMap, polMap, nil
+}
+
+func (c *Client) readPolicies(rows *sql.Rows) (map[string]rowSecurity, map[string][]*Policy) {
+	rlsMap := make(map[string]rowSecurity)
+	polMap := make(map[string][]*Policy)
+	for rows.Next() {
+		var (
+			schema     sql.NullString
+			tblName    sql.NullString
+			enabled    sql.NullString
+			forced     sql.NullString
+			name       sql.NullString
+			command    sql.NullString
+			permissive sql.NullString
+			roles      sql.NullString
+			using      sql.NullString
+			withCheck  sql.NullString
+		)
+		rows.Scan(&schema, &tblName, &enabled, &forced, &name, &command, &permissive, &roles, &using, &withCheck)
+		rlsMap[tblName.String] = rowSecurity{
+			enabled: enabled.String == "YES",
+			forced:  forced.String == "YES",
+		}
+		if !name.Valid {
+			continue
+		}
+		var roleNames []string
+		if roles.Valid {
+			json.Unmarshal([]byte(roles.String), &roleNames)
+		}
+		p := NewPolicy(name.String, command.String, permissive.String != "RESTRICTIVE", roleNames, using.String, withCheck.String)
+		polMap[tblName.String] = append(polMap[tblName.String], &p)
+	}
+	return rlsMap, polMap
+}
+
+func (c *Client) readExtensions(rows *sql.Rows) []*Extension {
+	exts := make([]*Extension, 0, 10)
+	for rows.Next() {
+		var (
+			name    sql.NullString
+			version sql.NullString
+			schema  sql.NullString
+			comment sql.NullString
+		)
+		rows.Scan(&name, &version, &schema, &comment)
+		ext := NewExtension(name.String, version.String, schema.String, comment.String)
+		exts = append(exts, &ext)
+	}
+	return exts
+}
+
+// loadExtensionObjects returns objects per extension name,
+// and extension names per "schema.name" of relations (eg. tables and views) that belong to extensions.
+func (c *Client) loadExtensionObjects(ctx context.Context) (map[string][]*ExtensionObject, map[string]string, error) {
+	p, ok := c.provider.(ExtensionsProvider)
+	if !ok {
+		return nil, nil, ErrNotSupported
+	}
+	rows, err := c.db.QueryContext(ctx, p.ExtensionObjectsSQL())
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	objs := make(map[string][]*ExtensionObject)
+	members := make(map[string]string)
+	for rows.Next() {
+		var (
+			extName sql.NullString
+			kind    sql.NullString
+			schema  sql.NullString
+			name    sql.NullString
+		)
+		rows.Scan(&extName, &kind, &schema, &name)
+		obj := NewExtensionObject(kind.String, schema.String, name.String)
+		objs[extName.String] = append(objs[extName.String], &obj)
+		if obj.Kind() != "TYPE" && obj.Kind() != "FUNCTION" {
+			members[obj.Schema()+"."+obj.Name()] = extName.String
+		}
+	}
+	return objs, members, nil
+}
+
+// setExtension sets extension name to table if table is in members that loadExtensionObjects returns.
+func setExtension(tbl *Table, members map[string]string) {
+	if extName, ok := members[tbl.Schema()+"."+tbl.Name()]; ok {
+		tbl.SetExtension(extName)
+	}
+}
+
+func (c *Client) readRoles(rows *sql.Rows) []*Role {
+	roles := make([]*Role, 0, 10)
+	for rows.Next() {
+		var (
+			name       sql.NullString
+			superuser  sql.NullString
+			inherit    sql.NullString
+			createRole sql.NullString
+			createDB   sql.NullString
+			canLogin   sql.NullString
+			memberOf   sql.NullString
+		)
+		rows.Scan(&name, &superuser, &inherit, &createRole, &createDB, &canLogin, &memberOf)
+		var names []string
+		if memberOf.Valid {
+			json.Unmarshal([]byte(memberOf.String), &names)
+		}
+		r := NewRole(
+			name.String,
+			superuser.String == "YES",
+			inherit.String == "YES",
+			createRole.String == "YES",
+			createDB.String == "YES",
+			canLogin.String == "YES",
+			names)
+		roles = append(roles, &r)
+	}
+	return roles
+}
+
+func timeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
@@ -1,7 +1,12 @@
 package dbmodel
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
 	"testing"
+	"time"
 )
 
 func BenchmarkLoadAllTables(b *testing.B) {
@@ -22,6 +27,14 @@ func TestUnconnectedClientRaisesError(t *testing.T) {
 	}
 }
 
+func TestUnconnectedClientRolesRaisesError(t *testing.T) {
+	c := NewClient(createPostgresDataSource("postgres", "9.4"))
+	_, err := c.Roles()
+	if err != ErrConnNotFound {
+		t.Errorf("%v is invalid Error", err)
+	}
+}
+
 func TestInvalidDriver(t *testing.T) {
 	c := NewClient(createPostgresDataSource("foobar", "9.4"))
 	c.Connect()
@@ -53,3 +66,216 @@ func TestUseCustomProviderWhenInvalidDriverGiven(t *testing.T) {
 		t.Errorf("Client should not raise error when valid provider and unknown driver given.")
 	}
 }
+
+// minimalProvider implements only Provider, so that it has no optional features.
+type minimalProvider struct {
+	p postgres
+}
+
+func (m minimalProvider) Connect() (*sql.DB, error)    { return m.p.Connect() }
+func (m minimalProvider) AllTableNamesSQL() string     { return m.p.AllTableNamesSQL() }
+func (m minimalProvider) TableNamesSQL() string        { return m.p.TableNamesSQL() }
+func (m minimalProvider) AllTablesSQL() string         { return m.p.AllTablesSQL() }
+func (m minimalProvider) TableSQL() string             { return m.p.TableSQL() }
+func (m minimalProvider) AllIndicesSQL() string        { return m.p.AllIndicesSQL() }
+func (m minimalProvider) IndicesSQL() string           { return m.p.IndicesSQL() }
+func (m minimalProvider) AllForeignKeysSQL() string    { return m.p.AllForeignKeysSQL() }
+func (m minimalProvider) ForeignKeysSQL() string       { return m.p.ForeignKeysSQL() }
+func (m minimalProvider) AllReferencedKeysSQL() string { return m.p.AllReferencedKeysSQL() }
+func (m minimalProvider) ReferencedKeysSQL() string    { return m.p.ReferencedKeysSQL() }
+func (m minimalProvider) AllConstraintsSQL() string    { return m.p.AllConstraintsSQL() }
+func (m minimalProvider) ConstraintsSQL() string       { return m.p.ConstraintsSQL() }
+
+func TestUseCustomProviderWithoutOptionalFeatures(t *testing.T) {
+	c := NewClient(createPostgresDataSource("foobar", "9.4"))
+	c.SetProvider(minimalProvider{p: newPostgres(createPostgresDataSource("postgres", "9.4"))})
+	c.Connect()
+	defer c.Disconnect()
+	builtin := createPostgresClient()
+	defer builtin.Disconnect()
+
+	for _, m := range []NameMatcher{NameLike("tbl%"), NamePrefix("tbl"), NameRegexp("^TBL").IgnoringCase()} {
+		expected, err := builtin.TableNames("schm", m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual, err := c.TableNames("schm", m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(actual) != len(expected) {
+			t.Errorf("TableNames with %#v should return %v table names. but actual %v", m, len(expected), len(actual))
+		}
+	}
+
+	opt := Option{Indices: true, Filter: TableFilter{Names: []string{"tbl2"}}}
+	tbls, err := c.AllTables("schm", opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tbls) != 1 || tbls[0].Name() != "tbl2" {
+		t.Errorf("AllTables should return only filtered table, but got %v tables", len(tbls))
+	}
+}
+
+func TestOptionalProvidersOfBuiltinProvider(t *testing.T) {
+	var p Provider = newPostgres(InitDataSource())
+	if _, ok := p.(NameMatchingProvider); !ok {
+		t.Error("postgres should implement NameMatchingProvider.")
+	}
+	if _, ok := p.(FilteringProvider); !ok {
+		t.Error("postgres should implement FilteringProvider.")
+	}
+	if _, ok := p.(StatisticsProvider); !ok {
+		t.Error("postgres should implement StatisticsProvider.")
+	}
+	if _, ok := p.(ColumnStatisticsProvider); !ok {
+		t.Error("postgres should implement ColumnStatisticsProvider.")
+	}
+	if _, ok := p.(PrivilegesProvider); !ok {
+		t.Error("postgres should implement PrivilegesProvider.")
+	}
+	if _, ok := p.(PoliciesProvider); !ok {
+		t.Error("postgres should implement PoliciesProvider.")
+	}
+	if _, ok := p.(ExtensionsProvider); !ok {
+		t.Error("postgres should implement ExtensionsProvider.")
+	}
+}
+
+func TestMinimalProviderRejectsOptionalFeatures(t *testing.T) {
+	c := NewClient(createPostgresDataSource("foobar", "9.4"))
+	c.SetProvider(minimalProvider{p: newPostgres(createPostgresDataSource("postgres", "9.4"))})
+	c.Connect()
+	defer c.Disconnect()
+
+	tests := []Option{
+		{Statistics: true},
+		{ColumnStatistics: true},
+		{Privileges: true},
+		{Policies: true},
+		{Extensions: true},
+		{Filter: TableFilter{ExcludeExtensionMembers: true}},
+	}
+	for _, opt := range tests {
+		if _, err := c.AllTables("schm", opt); err != ErrNotSupported {
+			t.Errorf("AllTables with %+v should return ErrNotSupported, but got %v", opt, err)
+		}
+	}
+	if _, err := c.Table("schm", "users", Option{Statistics: true}); err != ErrNotSupported {
+		t.Errorf("Table should return ErrNotSupported, but got %v", err)
+	}
+	if _, err := c.Roles(); err != ErrNotSupported {
+		t.Errorf("Roles should return ErrNotSupported, but got %v", err)
+	}
+	if _, err := c.Extensions(); err != ErrNotSupported {
+		t.Errorf("Extensions should return ErrNotSupported, but got %v", err)
+	}
+}
+
+func TestFilterTables(t *testing.T) {
+	users := NewTable("schm", "users", "")
+	posts := NewTable("schm", "posts", "")
+	view := NewTable("schm", "user_posts", "")
+	view.SetKind(KindView)
+	ext := NewTable("schm", "spatial_ref_sys", "")
+	ext.SetExtension("postgis")
+	tbls, err := filterTables([]*Table{&users, &posts, &view, &ext}, TableFilter{Includes: []string{"*s"}, ExcludeExtensionMembers: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual, expected := len(tbls), 2; actual != expected {
+		t.Fatalf("filterTables returns invalid count of tables. expected: %v, actual: %v", expected, actual)
+	}
+	if tbls[0] != &users || tbls[1] != &posts {
+		t.Errorf("filterTables returns invalid tables: %v, %v", tbls[0].Name(), tbls[1].Name())
+	}
+}
+
+func TestSetExtension(t *testing.T) {
+	members := map[string]string{"public.spatial_ref_sys": "postgis"}
+	tbl := NewTable("public", "spatial_ref_sys", "")
+	setExtension(&tbl, members)
+	if actual, expected := tbl.Extension(), "postgis"; actual != expected {
+		t.Errorf("Extension is invalid. expected: %v, actual: %v", expected, actual)
+	}
+	other := NewTable("other", "spatial_ref_sys", "")
+	setExtension(&other, members)
+	if actual, expected := other.Extension(), ""; actual != expected {
+		t.Errorf("Extension is invalid. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestRunQueriesRespectsConcurrency(t *testing.T) {
+	c := NewClient(createPostgresDataSource("postgres", "9.4"))
+	c.SetConcurrency(2)
+	var (
+		mu      sync.Mutex
+		running int
+		max     int
+	)
+	fn := func(ctx context.Context) error {
+		mu.Lock()
+		running++
+		if running > max {
+			max = running
+		}
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return nil
+	}
+	if err := c.runQueries(fn, fn, fn, fn, fn); err != nil {
+		t.Error(err)
+	}
+	if max > 2 {
+		t.Errorf("runQueries should not run functions over concurrency. expected: %v, actual: %v", 2, max)
+	}
+}
+
+func TestRunQueriesReturnsFirstError(t *testing.T) {
+	c := NewClient(createPostgresDataSource("postgres", "9.4"))
+	c.SetConcurrency(1)
+	expected := errors.New("failed")
+	called := false
+	err := c.runQueries(
+		func(ctx context.Context) error {
+			return expected
+		},
+		func(ctx context.Context) error {
+			called = true
+			return nil
+		},
+	)
+	if err != expected {
+		t.Errorf("runQueries should return first error. expected: %v, actual: %v", expected, err)
+	}
+	if called {
+		t.Error("runQueries should skip functions after error.")
+	}
+}
+
+func TestRunQueriesCancelsRunningQueries(t *testing.T) {
+	c := NewClient(createPostgresDataSource("postgres", "9.4"))
+	expected := errors.New("failed")
+	err := c.runQueries(
+		func(ctx context.Context) error {
+			time.Sleep(10 * time.Millisecond)
+			return expected
+		},
+		func(ctx context.Context) error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Second):
+				t.Error("Context should be canceled when other function fails.")
+				return nil
+			}
+		},
+	)
+	if err != expected {
+		t.Errorf("runQueries should return first error. expected: %v, actual: %v", expected, err)
+	}
+}
@@ -0,0 +1,375 @@
+// Package codegen generates Go, TypeScript and JSON Schema source code from tables loaded by dbmodel.
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+// GoType is Go type that column is mapped to.
+// Import is package path that type requires. (eg. "time" for time.Time)
+type GoType struct {
+	Name   string
+	Import string
+}
+
+// Option is option of code generation.
+type Option struct {
+	// Package is package name of generated code. Default is "model".
+	Package string
+	// Tags are keys of struct tags that have column name. Default is "db" and "json".
+	Tags []string
+	// Types overrides Go types of not null columns by data type.
+	Types map[string]GoType
+	// NullTypes overrides Go types of nullable columns by data type.
+	NullTypes map[string]GoType
+	// NullPointer maps nullable columns to pointer of not null type instead of sql.NullXxx.
+	NullPointer bool
+	// Template is template that renders File. Default is DefaultTemplate.
+	Template *template.Template
+	// EnumValues are values of enum types by data type, that TypeScript and JSON Schema generation use as unions.
+	EnumValues map[string][]string
+}
+
+// File is data that template renders.
+type File struct {
+	Package string
+	Imports []string
+	Structs []*Struct
+}
+
+// Struct is struct generated from table.
+type Struct struct {
+	Name    string
+	Comment string
+	Table   *dbmodel.Table
+	Fields  []*Field
+}
+
+// Field is field of struct generated from column.
+// Tag is struct tag built from Option.Tags without back quotes.
+type Field struct {
+	Name    string
+	Type    string
+	Tag     string
+	Comment string
+	Column  *dbmodel.Column
+}
+
+// DefaultTemplate is template that generates struct with table name constant per table.
+var DefaultTemplate = template.Must(template.New("struct").Parse(`// Code generated by dbmodel. DO NOT EDIT.
+
+package {{ .Package }}
+{{ if .Imports }}
+import (
+{{- range .Imports }}
+	"{{ . }}"
+{{- end }}
+)
+{{ end }}
+{{- range .Structs }}
+// {{ .Name }}TableName is name of {{ .Table.Name }} table.
+const {{ .Name }}TableName = {{ printf "%q" .Table.Name }}
+
+// {{ .Name }} is {{ if .Comment }}{{ .Comment }}{{ else }}row of {{ .Table.Name }} table.{{ end }}
+type {{ .Name }} struct {
+{{- range .Fields }}
+{{- if .Comment }}
+	// {{ .Comment }}
+{{- end }}
+	{{ .Name }} {{ .Type }} ` + "`{{ .Tag }}`" + `
+{{- end }}
+}
+{{ end -}}
+`))
+
+// defaultTypes maps data types of not null columns to Go types.
+var defaultTypes = map[string]GoType{
+	"int2":        {Name: "int16"},
+	"int4":        {Name: "int32"},
+	"int8":        {Name: "int64"},
+	"float4":      {Name: "float32"},
+	"float8":      {Name: "float64"},
+	"numeric":     {Name: "float64"},
+	"bool":        {Name: "bool"},
+	"varchar":     {Name: "string"},
+	"bpchar":      {Name: "string"},
+	"text":        {Name: "string"},
+	"uuid":        {Name: "string"},
+	"date":        {Name: "time.Time", Import: "time"},
+	"time":        {Name: "time.Time", Import: "time"},
+	"timetz":      {Name: "time.Time", Import: "time"},
+	"timestamp":   {Name: "time.Time", Import: "time"},
+	"timestamptz": {Name: "time.Time", Import: "time"},
+	"bytea":       {Name: "[]byte"},
+	"json":        {Name: "json.RawMessage", Import: "encoding/json"},
+	"jsonb":       {Name: "json.RawMessage", Import: "encoding/json"},
+}
+
+// defaultNullTypes maps data types of nullable columns to Go types.
+// Types that can be nil ([]byte, json.RawMessage) are used as they are.
+var defaultNullTypes = map[string]GoType{
+	"int2":        {Name: "sql.NullInt64", Import: "database/sql"},
+	"int4":        {Name: "sql.NullInt64", Import: "database/sql"},
+	"int8":        {Name: "sql.NullInt64", Import: "database/sql"},
+	"float4":      {Name: "sql.NullFloat64", Import: "database/sql"},
+	"float8":      {Name: "sql.NullFloat64", Import: "database/sql"},
+	"numeric":     {Name: "sql.NullFloat64", Import: "database/sql"},
+	"bool":        {Name: "sql.NullBool", Import: "database/sql"},
+	"varchar":     {Name: "sql.NullString", Import: "database/sql"},
+	"bpchar":      {Name: "sql.NullString", Import: "database/sql"},
+	"text":        {Name: "sql.NullString", Import: "database/sql"},
+	"uuid":        {Name: "sql.NullString", Import: "database/sql"},
+	"date":        {Name: "*time.Time", Import: "time"},
+	"time":        {Name: "*time.Time", Import: "time"},
+	"timetz":      {Name: "*time.Time", Import: "time"},
+	"timestamp":   {Name: "*time.Time", Import: "time"},
+	"timestamptz": {Name: "*time.Time", Import: "time"},
+	"bytea":       {Name: "[]byte"},
+	"json":        {Name: "json.RawMessage", Import: "encoding/json"},
+	"jsonb":       {Name: "json.RawMessage", Import: "encoding/json"},
+}
+
+// fallbackType is Go type of column whose data type is not mapped.
+var fallbackType = GoType{Name: "string"}
+
+// fallbackNullType is Go type of nullable column whose data type is not mapped.
+var fallbackNullType = GoType{Name: "sql.NullString", Import: "database/sql"}
+
+// Generate writes Go source code generated from tables with template of opt, and formats it by gofmt.
+// Without template, a struct and a table name constant are generated per table.
+func Generate(w io.Writer, tbls []*dbmodel.Table, opt Option) error {
+	tmpl := opt.Template
+	if tmpl == nil {
+		tmpl = DefaultTemplate
+	}
+	f, err := NewFile(tbls, opt)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, f); err != nil {
+		return err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// NewFile returns File that has structs generated from tables.
+// Name of struct is prefixed with schema if tables of other schemas have same name. (eg. "foo.users" -> "FooUsers")
+// If names of structs or fields of a struct are still duplicated, NewFile returns error.
+// Column name is quoted in struct tag, but column name that has back quote cannot be written in struct tag and NewFile returns error.
+func NewFile(tbls []*dbmodel.Table, opt Option) (*File, error) {
+	f := &File{
+		Package: opt.Package,
+		Structs: make([]*Struct, 0, len(tbls)),
+	}
+	if f.Package == "" {
+		f.Package = "model"
+	}
+	tags := opt.Tags
+	if tags == nil {
+		tags = []string{"db", "json"}
+	}
+	names, err := structNames(tbls)
+	if err != nil {
+		return nil, err
+	}
+	imports := make(map[string]bool)
+	for i, tbl := range tbls {
+		s := &Struct{
+			Name:    names[i],
+			Comment: singleLine(tbl.Comment()),
+			Table:   tbl,
+			Fields:  make([]*Field, 0, len(tbl.Columns())),
+		}
+		fields := make(map[string]string, len(tbl.Columns()))
+		for _, col := range tbl.Columns() {
+			name := CamelCase(col.Name())
+			if other, ok := fields[name]; ok {
+				return nil, fmt.Errorf("Columns '%v' and '%v' of table '%v.%v' have same field name '%v'.", other, col.Name(), tbl.Schema(), tbl.Name(), name)
+			}
+			fields[name] = col.Name()
+			typ := ColumnType(col, opt)
+			if typ.Import != "" {
+				imports[typ.Import] = true
+			}
+			if len(tags) > 0 && strings.Contains(col.Name(), "`") {
+				return nil, fmt.Errorf("Column '%v' of table '%v.%v' has back quote that struct tag cannot have.", col.Name(), tbl.Schema(), tbl.Name())
+			}
+			tagValues := make([]string, 0, len(tags))
+			for _, tag := range tags {
+				tagValues = append(tagValues, tag+":"+strconv.Quote(col.Name()))
+			}
+			s.Fields = append(s.Fields, &Field{
+				Name:    name,
+				Type:    typ.Name,
+				Tag:     strings.Join(tagValues, " "),
+				Comment: singleLine(col.Comment()),
+				Column:  col,
+			})
+		}
+		f.Structs = append(f.Structs, s)
+	}
+	for imp := range imports {
+		f.Imports = append(f.Imports, imp)
+	}
+	sort.Strings(f.Imports)
+	return f, nil
+}
+
+// structNames returns names of structs generated from tables in same order.
+// Table whose name is shared by tables of other schemas is prefixed with its schema.
+func structNames(tbls []*dbmodel.Table) ([]string, error) {
+	schemas := make(map[string]map[string]bool, len(tbls))
+	for _, tbl := range tbls {
+		if schemas[tbl.Name()] == nil {
+			schemas[tbl.Name()] = make(map[string]bool)
+		}
+		schemas[tbl.Name()][tbl.Schema()] = true
+	}
+	names := make([]string, 0, len(tbls))
+	found := make(map[string]*dbmodel.Table, len(tbls))
+	for _, tbl := range tbls {
+		name := CamelCase(tbl.Name())
+		if len(schemas[tbl.Name()]) > 1 {
+			name = CamelCase(tbl.Schema() + "_" + tbl.Name())
+		}
+		if other, ok := found[name]; ok {
+			return nil, fmt.Errorf("Tables '%v.%v' and '%v.%v' have same struct name '%v'.", other.Schema(), other.Name(), tbl.Schema(), tbl.Name(), name)
+		}
+		found[name] = tbl
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// ColumnType returns Go type of column.
+// Types of opt are prior to default types, and array is mapped to slice of element type.
+// Numeric without fractional digits that fits in int64 (eg. numeric(10, 0)) is mapped to default type of int8.
+func ColumnType(col *dbmodel.Column, opt Option) GoType {
+	dataType := col.DataType()
+	if strings.HasPrefix(dataType, "_") {
+		elem := dbmodel.NewColumn(col.Schema(), col.TableName(), col.Name(), col.Comment(), dataType[1:], col.Size(), false, "", 0)
+		typ := ColumnType(&elem, opt)
+		typ.Name = "[]" + typ.Name
+		return typ
+	}
+	defaultKey := dataType
+	if isIntegralNumeric(col) {
+		defaultKey = "int8"
+	}
+
+	if !col.IsNullable() {
+		if typ, ok := opt.Types[dataType]; ok {
+			return typ
+		}
+		if typ, ok := defaultTypes[defaultKey]; ok {
+			return typ
+		}
+		return fallbackType
+	}
+
+	if typ, ok := opt.NullTypes[dataType]; ok {
+		return typ
+	}
+	if opt.NullPointer {
+		notNull := dbmodel.NewColumn(col.Schema(), col.TableName(), col.Name(), col.Comment(), dataType, col.Size(), false, "", 0)
+		typ := ColumnType(&notNull, opt)
+		if !strings.HasPrefix(typ.Name, "[]") && !strings.HasPrefix(typ.Name, "*") && typ.Name != "json.RawMessage" {
+			typ.Name = "*" + typ.Name
+		}
+		return typ
+	}
+	if typ, ok := defaultNullTypes[defaultKey]; ok {
+		return typ
+	}
+	return fallbackNullType
+}
+
+// isIntegralNumeric returns true if column is numeric whose scale is 0 and precision is up to 18 digits.
+func isIntegralNumeric(col *dbmodel.Column) bool {
+	if col.DataType() != "numeric" && col.DataType() != "decimal" {
+		return false
+	}
+	p, s := col.Size().Precision(), col.Size().Scale()
+	return p.Valid && s.Valid && s.Int64 == 0 && p.Int64 <= 18
+}
+
+// commonInitialisms are words that are written in upper case in Go. (from golint)
+var commonInitialisms = map[string]bool{
+	"API":   true,
+	"ASCII": true,
+	"CPU":   true,
+	"CSS":   true,
+	"DNS":   true,
+	"EOF":   true,
+	"GUID":  true,
+	"HTML":  true,
+	"HTTP":  true,
+	"HTTPS": true,
+	"ID":    true,
+	"IP":    true,
+	"JSON":  true,
+	"LHS":   true,
+	"QPS":   true,
+	"RAM":   true,
+	"RHS":   true,
+	"RPC":   true,
+	"SLA":   true,
+	"SMTP":  true,
+	"SQL":   true,
+	"SSH":   true,
+	"TCP":   true,
+	"TLS":   true,
+	"TTL":   true,
+	"UDP":   true,
+	"UI":    true,
+	"UID":   true,
+	"UUID":  true,
+	"URI":   true,
+	"URL":   true,
+	"UTF8":  true,
+	"VM":    true,
+	"XML":   true,
+}
+
+// CamelCase returns exported Go identifier from name. (eg. "user_id" -> "UserID")
+// Name that starts with digit is prefixed with "X".
+func CamelCase(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b bytes.Buffer
+	for _, w := range words {
+		upper := strings.ToUpper(w)
+		if commonInitialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		rs := []rune(w)
+		b.WriteString(strings.ToUpper(string(rs[0])) + string(rs[1:]))
+	}
+	s := b.String()
+	if s == "" || unicode.IsDigit([]rune(s)[0]) {
+		return "X" + s
+	}
+	return s
+}
+
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,284 @@
+package codegen
+
+import (
+	"bytes"
+	"database/sql"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+// loadTestTables loads tables from testdata/schema.yml.
+func loadTestTables(t *testing.T) []*dbmodel.Table {
+	f, err := os.Open("testdata/schema.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	db, err := dbmodel.LoadYAML(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db.Tables()
+}
+
+func TestGenerate(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Generate(&buf, loadTestTables(t), Option{}); err != nil {
+		t.Fatal(err)
+	}
+	expected := "// Code generated by dbmodel. DO NOT EDIT.\n" +
+		"\n" +
+		"package model\n" +
+		"\n" +
+		"import (\n" +
+		"\t\"database/sql\"\n" +
+		"\t\"encoding/json\"\n" +
+		"\t\"time\"\n" +
+		")\n" +
+		"\n" +
+		"// UsersTableName is name of users table.\n" +
+		"const UsersTableName = \"users\"\n" +
+		"\n" +
+		"// Users is user accounts\n" +
+		"type Users struct {\n" +
+		"\tID int32 `db:\"id\" json:\"id\"`\n" +
+		"\t// login id\n" +
+		"\tEmail string         `db:\"email\" json:\"email\"`\n" +
+		"\tName  sql.NullString `db:\"name\" json:\"name\"`\n" +
+		"}\n" +
+		"\n" +
+		"// PostsTableName is name of posts table.\n" +
+		"const PostsTableName = \"posts\"\n" +
+		"\n" +
+		"// Posts is row of posts table.\n" +
+		"type Posts struct {\n" +
+		"\tID     int32          `db:\"id\" json:\"id\"`\n" +
+		"\tUserID sql.NullInt64  `db:\"user_id\" json:\"user_id\"`\n" +
+		"\tBody   sql.NullString `db:\"body\" json:\"body\"`\n" +
+		"}\n" +
+		"\n" +
+		"// UserAccountsTableName is name of user_accounts table.\n" +
+		"const UserAccountsTableName = \"user_accounts\"\n" +
+		"\n" +
+		"// UserAccounts is user accounts\n" +
+		"type UserAccounts struct {\n" +
+		"\tID int64 `db:\"id\" json:\"id\"`\n" +
+		"\t// login id of user\n" +
+		"\tEmail      string         `db:\"email\" json:\"email\"`\n" +
+		"\tNickname   sql.NullString `db:\"nickname\" json:\"nickname\"`\n" +
+		"\tProfileURL sql.NullString `db:\"profile_url\" json:\"profile_url\"`\n" +
+		"\tTags       []string       `db:\"tags\" json:\"tags\"`\n" +
+		"\tCreatedAt  time.Time      `db:\"created_at\" json:\"created_at\"`\n" +
+		"\tDeletedAt  *time.Time     `db:\"deleted_at\" json:\"deleted_at\"`\n" +
+		"}\n" +
+		"\n" +
+		"// SettingsTableName is name of settings table.\n" +
+		"const SettingsTableName = \"settings\"\n" +
+		"\n" +
+		"// Settings is row of settings table.\n" +
+		"type Settings struct {\n" +
+		"\tUserID int64           `db:\"user_id\" json:\"user_id\"`\n" +
+		"\tData   json.RawMessage `db:\"data\" json:\"data\"`\n" +
+		"\tPoint  sql.NullString  `db:\"point\" json:\"point\"`\n" +
+		"}\n" +
+		"\n" +
+		"// ActiveUsersTableName is name of active_users table.\n" +
+		"const ActiveUsersTableName = \"active_users\"\n" +
+		"\n" +
+		"// ActiveUsers is row of active_users table.\n" +
+		"type ActiveUsers struct {\n" +
+		"\tID sql.NullInt64 `db:\"id\" json:\"id\"`\n" +
+		"}\n"
+	if actual := buf.String(); actual != expected {
+		t.Errorf("Generate() writes invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestGenerateWithTemplate(t *testing.T) {
+	tmpl := template.Must(template.New("gorm").Parse(`package {{ .Package }}
+{{ range .Structs }}
+type {{ .Name }} struct {
+{{- range .Fields }}
+	{{ .Name }} {{ .Type }} ` + "`gorm:\"column:{{ .Column.Name }}{{ if .Column.PrimaryKeyPosition }};primaryKey{{ end }}\"`" + `
+{{- end }}
+}
+{{ end }}`))
+	var buf bytes.Buffer
+	if err := Generate(&buf, loadTestTables(t)[3:4], Option{Package: "entity", Template: tmpl}); err != nil {
+		t.Fatal(err)
+	}
+	expected := "package entity\n" +
+		"\n" +
+		"type Settings struct {\n" +
+		"\tUserID int64           `gorm:\"column:user_id;primaryKey\"`\n" +
+		"\tData   json.RawMessage `gorm:\"column:data\"`\n" +
+		"\tPoint  sql.NullString  `gorm:\"column:point\"`\n" +
+		"}\n"
+	if actual := buf.String(); actual != expected {
+		t.Errorf("Generate() writes invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestNewFileWithTags(t *testing.T) {
+	f, err := NewFile(loadTestTables(t)[3:4], Option{Tags: []string{"sql"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected, actual := `sql:"user_id"`, f.Structs[0].Fields[0].Tag; actual != expected {
+		t.Errorf("Tag returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := []string{"database/sql", "encoding/json"}, f.Imports; strings.Join(actual, ",") != strings.Join(expected, ",") {
+		t.Errorf("Imports returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestNewFileWithSameTableNames(t *testing.T) {
+	foo := dbmodel.NewTable("foo", "users", "")
+	bar := dbmodel.NewTable("bar", "users", "")
+	posts := dbmodel.NewTable("foo", "posts", "")
+	f, err := NewFile([]*dbmodel.Table{&foo, &bar, &posts}, Option{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, expected := range []string{"FooUsers", "BarUsers", "Posts"} {
+		if actual := f.Structs[i].Name; actual != expected {
+			t.Errorf("Name is invalid. expected: %v, actual: %v", expected, actual)
+		}
+	}
+}
+
+func TestNewFileWithDuplicatedNames(t *testing.T) {
+	users := dbmodel.NewTable("foo", "users", "")
+	camel := dbmodel.NewTable("foo", "Users", "")
+	if _, err := NewFile([]*dbmodel.Table{&users, &camel}, Option{}); err == nil {
+		t.Error("NewFile() should return error for duplicated struct names.")
+	}
+
+	tbl := dbmodel.NewTable("foo", "users", "")
+	lower := dbmodel.NewColumn("foo", "users", "id", "", "int4", dbmodel.Size{}, false, "", 0)
+	upper := dbmodel.NewColumn("foo", "users", "ID", "", "int4", dbmodel.Size{}, false, "", 0)
+	tbl.AddColumn(&lower)
+	tbl.AddColumn(&upper)
+	var buf bytes.Buffer
+	if err := Generate(&buf, []*dbmodel.Table{&tbl}, Option{}); err == nil {
+		t.Error("Generate() should return error for duplicated field names.")
+	}
+	if buf.Len() > 0 {
+		t.Errorf("Generate() should not write anything. actual: %v", buf.String())
+	}
+}
+
+func TestGenerateWithQuotesInNames(t *testing.T) {
+	tbl := dbmodel.NewTable("foo", `a"b\c`, "")
+	col := dbmodel.NewColumn("foo", `a"b\c`, `x"y\z`, "", "int4", dbmodel.Size{}, false, "", 0)
+	tbl.AddColumn(&col)
+	var buf bytes.Buffer
+	if err := Generate(&buf, []*dbmodel.Table{&tbl}, Option{}); err != nil {
+		t.Fatal(err)
+	}
+	actual := buf.String()
+	for _, expected := range []string{
+		"const ABCTableName = \"a\\\"b\\\\c\"\n",
+		"\tXYZ int32 `db:\"x\\\"y\\\\z\" json:\"x\\\"y\\\\z\"`\n",
+	} {
+		if !strings.Contains(actual, expected) {
+			t.Errorf("Generate() should write %v. actual: %v", expected, actual)
+		}
+	}
+	f, err := NewFile([]*dbmodel.Table{&tbl}, Option{Tags: []string{"db"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected, actual := col.Name(), reflect.StructTag(f.Structs[0].Fields[0].Tag).Get("db"); actual != expected {
+		t.Errorf("Tag is invalid. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestNewFileWithBackQuoteInColumnName(t *testing.T) {
+	tbl := dbmodel.NewTable("foo", "users", "")
+	col := dbmodel.NewColumn("foo", "users", "a`b", "", "int4", dbmodel.Size{}, false, "", 0)
+	tbl.AddColumn(&col)
+	if _, err := NewFile([]*dbmodel.Table{&tbl}, Option{}); err == nil {
+		t.Error("NewFile() should return error for column name that has back quote.")
+	}
+	if _, err := NewFile([]*dbmodel.Table{&tbl}, Option{Tags: []string{}}); err != nil {
+		t.Errorf("NewFile() should not return error without tags. (%v)", err)
+	}
+}
+
+func TestColumnType(t *testing.T) {
+	tbl := loadTestTables(t)[2]
+	tests := []struct {
+		column   string
+		opt      Option
+		expected string
+	}{
+		{"id", Option{}, "int64"},
+		{"nickname", Option{}, "sql.NullString"},
+		{"nickname", Option{NullPointer: true}, "*string"},
+		{"deleted_at", Option{NullPointer: true}, "*time.Time"},
+		{"tags", Option{NullPointer: true}, "[]string"},
+		{"id", Option{Types: map[string]GoType{"int8": {Name: "uint64"}}}, "uint64"},
+		{"nickname", Option{NullTypes: map[string]GoType{"varchar": {Name: "null.String", Import: "gopkg.in/guregu/null.v3"}}}, "null.String"},
+		{"email", Option{NullTypes: map[string]GoType{"varchar": {Name: "null.String"}}}, "string"},
+	}
+	for _, test := range tests {
+		col, _ := tbl.FindColumn(test.column)
+		if actual := ColumnType(col, test.opt).Name; actual != test.expected {
+			t.Errorf("ColumnType() returns invalid value for %v. expected: %v, actual: %v", test.column, test.expected, actual)
+		}
+	}
+}
+
+func TestColumnTypeOfNumeric(t *testing.T) {
+	tests := []struct {
+		precision int64
+		scale     int64
+		nullable  bool
+		opt       Option
+		expected  string
+	}{
+		{10, 0, false, Option{}, "int64"},
+		{18, 0, false, Option{}, "int64"},
+		{19, 0, false, Option{}, "float64"},
+		{10, 2, false, Option{}, "float64"},
+		{10, 0, true, Option{}, "sql.NullInt64"},
+		{10, 0, true, Option{NullPointer: true}, "*int64"},
+		{10, 0, false, Option{Types: map[string]GoType{"numeric": {Name: "decimal.Decimal"}}}, "decimal.Decimal"},
+	}
+	for _, test := range tests {
+		size := dbmodel.NewSize(sql.NullInt64{}, sql.NullInt64{Int64: test.precision, Valid: true}, sql.NullInt64{Int64: test.scale, Valid: true})
+		col := dbmodel.NewColumn("foo", "bar", "amount", "", "numeric", size, test.nullable, "", 0)
+		if actual := ColumnType(&col, test.opt).Name; actual != test.expected {
+			t.Errorf("ColumnType() returns invalid value for numeric(%v, %v). expected: %v, actual: %v", test.precision, test.scale, test.expected, actual)
+		}
+	}
+	col := dbmodel.NewColumn("foo", "bar", "amount", "", "numeric", dbmodel.Size{}, false, "", 0)
+	if expected, actual := "float64", ColumnType(&col, Option{}).Name; actual != expected {
+		t.Errorf("ColumnType() returns invalid value for numeric without size. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestCamelCase(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"users", "Users"},
+		{"user_id", "UserID"},
+		{"profile_url", "ProfileURL"},
+		{"created-at", "CreatedAt"},
+		{"userName", "UserName"},
+		{"2fa_code", "X2faCode"},
+	}
+	for _, test := range tests {
+		if actual := CamelCase(test.name); actual != test.expected {
+			t.Errorf("CamelCase() returns invalid value. expected: %v, actual: %v", test.expected, actual)
+		}
+	}
+}
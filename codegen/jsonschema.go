@@ -0,0 +1,140 @@
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/pinzolo/dbmodel"
+	"github.com/pinzolo/dbmodel/doc"
+)
+
+// JSONSchemaVersion is URI of JSON Schema draft that generated documents declare.
+const JSONSchemaVersion = "https://json-schema.org/draft/2020-12/schema"
+
+// GenerateJSONSchema returns JSON Schema document per table named "<schema>.<table>.schema.json". (see doc.PageBaseName)
+// Nullable column is not required and accepts null, length of column is maxLength,
+// and precision of numeric column is minimum and maximum.
+// Column limited by CHECK constraint with IN list or enum values of opt has enum.
+// Pages can be written by doc.WritePages.
+func GenerateJSONSchema(tbls []*dbmodel.Table, opt Option) ([]*doc.Page, error) {
+	pages := make([]*doc.Page, 0, len(tbls))
+	for _, tbl := range tbls {
+		b, err := JSONSchema(tbl, opt)
+		if err != nil {
+			return nil, err
+		}
+		p := doc.NewPage(doc.PageBaseName(tbl.Schema(), tbl.Name())+".schema.json", b)
+		pages = append(pages, &p)
+	}
+	return pages, nil
+}
+
+// JSONSchema returns JSON Schema document of table as indented JSON.
+func JSONSchema(tbl *dbmodel.Table, opt Option) ([]byte, error) {
+	props := make(jsonObject, 0, len(tbl.Columns()))
+	required := make([]string, 0, len(tbl.Columns()))
+	for _, prop := range newProperties(tbl, opt) {
+		col := prop.column
+		schema := prop.schema(col.IsNullable())
+		if col.Comment() != "" {
+			schema = append(schema, jsonMember{"description", col.Comment()})
+		}
+		props = append(props, jsonMember{col.Name(), schema})
+		if !col.IsNullable() {
+			required = append(required, col.Name())
+		}
+	}
+
+	root := jsonObject{
+		{"$schema", JSONSchemaVersion},
+		{"title", doc.QualifiedName(tbl)},
+	}
+	if tbl.Comment() != "" {
+		root = append(root, jsonMember{"description", tbl.Comment()})
+	}
+	root = append(root,
+		jsonMember{"type", "object"},
+		jsonMember{"properties", props},
+		jsonMember{"required", required},
+		jsonMember{"additionalProperties", false})
+
+	b, err := json.Marshal(root)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err = json.Indent(&buf, b, "", "  "); err != nil {
+		return nil, err
+	}
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
+
+// schema returns JSON Schema of property.
+func (prop *property) schema(nullable bool) jsonObject {
+	schema := make(jsonObject, 0, 4)
+	if len(prop.values) > 0 {
+		values := make([]json.RawMessage, 0, len(prop.values)+1)
+		for _, v := range prop.values {
+			values = append(values, json.RawMessage(v))
+		}
+		if nullable {
+			values = append(values, json.RawMessage("null"))
+		}
+		return append(schema, jsonMember{"enum", values})
+	}
+	if prop.kind != "" {
+		if nullable {
+			schema = append(schema, jsonMember{"type", []string{prop.kind, "null"}})
+		} else {
+			schema = append(schema, jsonMember{"type", prop.kind})
+		}
+	}
+	if prop.format != "" {
+		schema = append(schema, jsonMember{"format", prop.format})
+	}
+	if prop.maxLength > 0 {
+		schema = append(schema, jsonMember{"maxLength", prop.maxLength})
+	}
+	if prop.minimum != "" {
+		schema = append(schema, jsonMember{"minimum", json.Number(prop.minimum)})
+	}
+	if prop.maximum != "" {
+		schema = append(schema, jsonMember{"maximum", json.Number(prop.maximum)})
+	}
+	if prop.items != nil {
+		schema = append(schema, jsonMember{"items", prop.items.schema(false)})
+	}
+	return schema
+}
+
+// jsonObject is JSON object that keeps order of members.
+type jsonObject []jsonMember
+
+type jsonMember struct {
+	key   string
+	value interface{}
+}
+
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	for i, m := range o {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		k, err := json.Marshal(m.key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteString(":")
+		b, err := json.Marshal(m.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+	}
+	buf.WriteString("}")
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,115 @@
+package codegen
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+func TestJSONSchema(t *testing.T) {
+	b, err := JSONSchema(loadSchemaTestTables(t)[0], schemaTestOption)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "foo.posts",
+  "description": "user's posts",
+  "type": "object",
+  "properties": {
+    "id": {
+      "type": "integer",
+      "minimum": -2147483648,
+      "maximum": 2147483647
+    },
+    "title": {
+      "type": "string",
+      "maxLength": 100,
+      "description": "title of post"
+    },
+    "status": {
+      "enum": [
+        "draft",
+        "published"
+      ]
+    },
+    "mood": {
+      "enum": [
+        "happy",
+        "sad",
+        null
+      ]
+    },
+    "price": {
+      "type": [
+        "number",
+        "null"
+      ],
+      "minimum": -999.99,
+      "maximum": 999.99
+    },
+    "tags": {
+      "type": [
+        "array",
+        "null"
+      ],
+      "items": {
+        "type": "string"
+      }
+    },
+    "published_at": {
+      "type": [
+        "string",
+        "null"
+      ],
+      "format": "date-time"
+    },
+    "extra": {}
+  },
+  "required": [
+    "id",
+    "title",
+    "status"
+  ],
+  "additionalProperties": false
+}
+`
+	if actual := string(b); actual != expected {
+		t.Errorf("JSONSchema() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestGenerateJSONSchema(t *testing.T) {
+	pages, err := GenerateJSONSchema(loadSchemaTestTables(t), Option{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pages) != 1 {
+		t.Fatalf("Page count is invalid. expected: %v, actual: %v", 1, len(pages))
+	}
+	if expected, actual := "foo.posts.schema.json", pages[0].Name(); actual != expected {
+		t.Errorf("Name() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestGenerateJSONSchemaWithPathSeparator(t *testing.T) {
+	tbl := dbmodel.NewTable("foo", "a/../../x", "")
+	pages, err := GenerateJSONSchema([]*dbmodel.Table{&tbl}, Option{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected, actual := "foo.a_.._.._x.schema.json", pages[0].Name(); actual != expected {
+		t.Errorf("Name() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestJSONObjectEscapesKey(t *testing.T) {
+	b, err := json.Marshal(jsonObject{{"a\x01<\xff\u00e9", 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual, expected := string(b), "{\"a\\u0001\\u003c\ufffd\u00e9\":1}"; actual != expected {
+		t.Errorf("JSON object is invalid. expected: %v, actual: %v", expected, actual)
+	}
+}
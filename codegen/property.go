@@ -0,0 +1,237 @@
+package codegen
+
+import (
+	"encoding/json"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+// property is type information of column that TypeScript and JSON Schema generation share.
+type property struct {
+	column *dbmodel.Column
+	// kind is type of JSON Schema, and empty kind means any type.
+	kind   string
+	format string
+	// values are JSON literals that column accepts.
+	values    []string
+	maxLength int64
+	minimum   string
+	maximum   string
+	items     *property
+}
+
+// newProperties returns properties of table's columns.
+// Values of CHECK constraint that limits column with IN list or enum values of opt are set to property.
+func newProperties(tbl *dbmodel.Table, opt Option) []*property {
+	checks := checkValues(tbl)
+	props := make([]*property, 0, len(tbl.Columns()))
+	for _, col := range tbl.Columns() {
+		prop := newProperty(col, opt)
+		if values, ok := checks[col.Name()]; ok {
+			prop.values = values
+		}
+		props = append(props, prop)
+	}
+	return props
+}
+
+func newProperty(col *dbmodel.Column, opt Option) *property {
+	prop := &property{column: col}
+	switch col.LogicalType() {
+	case dbmodel.LogicalInteger, dbmodel.LogicalBigInt:
+		prop.kind = "integer"
+		if p := col.Size().Precision(); p.Valid && p.Int64 > 1 && p.Int64 <= 64 {
+			bound := new(big.Int).Lsh(big.NewInt(1), uint(p.Int64-1))
+			prop.minimum = new(big.Int).Neg(bound).String()
+			prop.maximum = bound.Sub(bound, big.NewInt(1)).String()
+		}
+	case dbmodel.LogicalDecimal:
+		prop.kind = "number"
+		if col.DataType() == "numeric" || col.DataType() == "decimal" {
+			p, s := col.Size().Precision(), col.Size().Scale()
+			if p.Valid && s.Valid && p.Int64 >= s.Int64 {
+				prop.maximum = maxDecimal(p.Int64, s.Int64)
+				prop.minimum = "-" + prop.maximum
+			}
+		}
+	case dbmodel.LogicalString, dbmodel.LogicalText:
+		prop.kind = "string"
+		if l := col.Size().Length(); l.Valid {
+			prop.maxLength = l.Int64
+		}
+	case dbmodel.LogicalUUID:
+		prop.kind = "string"
+		prop.format = "uuid"
+	case dbmodel.LogicalBoolean:
+		prop.kind = "boolean"
+	case dbmodel.LogicalTimestamp, dbmodel.LogicalTimestampTZ:
+		prop.kind = "string"
+		prop.format = "date-time"
+	case dbmodel.LogicalDate:
+		prop.kind = "string"
+		prop.format = "date"
+	case dbmodel.LogicalBinary:
+		prop.kind = "string"
+	case dbmodel.LogicalArray:
+		prop.kind = "array"
+		elemType := strings.TrimSuffix(strings.TrimPrefix(col.DataType(), "_"), "[]")
+		elem := dbmodel.NewColumn(col.Schema(), col.TableName(), col.Name(), "", elemType, dbmodel.Size{}, false, "", 0)
+		prop.items = newProperty(&elem, opt)
+	case dbmodel.LogicalEnum:
+		prop.kind = "string"
+	}
+	if values, ok := opt.EnumValues[col.DataType()]; ok {
+		prop.kind = "string"
+		for _, v := range values {
+			prop.values = append(prop.values, jsonString(v))
+		}
+	}
+	return prop
+}
+
+// maxDecimal returns max value of numeric(precision, scale). (eg. numeric(5, 2) -> 999.99)
+func maxDecimal(precision int64, scale int64) string {
+	integer := strings.Repeat("9", int(precision-scale))
+	if integer == "" {
+		integer = "0"
+	}
+	if scale == 0 {
+		return integer
+	}
+	return integer + "." + strings.Repeat("9", int(scale))
+}
+
+var (
+	checkInList     = regexp.MustCompile(`(?is)^\(?"?(\w+)"?\)?(?:::[\w ]+)?\s+IN\s*(\(.*\))$`)
+	checkAnyArray   = regexp.MustCompile(`(?is)^\(?"?(\w+)"?\)?(?:::[\w ]+)?\s*=\s*ANY\s*(\(.*\))$`)
+	checkArray      = regexp.MustCompile(`(?is)^\(?ARRAY\[(.*?)\]\)?(?:::[\w ]+\[\])?$`)
+	checkLiteral    = regexp.MustCompile(`'((?:[^']|'')*)'|::[\w ]+(?:\([\d, ]*\))?(?:\[\])?|(-?\d+(?:\.\d+)?)`)
+	checkSeparators = regexp.MustCompile(`^[\s,]*$`)
+	checkStripCheck = regexp.MustCompile(`(?i)^CHECK\s*`)
+)
+
+// checkValues returns values of CHECK constraints that limit a column with IN list per column name.
+// Both "col IN ('a', 'b')" and PostgreSQL's normalized form "col = ANY (ARRAY['a', 'b'])" are recognized
+// only if it is whole expression of constraint and list has only literals.
+// Compound expressions (eg. "col IN ('a', 'b') AND qty > 5") are skipped.
+func checkValues(tbl *dbmodel.Table) map[string][]string {
+	checks := make(map[string][]string)
+	for _, con := range tbl.Constraints() {
+		if con.Kind() != "CHECK" {
+			continue
+		}
+		content := unwrapParens(checkStripCheck.ReplaceAllString(strings.TrimSpace(con.Content()), ""))
+		name, list, ok := checkList(content)
+		if !ok {
+			continue
+		}
+		if _, ok := tbl.FindColumn(name); !ok {
+			continue
+		}
+		if !checkSeparators.MatchString(checkLiteral.ReplaceAllString(list, "")) {
+			continue
+		}
+		values := make([]string, 0, 4)
+		for _, lit := range checkLiteral.FindAllStringSubmatch(list, -1) {
+			switch {
+			case strings.HasPrefix(lit[0], "'"):
+				values = append(values, jsonString(strings.Replace(lit[1], "''", "'", -1)))
+			case lit[2] != "":
+				values = append(values, lit[2])
+			}
+		}
+		if len(values) > 0 {
+			checks[name] = values
+		}
+	}
+	return checks
+}
+
+// checkList returns column name and list of expression that is single "col IN (...)" or "col = ANY (ARRAY[...])".
+func checkList(content string) (string, string, bool) {
+	if !isBalanced(content) {
+		return "", "", false
+	}
+	if m := checkInList.FindStringSubmatch(content); m != nil && isParenGroup(m[2]) {
+		return m[1], m[2][1 : len(m[2])-1], true
+	}
+	if m := checkAnyArray.FindStringSubmatch(content); m != nil && isParenGroup(m[2]) {
+		if a := checkArray.FindStringSubmatch(unwrapParens(m[2])); a != nil {
+			return m[1], a[1], true
+		}
+	}
+	return "", "", false
+}
+
+// unwrapParens removes parentheses that enclose whole expression. (eg. "((a = 1))" -> "a = 1")
+func unwrapParens(s string) string {
+	s = strings.TrimSpace(s)
+	for isParenGroup(s) {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	return s
+}
+
+// isParenGroup returns true if s starts with parenthesis that is closed at the end of s.
+func isParenGroup(s string) bool {
+	return strings.HasPrefix(s, "(") && closingParen(s) == len(s)-1
+}
+
+// closingParen returns index of parenthesis that closes the first character of s.
+// Parentheses in quoted literals and identifiers are ignored, and -1 is returned if it is not closed.
+func closingParen(s string) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// isBalanced returns true if all parentheses and quotes of s are closed.
+func isBalanced(s string) bool {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+	return depth == 0 && quote == 0
+}
+
+func jsonString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
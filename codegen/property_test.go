@@ -0,0 +1,112 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+func TestCheckValues(t *testing.T) {
+	tests := []struct {
+		content  string
+		expected string
+	}{
+		{"CHECK (status IN ('draft', 'published'))", `"draft","published"`},
+		{"((status)::text = ANY ((ARRAY['draft'::character varying, 'it''s'::character varying(10)])::text[]))", `"draft","it's"`},
+		{"(level = ANY (ARRAY[1, 2, -3]))", `1,2,-3`},
+		{"CHECK (\"status\" in ('a'))", `"a"`},
+		{"(level > 0)", ``},
+		{"CHECK (unknown IN ('a'))", ``},
+		{"CHECK (status IN ('a(', 'b'))", `"a(","b"`},
+		{"CHECK (status IN ('a','b') AND level > 5)", ``},
+		{"CHECK ((status IN ('a','b')) OR (level IN (7,8)))", ``},
+		{"(status IN ('a','b')) OR (level IN (7,8))", ``},
+		{"((status = ANY (ARRAY['a', 'b'])) AND (level > 5))", ``},
+		{"(status = ANY (ARRAY['a'])) OR (level = ANY (ARRAY[7]))", ``},
+		{"CHECK (status IN (lower('A'), 'b'))", ``},
+		{"CHECK (status IN ('a')", ``},
+	}
+	for _, test := range tests {
+		tbl := dbmodel.NewTable("foo", "posts", "")
+		for _, name := range []string{"status", "level"} {
+			col := dbmodel.NewColumn("foo", "posts", name, "", "text", dbmodel.Size{}, true, "", 0)
+			tbl.AddColumn(&col)
+		}
+		con := dbmodel.NewConstraint("foo", "posts", "posts_check", "CHECK", test.content)
+		tbl.AddConstraint(&con)
+		actual := ""
+		for _, values := range checkValues(&tbl) {
+			actual = strings.Join(values, ",")
+		}
+		if actual != test.expected {
+			t.Errorf("checkValues() returns invalid value for %v. expected: %v, actual: %v", test.content, test.expected, actual)
+		}
+	}
+}
+
+func TestMaxDecimal(t *testing.T) {
+	tests := []struct {
+		precision int64
+		scale     int64
+		expected  string
+	}{
+		{5, 2, "999.99"},
+		{3, 0, "999"},
+		{2, 2, "0.99"},
+	}
+	for _, test := range tests {
+		if actual := maxDecimal(test.precision, test.scale); actual != test.expected {
+			t.Errorf("maxDecimal() returns invalid value. expected: %v, actual: %v", test.expected, actual)
+		}
+	}
+}
+
+const schemaTestYAML = `
+schemas:
+  - name: foo
+    tables:
+      - name: posts
+        comment: user's posts
+        columns:
+          - name: id
+            type: int4
+            precision: 32
+            scale: 0
+            primary_key: 1
+          - name: title
+            type: varchar
+            length: 100
+            nullable: false
+            comment: title of post
+          - name: status
+            type: varchar
+            length: 10
+            nullable: false
+          - name: mood
+            type: mood
+          - name: price
+            type: numeric
+            precision: 5
+            scale: 2
+          - name: tags
+            type: _text
+          - name: published_at
+            type: timestamptz
+          - name: extra
+            type: jsonb
+        constraints:
+          - name: posts_status_check
+            kind: CHECK
+            content: CHECK (status IN ('draft', 'published'))
+`
+
+func loadSchemaTestTables(t *testing.T) []*dbmodel.Table {
+	db, err := dbmodel.LoadYAML(strings.NewReader(schemaTestYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db.Tables()
+}
+
+var schemaTestOption = Option{EnumValues: map[string][]string{"mood": {"happy", "sad"}}}
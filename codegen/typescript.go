@@ -0,0 +1,86 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// GenerateTypeScript writes TypeScript interface per table.
+// Nullable column is optional property that accepts null,
+// and column limited by CHECK constraint with IN list or enum values of opt is union of literal types.
+// Comments are written as JSDoc.
+// Interfaces are named in same way as structs of NewFile.
+func GenerateTypeScript(w io.Writer, tbls []*dbmodel.Table, opt Option) error {
+	names, err := structNames(tbls)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by dbmodel. DO NOT EDIT.\n")
+	for i, tbl := range tbls {
+		buf.WriteString("\n")
+		writeJSDoc(&buf, "", tbl.Comment())
+		fmt.Fprintf(&buf, "export interface %v {\n", names[i])
+		for _, prop := range newProperties(tbl, opt) {
+			col := prop.column
+			writeJSDoc(&buf, "  ", col.Comment())
+			name := col.Name()
+			if !tsIdentifier.MatchString(name) {
+				name = jsonString(name)
+			}
+			if col.IsNullable() {
+				fmt.Fprintf(&buf, "  %v?: %v | null;\n", name, tsType(prop))
+			} else {
+				fmt.Fprintf(&buf, "  %v: %v;\n", name, tsType(prop))
+			}
+		}
+		buf.WriteString("}\n")
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// tsType returns TypeScript type of property.
+func tsType(prop *property) string {
+	if len(prop.values) > 0 {
+		return strings.Join(prop.values, " | ")
+	}
+	switch prop.kind {
+	case "integer", "number":
+		return "number"
+	case "string":
+		return "string"
+	case "boolean":
+		return "boolean"
+	case "array":
+		elem := tsType(prop.items)
+		if strings.Contains(elem, " ") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	}
+	return "unknown"
+}
+
+func writeJSDoc(buf *bytes.Buffer, indent string, comment string) {
+	if comment == "" {
+		return
+	}
+	lines := strings.Split(strings.Replace(comment, "*/", "*\\/", -1), "\n")
+	if len(lines) == 1 {
+		fmt.Fprintf(buf, "%v/** %v */\n", indent, lines[0])
+		return
+	}
+	fmt.Fprintf(buf, "%v/**\n", indent)
+	for _, line := range lines {
+		fmt.Fprintf(buf, "%v * %v\n", indent, strings.TrimRight(line, "\r"))
+	}
+	fmt.Fprintf(buf, "%v */\n", indent)
+}
@@ -0,0 +1,48 @@
+package codegen
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+func TestGenerateTypeScript(t *testing.T) {
+	var buf bytes.Buffer
+	if err := GenerateTypeScript(&buf, loadSchemaTestTables(t), schemaTestOption); err != nil {
+		t.Fatal(err)
+	}
+	expected := `// Code generated by dbmodel. DO NOT EDIT.
+
+/** user's posts */
+export interface Posts {
+  id: number;
+  /** title of post */
+  title: string;
+  status: "draft" | "published";
+  mood?: "happy" | "sad" | null;
+  price?: number | null;
+  tags?: string[] | null;
+  published_at?: string | null;
+  extra?: unknown | null;
+}
+`
+	if actual := buf.String(); actual != expected {
+		t.Errorf("GenerateTypeScript() writes invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestGenerateTypeScriptWithSameTableNames(t *testing.T) {
+	foo := dbmodel.NewTable("foo", "users", "")
+	bar := dbmodel.NewTable("bar", "users", "")
+	var buf bytes.Buffer
+	if err := GenerateTypeScript(&buf, []*dbmodel.Table{&foo, &bar}, Option{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"export interface FooUsers {", "export interface BarUsers {"} {
+		if actual := buf.String(); !strings.Contains(actual, expected) {
+			t.Errorf("GenerateTypeScript() should write %v. actual: %v", expected, actual)
+		}
+	}
+}
@@ -11,6 +11,9 @@ type Column struct {
 	nullable     bool
 	defaultValue string
 	pkPosition   int64
+	stats        *ColumnStats
+	grants       []*Grant
+	logicalType  LogicalType
 }
 
 // Schema returns column schema.
@@ -59,6 +62,28 @@ func (c Column) PrimaryKeyPosition() int64 {
 	return c.pkPosition
 }
 
+// Stats returns column statistics.
+// If statistics are not loaded, Stats returns nil.
+func (c Column) Stats() *ColumnStats {
+	return c.stats
+}
+
+// SetStats sets column statistics.
+func (c *Column) SetStats(s *ColumnStats) {
+	c.stats = s
+}
+
+// Grants returns privileges granted on this column.
+// Privileges granted on table are not contained.
+func (c Column) Grants() []*Grant {
+	return c.grants
+}
+
+// AddGrant appends privilege to Grants.
+func (c *Column) AddGrant(g *Grant) {
+	c.grants = append(c.grants, g)
+}
+
 // NewColumn returns new Column initialized with arguments.
 func NewColumn(schema string, tableName string, name string, comment string, dataType string, size Size, nullable bool, defaultValue string, pkPosition int64) Column {
 	return Column{
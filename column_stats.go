@@ -0,0 +1,63 @@
+package dbmodel
+
+// ColumnStats is column statistics collected by database's analyzer.
+type ColumnStats struct {
+	nullFraction          float64
+	distinct              float64
+	averageWidth          int64
+	mostCommonValues      []string
+	mostCommonFrequencies []float64
+	histogramBounds       []string
+}
+
+// NullFraction returns fraction of NULL values.
+func (s ColumnStats) NullFraction() float64 {
+	return s.nullFraction
+}
+
+// Distinct returns estimated number of distinct values.
+// If Distinct is negative, it is the negative of number of distinct values divided by number of rows.
+// (eg. -1 means all values are unique)
+func (s ColumnStats) Distinct() float64 {
+	return s.distinct
+}
+
+// DistinctCount returns estimated number of distinct values using given row count.
+func (s ColumnStats) DistinctCount(rows int64) int64 {
+	if s.distinct < 0 {
+		return int64(-s.distinct*float64(rows) + 0.5)
+	}
+	return int64(s.distinct)
+}
+
+// AverageWidth returns average width in bytes of values.
+func (s ColumnStats) AverageWidth() int64 {
+	return s.averageWidth
+}
+
+// MostCommonValues returns most common values as text.
+func (s ColumnStats) MostCommonValues() []string {
+	return s.mostCommonValues
+}
+
+// MostCommonFrequencies returns frequencies of MostCommonValues.
+func (s ColumnStats) MostCommonFrequencies() []float64 {
+	return s.mostCommonFrequencies
+}
+
+// HistogramBounds returns values that divide column's values into groups of approximately equal population.
+func (s ColumnStats) HistogramBounds() []string {
+	return s.histogramBounds
+}
+
+// NewColumnStats returns new ColumnStats initialized with arguments.
+func NewColumnStats(nullFraction float64, distinct float64, averageWidth int64, mostCommonValues []string, mostCommonFrequencies []float64, histogramBounds []string) ColumnStats {
+	return ColumnStats{
+		nullFraction:          nullFraction,
+		distinct:              distinct,
+		averageWidth:          averageWidth,
+		mostCommonValues:      mostCommonValues,
+		mostCommonFrequencies: mostCommonFrequencies,
+		histogramBounds:       histogramBounds,
+	}
+}
@@ -0,0 +1,39 @@
+package dbmodel
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewColumnStats(t *testing.T) {
+	s := NewColumnStats(0.25, 3, 4, []string{"A", "B"}, []float64{0.5, 0.25}, []string{"C", "D", "E"})
+	if expected, actual := 0.25, s.NullFraction(); actual != expected {
+		t.Errorf("NullFraction() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := float64(3), s.Distinct(); actual != expected {
+		t.Errorf("Distinct() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := int64(4), s.AverageWidth(); actual != expected {
+		t.Errorf("AverageWidth() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := []string{"A", "B"}, s.MostCommonValues(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("MostCommonValues() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := []float64{0.5, 0.25}, s.MostCommonFrequencies(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("MostCommonFrequencies() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := []string{"C", "D", "E"}, s.HistogramBounds(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("HistogramBounds() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestColumnStatsDistinctCount(t *testing.T) {
+	s := NewColumnStats(0, 3, 4, nil, nil, nil)
+	if expected, actual := int64(3), s.DistinctCount(100); actual != expected {
+		t.Errorf("DistinctCount() returns invalid value on positive distinct. expected: %v, actual: %v", expected, actual)
+	}
+	s = NewColumnStats(0, -0.5, 4, nil, nil, nil)
+	if expected, actual := int64(50), s.DistinctCount(100); actual != expected {
+		t.Errorf("DistinctCount() returns invalid value on negative distinct. expected: %v, actual: %v", expected, actual)
+	}
+}
@@ -32,3 +32,24 @@ func TestNewColumn(t *testing.T) {
 		t.Errorf("PrimaryKeyPosition() returns invalid value. expected: %v, actual: %v", "Jone Doe", c.DefaultValue())
 	}
 }
+
+func TestSetStatsToColumn(t *testing.T) {
+	c := Column{name: "name"}
+	if c.Stats() != nil {
+		t.Error("Stats() should return nil when statistics are not loaded.")
+	}
+	s := NewColumnStats(0, -1, 8, nil, nil, nil)
+	c.SetStats(&s)
+	if c.Stats() != &s {
+		t.Error("Stats() should return set statistics.")
+	}
+}
+
+func TestAddGrantToColumn(t *testing.T) {
+	c := Column{name: "name"}
+	g := NewGrant("app", "UPDATE", false)
+	c.AddGrant(&g)
+	if len(c.Grants()) != 1 || c.Grants()[0] != &g {
+		t.Errorf("Failed to add grant. (%#v)", c.Grants())
+	}
+}
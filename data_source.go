@@ -10,6 +10,9 @@ type DataSource struct {
 	Password string
 	Database string
 	Options  map[string]string
+	// TypeMapping overrides default mapping of Driver from data type to LogicalType.
+	// User defined types such as domains should be registered to use. (eg. TypeMapping{"schm.domain1": LogicalInteger})
+	TypeMapping TypeMapping
 }
 
 // NewDataSource returns new DataSource with initialized given arguments.
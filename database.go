@@ -0,0 +1,69 @@
+package dbmodel
+
+// Database is set of tables that belong to one database.
+type Database struct {
+	name   string
+	tables []*Table
+}
+
+// Name returns database name.
+func (d Database) Name() string {
+	return d.name
+}
+
+// Tables returns all tables in database.
+func (d Database) Tables() []*Table {
+	return d.tables
+}
+
+// Schemas returns schema names in order of appearance.
+func (d Database) Schemas() []string {
+	schemas := make([]string, 0, 2)
+	for _, tbl := range d.tables {
+		found := false
+		for _, s := range schemas {
+			if s == tbl.Schema() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			schemas = append(schemas, tbl.Schema())
+		}
+	}
+	return schemas
+}
+
+// SchemaTables returns tables in given schema.
+func (d Database) SchemaTables(schema string) []*Table {
+	tbls := make([]*Table, 0, len(d.tables))
+	for _, tbl := range d.tables {
+		if tbl.Schema() == schema {
+			tbls = append(tbls, tbl)
+		}
+	}
+	return tbls
+}
+
+// NewDatabase returns new Database initialized with arguments.
+func NewDatabase(name string) Database {
+	return Database{
+		name:   name,
+		tables: make([]*Table, 0, 10),
+	}
+}
+
+// AddTable appends table to Tables.
+func (d *Database) AddTable(tbl *Table) {
+	d.tables = append(d.tables, tbl)
+}
+
+// FindTable returns table that has given schema and name.
+func (d *Database) FindTable(schema string, name string) (*Table, bool) {
+	for _, tbl := range d.tables {
+		if tbl.Schema() == schema && tbl.Name() == name {
+			return tbl, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,48 @@
+package dbmodel
+
+import "testing"
+
+func TestNewDatabase(t *testing.T) {
+	db := NewDatabase("sample")
+	if expected, actual := "sample", db.Name(); actual != expected {
+		t.Errorf("Name() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if db.Tables() == nil || len(db.Tables()) != 0 {
+		t.Error("Tables() should be initialized.")
+	}
+}
+
+func TestDatabaseSchemas(t *testing.T) {
+	db := newTestDatabase()
+	schemas := db.Schemas()
+	if len(schemas) != 2 || schemas[0] != "foo" || schemas[1] != "bar" {
+		t.Errorf("Schemas() returns invalid value. (%v)", schemas)
+	}
+}
+
+func TestDatabaseSchemaTables(t *testing.T) {
+	db := newTestDatabase()
+	tbls := db.SchemaTables("foo")
+	if len(tbls) != 2 || tbls[0].Name() != "users" || tbls[1].Name() != "posts" {
+		t.Errorf("SchemaTables() returns invalid tables. (%v)", tbls)
+	}
+}
+
+func TestDatabaseFindTable(t *testing.T) {
+	db := newTestDatabase()
+	if tbl, ok := db.FindTable("bar", "users"); !ok || tbl.Schema() != "bar" {
+		t.Error("FindTable() should find table by schema and name.")
+	}
+	if _, ok := db.FindTable("bar", "posts"); ok {
+		t.Error("FindTable() should not find table in other schema.")
+	}
+}
+
+func newTestDatabase() *Database {
+	db := NewDatabase("sample")
+	db.AddTable(newUserTable())
+	db.AddTable(newPostTable())
+	other := NewTable("bar", "users", "")
+	db.AddTable(&other)
+	return &db
+}
@@ -0,0 +1,162 @@
+// Package dbml exports tables loaded by dbmodel in DBML (https://dbml.dbdiagram.io) and imports tables from DBML.
+package dbml
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/pinzolo/dbmodel"
+	"github.com/pinzolo/dbmodel/ddl"
+)
+
+var plainName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Write writes tables in DBML.
+// Comments are written as notes, and foreign keys are written as refs after all tables.
+// Column type is written in PostgreSQL style, and tables that are not KindTable are skipped.
+func Write(w io.Writer, tbls []*dbmodel.Table) error {
+	var buf bytes.Buffer
+	written := make([]*dbmodel.Table, 0, len(tbls))
+	for _, tbl := range tbls {
+		if tbl.Kind() != dbmodel.KindTable {
+			continue
+		}
+		if len(written) > 0 {
+			buf.WriteString("\n")
+		}
+		writeTable(&buf, tbl)
+		written = append(written, tbl)
+	}
+	for _, tbl := range written {
+		for _, fk := range tbl.ForeignKeys() {
+			crs := fk.ColumnReferences()
+			if len(crs) == 0 {
+				continue
+			}
+			from := make([]*dbmodel.Column, 0, len(crs))
+			to := make([]*dbmodel.Column, 0, len(crs))
+			for _, cr := range crs {
+				from = append(from, cr.From())
+				to = append(to, cr.To())
+			}
+			fmt.Fprintf(&buf, "\nRef %v: %v > %v\n", quoteName(fk.Name()), endpointString(from), endpointString(to))
+		}
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func writeTable(buf *bytes.Buffer, tbl *dbmodel.Table) {
+	fmt.Fprintf(buf, "Table %v.%v {\n", quoteName(tbl.Schema()), quoteName(tbl.Name()))
+	pks := ddl.PrimaryKeyColumns(tbl)
+	for _, col := range tbl.Columns() {
+		settings := make([]string, 0, 4)
+		if len(pks) == 1 && col.PrimaryKeyPosition() > 0 {
+			settings = append(settings, "pk")
+		} else if !col.IsNullable() {
+			settings = append(settings, "not null")
+		}
+		if col.DefaultValue() != "" {
+			settings = append(settings, "default: `"+col.DefaultValue()+"`")
+		}
+		if col.Comment() != "" {
+			settings = append(settings, "note: "+quoteString(col.Comment()))
+		}
+		fmt.Fprintf(buf, "  %v %v%v\n", quoteName(col.Name()), quoteType(ddl.Postgres.ColumnType(col)), settingsString(settings))
+	}
+
+	lines := make([]string, 0, len(tbl.Indices())+1)
+	if len(pks) > 1 {
+		lines = append(lines, columnsString(pks)+" [pk]")
+	}
+	for _, idx := range tbl.Indices() {
+		if idx.IsUnique() && sameNames(idx.Columns(), pks) {
+			continue
+		}
+		settings := make([]string, 0, 2)
+		if idx.IsUnique() {
+			settings = append(settings, "unique")
+		}
+		settings = append(settings, "name: "+quoteString(idx.Name()))
+		lines = append(lines, columnsString(idx.Columns())+settingsString(settings))
+	}
+	if len(lines) > 0 {
+		buf.WriteString("\n  indexes {\n")
+		for _, line := range lines {
+			buf.WriteString("    " + line + "\n")
+		}
+		buf.WriteString("  }\n")
+	}
+	if tbl.Comment() != "" {
+		fmt.Fprintf(buf, "\n  Note: %v\n", quoteString(tbl.Comment()))
+	}
+	buf.WriteString("}\n")
+}
+
+func settingsString(settings []string) string {
+	if len(settings) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(settings, ", ") + "]"
+}
+
+// columnsString returns column name if single column is given, otherwise column names in parentheses.
+func columnsString(cols []*dbmodel.Column) string {
+	if len(cols) == 1 {
+		return quoteName(cols[0].Name())
+	}
+	names := make([]string, 0, len(cols))
+	for _, col := range cols {
+		names = append(names, quoteName(col.Name()))
+	}
+	return "(" + strings.Join(names, ", ") + ")"
+}
+
+func endpointString(cols []*dbmodel.Column) string {
+	return quoteName(cols[0].Schema()) + "." + quoteName(cols[0].TableName()) + "." + columnsString(cols)
+}
+
+func sameNames(a []*dbmodel.Column, b []*dbmodel.Column) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name() != b[i].Name() {
+			return false
+		}
+	}
+	return true
+}
+
+func quoteName(s string) string {
+	if plainName.MatchString(s) {
+		return s
+	}
+	return `"` + strings.Replace(s, `"`, `\"`, -1) + `"`
+}
+
+// quoteType quotes type name that contains spaces. (eg. "double precision", "character varying"(10))
+func quoteType(s string) string {
+	name, args := s, ""
+	if i := strings.IndexAny(s, "(["); i >= 0 {
+		name, args = s[:i], s[i:]
+	}
+	if strings.Contains(name, " ") {
+		return `"` + name + `"` + args
+	}
+	return s
+}
+
+// quoteString returns string literal of DBML.
+// Multi-line string is written with triple quotes.
+// Every quote is escaped, so that quotes at the end of string are not read as closing quotes.
+func quoteString(s string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s)
+	if strings.Contains(s, "\n") {
+		return "'''" + escaped + "'''"
+	}
+	return "'" + escaped + "'"
+}
@@ -0,0 +1,152 @@
+package dbml
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+// loadTestTables loads tables from testdata/schema.yml.
+func loadTestTables(t *testing.T) []*dbmodel.Table {
+	f, err := os.Open("testdata/schema.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	db, err := dbmodel.LoadYAML(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db.Tables()
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, loadTestTables(t)); err != nil {
+		t.Fatal(err)
+	}
+	expected := `Table foo.users {
+  id int4 [pk]
+  email varchar(255) [not null, note: 'login id']
+  name text
+
+  indexes {
+    email [unique, name: 'users_email_key']
+  }
+
+  Note: 'user accounts'
+}
+
+Table foo.posts {
+  id int4 [pk]
+  user_id int4
+  body text [default: ` + "`''::text`" + `]
+}
+
+Table foo.orders {
+  user_id int4 [not null]
+  seq int4 [not null]
+  price numeric(10, 2) [note: 'it\'s tax included']
+  tags text[]
+
+  indexes {
+    (user_id, seq) [pk]
+    price [name: 'orders_price_idx']
+  }
+
+  Note: '''orders
+placed by users'''
+}
+
+Ref posts_user_id_fkey: foo.posts.user_id > foo.users.id
+
+Ref orders_user_id_fkey: foo.orders.user_id > foo.users.id
+`
+	if actual := buf.String(); actual != expected {
+		t.Errorf("Write() writes invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestWriteAndRead(t *testing.T) {
+	all := loadTestTables(t)
+	tbls := []*dbmodel.Table{all[0], all[2]}
+	// Read derives index of primary key as postgres does.
+	id, _ := tbls[0].FindColumn("id")
+	idx := dbmodel.NewIndex("foo", "users", "users_pkey", true)
+	idx.AddColumn(id)
+	tbls[0].AddIndex(&idx)
+	var buf bytes.Buffer
+	if err := Write(&buf, tbls); err != nil {
+		t.Fatal(err)
+	}
+	db, err := Read(&buf, "public")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := dbmodel.Diff(tbls, db.Tables()); !diff.IsEmpty() {
+		t.Errorf("Read tables should be same as written tables. diff: %v", diff)
+	}
+}
+
+func TestWriteAndReadMultiLineNotes(t *testing.T) {
+	tbl := dbmodel.NewTable("foo", "bar", "it's\nquoted'")
+	col := dbmodel.NewColumn("foo", "bar", "baz", "a\nb''", "text", dbmodel.Size{}, true, "", 0)
+	tbl.AddColumn(&col)
+	var buf bytes.Buffer
+	if err := Write(&buf, []*dbmodel.Table{&tbl}); err != nil {
+		t.Fatal(err)
+	}
+	db, err := Read(&buf, "public")
+	if err != nil {
+		t.Fatal(err)
+	}
+	read, ok := db.FindTable("foo", "bar")
+	if !ok {
+		t.Fatal("Table 'foo.bar' should be read.")
+	}
+	if expected, actual := tbl.Comment(), read.Comment(); actual != expected {
+		t.Errorf("Table comment is invalid. expected: %q, actual: %q", expected, actual)
+	}
+	rc, ok := read.FindColumn("baz")
+	if !ok {
+		t.Fatal("Column 'baz' should be read.")
+	}
+	if expected, actual := col.Comment(), rc.Comment(); actual != expected {
+		t.Errorf("Column comment is invalid. expected: %q, actual: %q", expected, actual)
+	}
+}
+
+func TestQuoteString(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected string
+	}{
+		{"login id", `'login id'`},
+		{`it's \ here`, `'it\'s \\ here'`},
+		{"a\nb''", `'''a` + "\n" + `b\'\''''`},
+	}
+	for _, test := range tests {
+		if actual := quoteString(test.s); actual != test.expected {
+			t.Errorf("quoteString() returns invalid value. expected: %v, actual: %v", test.expected, actual)
+		}
+	}
+}
+
+func TestQuoteName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"users", "users"},
+		{"user list", `"user list"`},
+		{`a"b`, `"a\"b"`},
+		{"1st", `"1st"`},
+	}
+	for _, test := range tests {
+		if actual := quoteName(test.name); actual != test.expected {
+			t.Errorf("quoteName() returns invalid value. expected: %v, actual: %v", test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package dbml
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenExpr
+	tokenNumber
+	tokenPunct
+)
+
+// token is lexical unit of DBML.
+// Value of quoted identifier, string and expression does not contain quotes.
+type token struct {
+	kind   tokenKind
+	value  string
+	line   int
+	quoted bool
+}
+
+// is returns true if token is punctuation or unquoted identifier that equals to s ignoring case.
+func (t token) is(s string) bool {
+	if t.kind != tokenPunct && (t.kind != tokenIdent || t.quoted) {
+		return false
+	}
+	return strings.EqualFold(t.value, s)
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of input"
+	case tokenString:
+		return "'" + t.value + "'"
+	case tokenExpr:
+		return "`" + t.value + "`"
+	}
+	return t.value
+}
+
+// tokenize splits DBML into tokens.
+// Comments are skipped.
+func tokenize(src string) ([]token, error) {
+	rs := []rune(src)
+	tokens := make([]token, 0, len(rs)/4)
+	line := 1
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case r == '\n':
+			line++
+			i++
+		case unicode.IsSpace(r):
+			i++
+		case r == '/' && i+1 < len(rs) && rs[i+1] == '/':
+			for i < len(rs) && rs[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(rs) && rs[i+1] == '*':
+			end := indexRunes(rs, i+2, "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("Comment at line %v is not closed.", line)
+			}
+			line += strings.Count(string(rs[i:end]), "\n")
+			i = end + 2
+		case r == '\'' && i+2 < len(rs) && rs[i+1] == '\'' && rs[i+2] == '\'':
+			start := line
+			end := i + 3
+			for ; end < len(rs); end++ {
+				if rs[end] == '\\' {
+					end++
+				} else if rs[end] == '\'' && end+2 < len(rs) && rs[end+1] == '\'' && rs[end+2] == '\'' {
+					break
+				}
+			}
+			if end >= len(rs) {
+				return nil, fmt.Errorf("String at line %v is not closed.", start)
+			}
+			s := unescape(string(rs[i+3 : end]))
+			line += strings.Count(s, "\n")
+			tokens = append(tokens, token{kind: tokenString, value: s, line: start})
+			i = end + 3
+		case r == '\'' || r == '"' || r == '`':
+			start := line
+			var b bytes.Buffer
+			j := i + 1
+			for ; j < len(rs) && rs[j] != r; j++ {
+				if rs[j] == '\\' && j+1 < len(rs) && r != '`' {
+					b.WriteRune(rs[j])
+					j++
+				}
+				if rs[j] == '\n' {
+					line++
+				}
+				b.WriteRune(rs[j])
+			}
+			if j >= len(rs) {
+				return nil, fmt.Errorf("Quoted value at line %v is not closed.", start)
+			}
+			t := token{kind: tokenString, value: unescape(b.String()), line: start}
+			if r == '"' {
+				t.kind = tokenIdent
+				t.quoted = true
+			} else if r == '`' {
+				t.kind = tokenExpr
+				t.value = b.String()
+			}
+			tokens = append(tokens, t)
+			i = j + 1
+		case unicode.IsDigit(r):
+			j := i
+			for j < len(rs) && (unicode.IsDigit(rs[j]) || (rs[j] == '.' && j+1 < len(rs) && unicode.IsDigit(rs[j+1]))) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, value: string(rs[i:j]), line: line})
+			i = j
+		case isIdentRune(r):
+			j := i
+			for j < len(rs) && isIdentRune(rs[j]) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, value: string(rs[i:j]), line: line})
+			i = j
+		case r == '<' && i+1 < len(rs) && rs[i+1] == '>':
+			tokens = append(tokens, token{kind: tokenPunct, value: "<>", line: line})
+			i += 2
+		case strings.ContainsRune("{}[](),:.<>-~", r):
+			tokens = append(tokens, token{kind: tokenPunct, value: string(r), line: line})
+			i++
+		default:
+			return nil, fmt.Errorf("Character '%c' at line %v is invalid.", r, line)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, line: line}), nil
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || r == '#' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func indexRunes(rs []rune, from int, s string) int {
+	if idx := strings.Index(string(rs[from:]), s); idx >= 0 {
+		return from + len([]rune(string(rs[from:])[:idx]))
+	}
+	return -1
+}
+
+var unescaper = strings.NewReplacer(`\\`, `\`, `\'`, `'`, `\"`, `"`, `\n`, "\n", `\t`, "\t")
+
+func unescape(s string) string {
+	return unescaper.Replace(s)
+}
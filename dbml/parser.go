@@ -0,0 +1,838 @@
+package dbml
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+// lengthTypes are types whose single size argument is length.
+var lengthTypes = map[string]bool{
+	"varchar": true,
+	"bpchar":  true,
+	"bit":     true,
+	"varbit":  true,
+}
+
+// typeAliases maps type names of SQL standard and DBML samples to PostgreSQL's internal type names,
+// that Client loads as data type.
+var typeAliases = map[string]string{
+	"smallint":                    "int2",
+	"integer":                     "int4",
+	"int":                         "int4",
+	"bigint":                      "int8",
+	"real":                        "float4",
+	"double precision":            "float8",
+	"float":                       "float8",
+	"decimal":                     "numeric",
+	"boolean":                     "bool",
+	"character varying":           "varchar",
+	"character":                   "bpchar",
+	"char":                        "bpchar",
+	"bit varying":                 "varbit",
+	"timestamp with time zone":    "timestamptz",
+	"timestamp without time zone": "timestamp",
+	"time with time zone":         "timetz",
+	"time without time zone":      "time",
+}
+
+// serialTypes maps serial types to integer types.
+var serialTypes = map[string]string{
+	"smallserial": "int2",
+	"serial":      "int4",
+	"bigserial":   "int8",
+}
+
+// implicitSizes are sizes that PostgreSQL reports for types without size arguments.
+var implicitSizes = map[string]dbmodel.Size{
+	"int2":        dbmodel.NewSize(nullInt(-1), nullInt(16), nullInt(0)),
+	"int4":        dbmodel.NewSize(nullInt(-1), nullInt(32), nullInt(0)),
+	"int8":        dbmodel.NewSize(nullInt(-1), nullInt(64), nullInt(0)),
+	"float4":      dbmodel.NewSize(nullInt(-1), nullInt(24), nullInt(-1)),
+	"float8":      dbmodel.NewSize(nullInt(-1), nullInt(53), nullInt(-1)),
+	"date":        dbmodel.NewSize(nullInt(-1), nullInt(0), nullInt(-1)),
+	"time":        dbmodel.NewSize(nullInt(-1), nullInt(6), nullInt(-1)),
+	"timetz":      dbmodel.NewSize(nullInt(-1), nullInt(6), nullInt(-1)),
+	"timestamp":   dbmodel.NewSize(nullInt(-1), nullInt(6), nullInt(-1)),
+	"timestamptz": dbmodel.NewSize(nullInt(-1), nullInt(6), nullInt(-1)),
+	"interval":    dbmodel.NewSize(nullInt(-1), nullInt(6), nullInt(-1)),
+}
+
+type columnDef struct {
+	name     string
+	typ      string
+	args     []int64
+	array    bool
+	pk       bool
+	notNull  bool
+	unique   bool
+	serial   bool
+	defValue string
+	note     string
+	ref      *refDef
+}
+
+type indexDef struct {
+	name    string
+	columns []string
+	pk      bool
+	unique  bool
+}
+
+type tableDef struct {
+	schema  string
+	name    string
+	alias   string
+	note    string
+	columns []*columnDef
+	indices []*indexDef
+	line    int
+}
+
+// endpoint is columns of a side of ref.
+// table is name parts of table. ([name] or [schema, name])
+type endpoint struct {
+	table   []string
+	columns []string
+}
+
+// refDef is relationship that from columns reference to columns.
+type refDef struct {
+	name string
+	from endpoint
+	to   endpoint
+	line int
+}
+
+type parser struct {
+	tokens        []token
+	pos           int
+	defaultSchema string
+	project       string
+	tables        []*tableDef
+	refs          []*refDef
+}
+
+// Read parses DBML and builds tables.
+// Tables without schema are placed in defaultSchema.
+// Type names are normalized to PostgreSQL's internal names (eg. integer -> int4) with implicit sizes,
+// so that tables can be compared with tables loaded by Client.
+// Primary key and unique columns have indices named as PostgreSQL names them.
+// Project name is used as database name, and enums, table groups and other elements are ignored.
+func Read(r io.Reader, defaultSchema string) (*dbmodel.Database, error) {
+	if defaultSchema == "" {
+		return nil, dbmodel.ErrSchemaEmpty
+	}
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := tokenize(string(b))
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, defaultSchema: defaultSchema}
+	if err = p.parse(); err != nil {
+		return nil, err
+	}
+	return p.build()
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) peekAt(n int) token {
+	if p.pos+n < len(p.tokens) {
+		return p.tokens[p.pos+n]
+	}
+	return p.tokens[len(p.tokens)-1]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expect(s string) error {
+	if t := p.next(); !t.is(s) {
+		return unexpected(t, s)
+	}
+	return nil
+}
+
+func (p *parser) ident() (string, error) {
+	t := p.next()
+	if t.kind != tokenIdent {
+		return "", unexpected(t, "name")
+	}
+	return t.value, nil
+}
+
+func (p *parser) str() (string, error) {
+	t := p.next()
+	if t.kind != tokenString {
+		return "", unexpected(t, "string")
+	}
+	return t.value, nil
+}
+
+func unexpected(t token, expected string) error {
+	return fmt.Errorf("Unexpected %v at line %v, %v is expected.", t, t.line, expected)
+}
+
+func (p *parser) parse() error {
+	for p.peek().kind != tokenEOF {
+		t := p.next()
+		var err error
+		switch {
+		case t.is("table"):
+			err = p.parseTable(t.line)
+		case t.is("ref"):
+			err = p.parseRef()
+		case t.is("project"):
+			if p.peek().kind == tokenIdent {
+				p.project = p.next().value
+			}
+			err = p.skipBlock()
+		case t.kind == tokenIdent:
+			err = p.skipBlock()
+		default:
+			err = unexpected(t, "element")
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// skipBlock skips tokens until end of next block.
+func (p *parser) skipBlock() error {
+	for !p.peek().is("{") {
+		if t := p.next(); t.kind == tokenEOF {
+			return unexpected(t, "{")
+		}
+	}
+	depth := 0
+	for {
+		t := p.next()
+		switch {
+		case t.kind == tokenEOF:
+			return unexpected(t, "}")
+		case t.is("{"):
+			depth++
+		case t.is("}"):
+			depth--
+			if depth == 0 {
+				return nil
+			}
+		}
+	}
+}
+
+func (p *parser) parseTable(line int) error {
+	names, err := p.parseNames()
+	if err != nil {
+		return err
+	}
+	td := &tableDef{line: line}
+	switch len(names) {
+	case 1:
+		td.schema, td.name = p.defaultSchema, names[0]
+	case 2:
+		td.schema, td.name = names[0], names[1]
+	default:
+		return fmt.Errorf("Table name '%v' at line %v is invalid.", strings.Join(names, "."), line)
+	}
+	if p.peek().is("as") {
+		p.next()
+		if td.alias, err = p.ident(); err != nil {
+			return err
+		}
+	}
+	if p.peek().is("[") {
+		settings, err := p.parseSettings()
+		if err != nil {
+			return err
+		}
+		for _, s := range settings {
+			if s.key == "note" {
+				if td.note, err = s.stringValue(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if err = p.expect("{"); err != nil {
+		return err
+	}
+	for !p.peek().is("}") {
+		switch {
+		case p.peek().is("indexes") && p.peekAt(1).is("{"):
+			p.next()
+			if err = p.parseIndices(td); err != nil {
+				return err
+			}
+		case p.peek().is("note") && (p.peekAt(1).is(":") || p.peekAt(1).is("{")):
+			if td.note, err = p.parseNote(); err != nil {
+				return err
+			}
+		default:
+			col, err := p.parseColumn()
+			if err != nil {
+				return err
+			}
+			if col.ref != nil {
+				col.ref.complete(td, col.name)
+			}
+			td.columns = append(td.columns, col)
+		}
+	}
+	p.next()
+	p.tables = append(p.tables, td)
+	return nil
+}
+
+// parseNames parses names joined by ".".
+func (p *parser) parseNames() ([]string, error) {
+	names := make([]string, 0, 3)
+	for {
+		name, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+		if !p.peek().is(".") || p.peekAt(1).is("(") {
+			return names, nil
+		}
+		p.next()
+	}
+}
+
+func (p *parser) parseNote() (string, error) {
+	p.next()
+	if p.next().is(":") {
+		return p.str()
+	}
+	s, err := p.str()
+	if err != nil {
+		return "", err
+	}
+	return s, p.expect("}")
+}
+
+func (p *parser) parseColumn() (*columnDef, error) {
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	col := &columnDef{name: name}
+	names, err := p.parseNames()
+	if err != nil {
+		return nil, err
+	}
+	col.typ = strings.Join(names, ".")
+	if p.peek().is("(") {
+		p.next()
+		for !p.peek().is(")") {
+			t := p.next()
+			n, err := strconv.ParseInt(t.value, 10, 64)
+			if t.kind != tokenNumber || err != nil {
+				return nil, unexpected(t, "number")
+			}
+			col.args = append(col.args, n)
+			if p.peek().is(",") {
+				p.next()
+			}
+		}
+		p.next()
+	}
+	for p.peek().is("[") && p.peekAt(1).is("]") {
+		p.next()
+		p.next()
+		col.array = true
+	}
+	if !p.peek().is("[") {
+		return col, nil
+	}
+	settings, err := p.parseSettings()
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range settings {
+		switch s.key {
+		case "pk", "primary key":
+			col.pk = true
+		case "not null":
+			col.notNull = true
+		case "null":
+			col.notNull = false
+		case "unique":
+			col.unique = true
+		case "increment":
+			col.serial = true
+		case "note":
+			if col.note, err = s.stringValue(); err != nil {
+				return nil, err
+			}
+		case "default":
+			if col.defValue, err = s.defaultValue(); err != nil {
+				return nil, err
+			}
+		case "ref":
+			if col.ref, err = s.refValue(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return col, nil
+}
+
+func (p *parser) parseIndices(td *tableDef) error {
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for !p.peek().is("}") {
+		idx := &indexDef{}
+		expression := false
+		switch t := p.next(); {
+		case t.is("("):
+			for !p.peek().is(")") {
+				c := p.next()
+				switch c.kind {
+				case tokenIdent:
+					idx.columns = append(idx.columns, c.value)
+				case tokenExpr:
+					expression = true
+				default:
+					return unexpected(c, "column")
+				}
+				if p.peek().is(",") {
+					p.next()
+				}
+			}
+			p.next()
+		case t.kind == tokenIdent:
+			idx.columns = append(idx.columns, t.value)
+		case t.kind == tokenExpr:
+			expression = true
+		default:
+			return unexpected(t, "index")
+		}
+		if p.peek().is("[") {
+			settings, err := p.parseSettings()
+			if err != nil {
+				return err
+			}
+			for _, s := range settings {
+				switch s.key {
+				case "pk", "primary key":
+					idx.pk = true
+				case "unique":
+					idx.unique = true
+				case "name":
+					if idx.name, err = s.stringValue(); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		if !expression {
+			td.indices = append(td.indices, idx)
+		}
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) parseRef() error {
+	name := ""
+	if p.peek().kind == tokenIdent {
+		name = p.next().value
+	}
+	if p.peek().is(":") {
+		p.next()
+		ref, err := p.parseRelationship(name)
+		if err != nil {
+			return err
+		}
+		p.refs = append(p.refs, ref)
+		return nil
+	}
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for !p.peek().is("}") {
+		ref, err := p.parseRelationship(name)
+		if err != nil {
+			return err
+		}
+		p.refs = append(p.refs, ref)
+	}
+	p.next()
+	return nil
+}
+
+// parseRelationship parses "endpoint op endpoint [settings]".
+func (p *parser) parseRelationship(name string) (*refDef, error) {
+	line := p.peek().line
+	left, err := p.parseEndpoint()
+	if err != nil {
+		return nil, err
+	}
+	op := p.next()
+	right, err := p.parseEndpoint()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().is("[") {
+		if _, err = p.parseSettings(); err != nil {
+			return nil, err
+		}
+	}
+	return newRefDef(name, op, left, right, line)
+}
+
+func newRefDef(name string, op token, left endpoint, right endpoint, line int) (*refDef, error) {
+	switch {
+	case op.is(">"), op.is("-"):
+		return &refDef{name: name, from: left, to: right, line: line}, nil
+	case op.is("<"):
+		return &refDef{name: name, from: right, to: left, line: line}, nil
+	case op.is("<>"):
+		return nil, fmt.Errorf("Many-to-many relationship at line %v is not supported.", line)
+	}
+	return nil, unexpected(op, "relationship")
+}
+
+func (p *parser) parseEndpoint() (endpoint, error) {
+	var e endpoint
+	names, err := p.parseNames()
+	if err != nil {
+		return e, err
+	}
+	if p.peek().is(".") {
+		p.next()
+		p.next()
+		for !p.peek().is(")") {
+			col, err := p.ident()
+			if err != nil {
+				return e, err
+			}
+			e.columns = append(e.columns, col)
+			if p.peek().is(",") {
+				p.next()
+			}
+		}
+		p.next()
+		e.table = names
+	} else {
+		if len(names) < 2 {
+			return e, fmt.Errorf("Column of '%v' at line %v is not specified.", names[0], p.peek().line)
+		}
+		e.table = names[:len(names)-1]
+		e.columns = names[len(names)-1:]
+	}
+	if len(e.table) > 2 {
+		return e, fmt.Errorf("Table name '%v' at line %v is invalid.", strings.Join(e.table, "."), p.peek().line)
+	}
+	return e, nil
+}
+
+// setting is a setting in brackets.
+// key is words before colon in lower case.
+type setting struct {
+	key   string
+	value []token
+	line  int
+}
+
+func (p *parser) parseSettings() ([]setting, error) {
+	p.next()
+	settings := make([]setting, 0, 4)
+	for {
+		s := setting{line: p.peek().line}
+		words := make([]string, 0, 2)
+		for !p.peek().is(":") && !p.peek().is(",") && !p.peek().is("]") {
+			t := p.next()
+			if t.kind == tokenEOF {
+				return nil, unexpected(t, "]")
+			}
+			words = append(words, strings.ToLower(t.value))
+		}
+		s.key = strings.Join(words, " ")
+		if p.peek().is(":") {
+			p.next()
+			depth := 0
+			for depth > 0 || (!p.peek().is(",") && !p.peek().is("]")) {
+				t := p.next()
+				switch {
+				case t.kind == tokenEOF:
+					return nil, unexpected(t, "]")
+				case t.is("("):
+					depth++
+				case t.is(")"):
+					depth--
+				}
+				s.value = append(s.value, t)
+			}
+		}
+		settings = append(settings, s)
+		if p.next().is("]") {
+			return settings, nil
+		}
+	}
+}
+
+func (s setting) stringValue() (string, error) {
+	if len(s.value) != 1 || s.value[0].kind != tokenString {
+		return "", fmt.Errorf("Value of '%v' at line %v should be string.", s.key, s.line)
+	}
+	return s.value[0].value, nil
+}
+
+// defaultValue returns default value as SQL expression.
+func (s setting) defaultValue() (string, error) {
+	if len(s.value) == 2 && s.value[0].is("-") && s.value[1].kind == tokenNumber {
+		return "-" + s.value[1].value, nil
+	}
+	if len(s.value) != 1 {
+		return "", fmt.Errorf("Default value at line %v is invalid.", s.line)
+	}
+	v := s.value[0]
+	switch {
+	case v.kind == tokenString:
+		return "'" + strings.Replace(v.value, "'", "''", -1) + "'", nil
+	case v.is("null"):
+		return "", nil
+	}
+	return v.value, nil
+}
+
+// refValue returns relationship of inline ref.
+// From side is left as empty, and it is completed by column.
+func (s setting) refValue() (*refDef, error) {
+	if len(s.value) < 2 {
+		return nil, fmt.Errorf("Ref at line %v is invalid.", s.line)
+	}
+	p := &parser{tokens: append(s.value[1:], token{kind: tokenEOF, line: s.line})}
+	right, err := p.parseEndpoint()
+	if err != nil {
+		return nil, err
+	}
+	return newRefDef("", s.value[0], endpoint{}, right, s.line)
+}
+
+// complete fills empty endpoint of inline ref with column.
+func (r *refDef) complete(td *tableDef, column string) {
+	e := endpoint{table: []string{td.schema, td.name}, columns: []string{column}}
+	if len(r.from.table) == 0 {
+		r.from = e
+	} else {
+		r.to = e
+	}
+}
+
+func (p *parser) build() (*dbmodel.Database, error) {
+	db := dbmodel.NewDatabase(p.project)
+	aliases := make(map[string]*dbmodel.Table, len(p.tables))
+	for _, td := range p.tables {
+		if _, ok := db.FindTable(td.schema, td.name); ok {
+			return nil, fmt.Errorf("Table '%v.%v' at line %v is duplicated.", td.schema, td.name, td.line)
+		}
+		tbl, err := td.table()
+		if err != nil {
+			return nil, err
+		}
+		db.AddTable(tbl)
+		if td.alias != "" {
+			aliases[td.alias] = tbl
+		}
+		for _, col := range td.columns {
+			if col.ref != nil {
+				p.refs = append(p.refs, col.ref)
+			}
+		}
+	}
+
+	find := func(e endpoint, line int) (*dbmodel.Table, []*dbmodel.Column, error) {
+		var tbl *dbmodel.Table
+		ok := false
+		if len(e.table) == 1 {
+			if tbl, ok = aliases[e.table[0]]; !ok {
+				tbl, ok = db.FindTable(p.defaultSchema, e.table[0])
+			}
+		} else {
+			tbl, ok = db.FindTable(e.table[0], e.table[1])
+		}
+		if !ok {
+			return nil, nil, fmt.Errorf("Table '%v' of ref at line %v is not found.", strings.Join(e.table, "."), line)
+		}
+		cols := make([]*dbmodel.Column, 0, len(e.columns))
+		for _, name := range e.columns {
+			col, ok := tbl.FindColumn(name)
+			if !ok {
+				return nil, nil, fmt.Errorf("Column '%v' of ref at line %v is not found.", name, line)
+			}
+			cols = append(cols, col)
+		}
+		return tbl, cols, nil
+	}
+
+	for _, ref := range p.refs {
+		from, fromCols, err := find(ref.from, ref.line)
+		if err != nil {
+			return nil, err
+		}
+		to, toCols, err := find(ref.to, ref.line)
+		if err != nil {
+			return nil, err
+		}
+		if len(fromCols) != len(toCols) {
+			return nil, fmt.Errorf("Columns of ref at line %v are not paired.", ref.line)
+		}
+		name := ref.name
+		if name == "" {
+			name = objectName(from.Name(), ref.from.columns, "fkey")
+		}
+		fk := dbmodel.NewForeignKey(from.Schema(), from.Name(), name)
+		for i := range fromCols {
+			cr := dbmodel.NewColumnReference(fromCols[i], toCols[i])
+			fk.AddColumnReference(&cr)
+		}
+		from.AddForeignKey(&fk)
+		to.AddReferencedKey(&fk)
+	}
+	dbmodel.LinkTables(db.Tables())
+	return &db, nil
+}
+
+func (td *tableDef) table() (*dbmodel.Table, error) {
+	tbl := dbmodel.NewTable(td.schema, td.name, td.note)
+	pkNames := make([]string, 0, 2)
+	for _, col := range td.columns {
+		if col.pk {
+			pkNames = append(pkNames, col.name)
+		}
+	}
+	for _, idx := range td.indices {
+		if idx.pk {
+			if len(pkNames) > 0 {
+				return nil, fmt.Errorf("Primary key of table '%v.%v' is duplicated.", td.schema, td.name)
+			}
+			pkNames = idx.columns
+		}
+	}
+	pkPositions := make(map[string]int64, len(pkNames))
+	for i, name := range pkNames {
+		pkPositions[name] = int64(i + 1)
+	}
+
+	for _, cd := range td.columns {
+		typ, size := normalizeType(cd)
+		nullable := !cd.notNull && pkPositions[cd.name] == 0
+		defValue := cd.defValue
+		if cd.serial && defValue == "" {
+			defValue = "nextval('" + objectName(td.name, []string{cd.name}, "seq") + "'::regclass)"
+			nullable = false
+		}
+		col := dbmodel.NewColumn(td.schema, td.name, cd.name, cd.note, typ, size, nullable, defValue, pkPositions[cd.name])
+		tbl.AddColumn(&col)
+	}
+
+	addIndex := func(name string, unique bool, columns []string) error {
+		idx := dbmodel.NewIndex(td.schema, td.name, name, unique)
+		for _, c := range columns {
+			col, ok := tbl.FindColumn(c)
+			if !ok {
+				return fmt.Errorf("Column '%v' of index '%v' is not found.", c, name)
+			}
+			idx.AddColumn(col)
+		}
+		tbl.AddIndex(&idx)
+		return nil
+	}
+	if len(pkNames) > 0 {
+		if err := addIndex(td.name+"_pkey", true, pkNames); err != nil {
+			return nil, err
+		}
+	}
+	for _, cd := range td.columns {
+		if cd.unique {
+			if err := addIndex(objectName(td.name, []string{cd.name}, "key"), true, []string{cd.name}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, idx := range td.indices {
+		if idx.pk {
+			continue
+		}
+		name := idx.name
+		if name == "" {
+			suffix := "idx"
+			if idx.unique {
+				suffix = "key"
+			}
+			name = objectName(td.name, idx.columns, suffix)
+		}
+		if err := addIndex(name, idx.unique, idx.columns); err != nil {
+			return nil, err
+		}
+	}
+	return &tbl, nil
+}
+
+// normalizeType returns PostgreSQL's internal type name and size of column.
+func normalizeType(cd *columnDef) (string, dbmodel.Size) {
+	typ := strings.ToLower(cd.typ)
+	if t, ok := serialTypes[typ]; ok {
+		typ = t
+		cd.serial = true
+	}
+	if t, ok := typeAliases[typ]; ok {
+		typ = t
+	}
+	if cd.array {
+		return "_" + typ, dbmodel.NewSize(nullInt(-1), nullInt(-1), nullInt(-1))
+	}
+	switch len(cd.args) {
+	case 0:
+		if size, ok := implicitSizes[typ]; ok {
+			return typ, size
+		}
+	case 1:
+		if lengthTypes[typ] {
+			return typ, dbmodel.NewSize(nullInt(cd.args[0]), nullInt(-1), nullInt(-1))
+		}
+		if typ == "numeric" {
+			return typ, dbmodel.NewSize(nullInt(-1), nullInt(cd.args[0]), nullInt(0))
+		}
+		return typ, dbmodel.NewSize(nullInt(-1), nullInt(cd.args[0]), nullInt(-1))
+	default:
+		return typ, dbmodel.NewSize(nullInt(-1), nullInt(cd.args[0]), nullInt(cd.args[1]))
+	}
+	return typ, dbmodel.NewSize(nullInt(-1), nullInt(-1), nullInt(-1))
+}
+
+// objectName returns name of object as PostgreSQL names it. (eg. posts_user_id_fkey)
+func objectName(table string, columns []string, suffix string) string {
+	return table + "_" + strings.Join(columns, "_") + "_" + suffix
+}
+
+// nullInt returns sql.NullInt64, and negative value is treated as null.
+func nullInt(n int64) sql.NullInt64 {
+	if n < 0 {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: n, Valid: true}
+}
@@ -0,0 +1,266 @@
+package dbml
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+const testDBML = `
+Project blog {
+  database_type: 'PostgreSQL'
+  Note: 'blog service'
+}
+
+// users of blog
+Table users as U [note: 'user accounts'] {
+  id integer [pk, increment]
+  email "character varying"(255) [not null, unique, note: 'login id']
+  name varchar(100) [default: 'anonymous']
+  score decimal(5) [default: -1]
+  created_at timestamptz [default: ` + "`now()`" + `]
+}
+
+/*
+ * posts are written by users
+ */
+Table bar.posts {
+  id bigserial [primary key]
+  user_id int [ref: > U.id]
+  title text [null]
+  tags varchar[]
+
+  indexes {
+    (user_id, title) [unique]
+    title [name: 'posts_title_index']
+    ` + "`lower(title)`" + `
+  }
+
+  Note {
+    'posts
+of users'
+  }
+}
+
+Table bar.comments {
+  post_id bigint
+  seq int
+  body text
+
+  indexes {
+    (post_id, seq) [pk]
+  }
+}
+
+Ref comments_post_fk: bar.comments.post_id > bar.posts.id [delete: cascade]
+
+Enum status {
+  active
+  inactive
+}
+
+TableGroup blog {
+  users
+  bar.posts
+}
+`
+
+func readTestDBML(t *testing.T) *dbmodel.Database {
+	db, err := Read(strings.NewReader(testDBML), "public")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestRead(t *testing.T) {
+	db := readTestDBML(t)
+	if expected, actual := "blog", db.Name(); actual != expected {
+		t.Errorf("Name() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := 3, len(db.Tables()); actual != expected {
+		t.Fatalf("Table count is invalid. expected: %v, actual: %v", expected, actual)
+	}
+	usr, ok := db.FindTable("public", "users")
+	if !ok {
+		t.Fatal("Table without schema should be placed in default schema.")
+	}
+	if expected, actual := "user accounts", usr.Comment(); actual != expected {
+		t.Errorf("Comment() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	pst, _ := db.FindTable("bar", "posts")
+	if expected, actual := "posts\nof users", pst.Comment(); actual != expected {
+		t.Errorf("Comment() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestReadColumns(t *testing.T) {
+	db := readTestDBML(t)
+	usr, _ := db.FindTable("public", "users")
+	pst, _ := db.FindTable("bar", "posts")
+	tests := []struct {
+		tbl          *dbmodel.Table
+		column       string
+		dataType     string
+		size         string
+		nullable     bool
+		defaultValue string
+		pkPosition   int64
+		comment      string
+	}{
+		{usr, "id", "int4", "32, 0", false, "nextval('users_id_seq'::regclass)", 1, ""},
+		{usr, "email", "varchar", "255", false, "", 0, "login id"},
+		{usr, "name", "varchar", "100", true, "'anonymous'", 0, ""},
+		{usr, "score", "numeric", "5, 0", true, "-1", 0, ""},
+		{usr, "created_at", "timestamptz", "6", true, "now()", 0, ""},
+		{pst, "id", "int8", "64, 0", false, "nextval('posts_id_seq'::regclass)", 1, ""},
+		{pst, "user_id", "int4", "32, 0", true, "", 0, ""},
+		{pst, "title", "text", "", true, "", 0, ""},
+		{pst, "tags", "_varchar", "", true, "", 0, ""},
+	}
+	for _, test := range tests {
+		col, ok := test.tbl.FindColumn(test.column)
+		if !ok {
+			t.Errorf("Column '%v' is not found.", test.column)
+			continue
+		}
+		if col.DataType() != test.dataType || col.Size().String() != test.size || col.IsNullable() != test.nullable ||
+			col.DefaultValue() != test.defaultValue || col.PrimaryKeyPosition() != test.pkPosition || col.Comment() != test.comment {
+			t.Errorf("Column '%v' is invalid. expected: %+v, actual: %v %v %v %v %v %v", test.column, test,
+				col.DataType(), col.Size(), col.IsNullable(), col.DefaultValue(), col.PrimaryKeyPosition(), col.Comment())
+		}
+	}
+}
+
+func TestReadIndices(t *testing.T) {
+	db := readTestDBML(t)
+	tests := []struct {
+		schema   string
+		table    string
+		expected []string
+	}{
+		{"public", "users", []string{"users_pkey UNIQUE (id)", "users_email_key UNIQUE (email)"}},
+		{"bar", "posts", []string{"posts_pkey UNIQUE (id)", "posts_user_id_title_key UNIQUE (user_id, title)", "posts_title_index (title)"}},
+		{"bar", "comments", []string{"comments_pkey UNIQUE (post_id, seq)"}},
+	}
+	for _, test := range tests {
+		tbl, _ := db.FindTable(test.schema, test.table)
+		actual := make([]string, 0, len(tbl.Indices()))
+		for _, idx := range tbl.Indices() {
+			names := make([]string, 0, len(idx.Columns()))
+			for _, col := range idx.Columns() {
+				names = append(names, col.Name())
+			}
+			s := idx.Name()
+			if idx.IsUnique() {
+				s += " UNIQUE"
+			}
+			actual = append(actual, s+" ("+strings.Join(names, ", ")+")")
+		}
+		if strings.Join(actual, "; ") != strings.Join(test.expected, "; ") {
+			t.Errorf("Indices of %v are invalid. expected: %v, actual: %v", test.table, test.expected, actual)
+		}
+	}
+	cmt, _ := db.FindTable("bar", "comments")
+	if col, _ := cmt.FindColumn("seq"); col.PrimaryKeyPosition() != 2 || col.IsNullable() {
+		t.Errorf("Column of primary key index should be not null primary key column. (%v, %v)", col.PrimaryKeyPosition(), col.IsNullable())
+	}
+}
+
+func TestReadRefs(t *testing.T) {
+	db := readTestDBML(t)
+	usr, _ := db.FindTable("public", "users")
+	pst, _ := db.FindTable("bar", "posts")
+	cmt, _ := db.FindTable("bar", "comments")
+
+	if len(pst.ForeignKeys()) != 1 || len(cmt.ForeignKeys()) != 1 {
+		t.Fatalf("Foreign key count is invalid. posts: %v, comments: %v", len(pst.ForeignKeys()), len(cmt.ForeignKeys()))
+	}
+	fk := pst.ForeignKeys()[0]
+	if expected, actual := "posts_user_id_fkey", fk.Name(); actual != expected {
+		t.Errorf("Name() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if fk.ReferencedTable() != usr {
+		t.Error("ReferencedTable() should return table referenced by alias.")
+	}
+	if len(usr.ReferencedKeys()) != 1 || usr.ReferencedKeys()[0] != fk {
+		t.Error("ReferencedKeys() should contain foreign key of referencing table.")
+	}
+	fk = cmt.ForeignKeys()[0]
+	if expected, actual := "comments_post_fk", fk.Name(); actual != expected {
+		t.Errorf("Name() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if cr := fk.ColumnReferences()[0]; cr.From().Name() != "post_id" || cr.To().Name() != "id" || cr.To().TableName() != "posts" {
+		t.Errorf("ColumnReferences() returns invalid value. (%v -> %v.%v)", cr.From().Name(), cr.To().TableName(), cr.To().Name())
+	}
+}
+
+func TestReadOneToManyRef(t *testing.T) {
+	src := `
+Table users {
+  id int [pk]
+}
+Table posts {
+  user_id int
+}
+Ref: users.id < posts.user_id
+`
+	db, err := Read(strings.NewReader(src), "public")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pst, _ := db.FindTable("public", "posts")
+	if len(pst.ForeignKeys()) != 1 {
+		t.Fatal("Right side of '<' should have foreign key.")
+	}
+}
+
+func TestReadInvalidDBML(t *testing.T) {
+	tests := []struct {
+		src      string
+		expected string
+	}{
+		{"Table users {\n  id int\n", "Unexpected end of input at line 3, name is expected."},
+		{"Table users {\n  id int\n}\nRef: users.id > posts.user_id", "Table 'posts' of ref at line 4 is not found."},
+		{"Table users {\n  id int\n}\nRef: users.id > users.code", "Column 'code' of ref at line 4 is not found."},
+		{"Table users {\n  id int\n}\nRef: users.id <> users.id", "Many-to-many relationship at line 4 is not supported."},
+		{"Table users {\n  id int\n}\nTable users {\n  id int\n}", "Table 'public.users' at line 4 is duplicated."},
+		{"Table users {\n  id int [note: 'abc]\n}", "Quoted value at line 2 is not closed."},
+		{"Table users {\n  id int\n  indexes {\n    code\n  }\n}", "Column 'code' of index 'users_code_idx' is not found."},
+	}
+	for _, test := range tests {
+		_, err := Read(strings.NewReader(test.src), "public")
+		if err == nil {
+			t.Errorf("Read() should raise error for %q.", test.src)
+			continue
+		}
+		if actual := err.Error(); actual != test.expected {
+			t.Errorf("Read() raises invalid error. expected: %v, actual: %v", test.expected, actual)
+		}
+	}
+}
+
+func TestQuoteType(t *testing.T) {
+	tests := []struct {
+		typ      string
+		expected string
+	}{
+		{"int4", "int4"},
+		{"numeric(10, 2)", "numeric(10, 2)"},
+		{"double precision", `"double precision"`},
+		{"character varying(10)", `"character varying"(10)`},
+		{"character varying[]", `"character varying"[]`},
+	}
+	for _, test := range tests {
+		if actual := quoteType(test.typ); actual != test.expected {
+			t.Errorf("quoteType() returns invalid value. expected: %v, actual: %v", test.expected, actual)
+		}
+	}
+}
+
+func TestReadWithoutDefaultSchema(t *testing.T) {
+	if _, err := Read(strings.NewReader(testDBML), ""); err != dbmodel.ErrSchemaEmpty {
+		t.Errorf("Read() should raise ErrSchemaEmpty. actual: %v", err)
+	}
+}
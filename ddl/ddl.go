@@ -0,0 +1,212 @@
+// Package ddl generates DDL statements from tables loaded by dbmodel.
+package ddl
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+// Dialect is SQL dialect that renders database specific parts of DDL.
+type Dialect interface {
+	// Quote returns identifier quoted if it is needed.
+	Quote(name string) string
+	// TableName returns qualified table name.
+	TableName(schema string, name string) string
+	// ColumnType returns data type of column with size.
+	ColumnType(col *dbmodel.Column) string
+	// ColumnDefinition returns column definition in CREATE TABLE statement.
+	ColumnDefinition(col *dbmodel.Column) string
+	// ConstraintDefinition returns table constraint definition in CREATE TABLE statement.
+	// If dialect does not support constraint, ConstraintDefinition returns empty.
+	ConstraintDefinition(con *dbmodel.Constraint) string
+	// TableComment returns statement that sets comment of table.
+	// If comment of table is empty, returned statement removes comment.
+	TableComment(tbl *dbmodel.Table) string
+	// ColumnComment returns statement that sets comment of column.
+	// If comment of column is empty, returned statement removes comment.
+	ColumnComment(col *dbmodel.Column) string
+	// AlterColumn returns statements that change data type, size, nullability and default value of column from old to new.
+	AlterColumn(old *dbmodel.Column, new *dbmodel.Column) []string
+	// IsNarrowing returns true if changing data type or size of column from old to new may lose data.
+	IsNarrowing(old *dbmodel.Column, new *dbmodel.Column) bool
+	// DropPrimaryKey returns statement that drops primary key of table.
+	DropPrimaryKey(tbl *dbmodel.Table) string
+	// DropForeignKey returns statement that drops foreign key.
+	DropForeignKey(fk *dbmodel.ForeignKey) string
+	// DropConstraint returns statement that drops constraint.
+	DropConstraint(con *dbmodel.Constraint) string
+	// DropIndex returns statement that drops index.
+	DropIndex(idx *dbmodel.Index) string
+}
+
+// CreateStatements returns statements that create given tables.
+// Statements are ordered by CREATE TABLE, CREATE INDEX and COMMENT of each table,
+// and then ALTER TABLE ADD FOREIGN KEY of all tables, so that referenced tables exist.
+// Tables that are not KindTable (eg. views) are skipped, because their definitions are not loaded.
+// Statements do not have terminator.
+func CreateStatements(d Dialect, tbls []*dbmodel.Table) []string {
+	stmts := make([]string, 0, len(tbls)*3)
+	for _, tbl := range tbls {
+		if tbl.Kind() != dbmodel.KindTable {
+			continue
+		}
+		stmts = append(stmts, CreateTable(d, tbl))
+		for _, idx := range tbl.Indices() {
+			if !isImplicitIndex(tbl, idx) {
+				stmts = append(stmts, CreateIndex(d, idx))
+			}
+		}
+		stmts = append(stmts, commentStatements(d, tbl)...)
+	}
+	for _, tbl := range tbls {
+		if tbl.Kind() != dbmodel.KindTable {
+			continue
+		}
+		for _, fk := range tbl.ForeignKeys() {
+			stmts = append(stmts, AddForeignKey(d, fk))
+		}
+	}
+	return stmts
+}
+
+// Write writes statements returned by CreateStatements.
+// Each statement is terminated by ";" and separated by blank line.
+// If tables have constraints that dialect does not support, Write returns error without writing.
+func Write(w io.Writer, d Dialect, tbls []*dbmodel.Table) error {
+	for _, tbl := range tbls {
+		if tbl.Kind() != dbmodel.KindTable {
+			continue
+		}
+		if err := checkConstraints(d, tbl.Constraints()); err != nil {
+			return err
+		}
+	}
+	return WriteStatements(w, CreateStatements(d, tbls))
+}
+
+// WriteStatements writes given statements terminated by ";" and separated by blank line.
+func WriteStatements(w io.Writer, stmts []string) error {
+	for i, stmt := range stmts {
+		sep := ";\n\n"
+		if i == len(stmts)-1 {
+			sep = ";\n"
+		}
+		if _, err := io.WriteString(w, stmt+sep); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateTable returns CREATE TABLE statement with columns, primary key and constraints.
+// Constraints that dialect does not support are skipped.
+func CreateTable(d Dialect, tbl *dbmodel.Table) string {
+	defs := make([]string, 0, len(tbl.Columns())+len(tbl.Constraints())+1)
+	for _, col := range tbl.Columns() {
+		defs = append(defs, d.ColumnDefinition(col))
+	}
+	if pk := PrimaryKeyColumns(tbl); len(pk) > 0 {
+		defs = append(defs, "PRIMARY KEY ("+quoteColumns(d, pk)+")")
+	}
+	for _, con := range tbl.Constraints() {
+		if def := d.ConstraintDefinition(con); def != "" {
+			defs = append(defs, def)
+		}
+	}
+	return "CREATE TABLE " + d.TableName(tbl.Schema(), tbl.Name()) + " (\n    " + strings.Join(defs, ",\n    ") + "\n)"
+}
+
+// CreateIndex returns CREATE INDEX statement.
+func CreateIndex(d Dialect, idx *dbmodel.Index) string {
+	unique := ""
+	if idx.IsUnique() {
+		unique = "UNIQUE "
+	}
+	return "CREATE " + unique + "INDEX " + d.Quote(idx.Name()) + " ON " + d.TableName(idx.Schema(), idx.TableName()) + " (" + quoteColumns(d, idx.Columns()) + ")"
+}
+
+// AddForeignKey returns ALTER TABLE statement that adds foreign key.
+func AddForeignKey(d Dialect, fk *dbmodel.ForeignKey) string {
+	froms := make([]*dbmodel.Column, 0, len(fk.ColumnReferences()))
+	tos := make([]*dbmodel.Column, 0, len(fk.ColumnReferences()))
+	for _, cr := range fk.ColumnReferences() {
+		froms = append(froms, cr.From())
+		tos = append(tos, cr.To())
+	}
+	ref := ""
+	if len(tos) > 0 {
+		ref = d.TableName(tos[0].Schema(), tos[0].TableName())
+	}
+	return "ALTER TABLE " + d.TableName(fk.Schema(), fk.TableName()) +
+		" ADD CONSTRAINT " + d.Quote(fk.Name()) +
+		" FOREIGN KEY (" + quoteColumns(d, froms) + ") REFERENCES " + ref + " (" + quoteColumns(d, tos) + ")"
+}
+
+// PrimaryKeyColumns returns primary key columns of table in order of PrimaryKeyPosition.
+func PrimaryKeyColumns(tbl *dbmodel.Table) []*dbmodel.Column {
+	cols := make([]*dbmodel.Column, 0, 2)
+	for pos := int64(1); ; pos++ {
+		found := false
+		for _, col := range tbl.Columns() {
+			if col.PrimaryKeyPosition() == pos {
+				cols = append(cols, col)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return cols
+		}
+	}
+}
+
+func commentStatements(d Dialect, tbl *dbmodel.Table) []string {
+	stmts := make([]string, 0, len(tbl.Columns())+1)
+	if tbl.Comment() != "" {
+		stmts = append(stmts, d.TableComment(tbl))
+	}
+	for _, col := range tbl.Columns() {
+		if col.Comment() != "" {
+			stmts = append(stmts, d.ColumnComment(col))
+		}
+	}
+	return stmts
+}
+
+// isImplicitIndex returns true if index is created by primary key or constraint implicitly.
+func isImplicitIndex(tbl *dbmodel.Table, idx *dbmodel.Index) bool {
+	if _, ok := tbl.FindConstraint(idx.Name()); ok {
+		return true
+	}
+	pk := PrimaryKeyColumns(tbl)
+	if !idx.IsUnique() || len(pk) == 0 || len(pk) != len(idx.Columns()) {
+		return false
+	}
+	for i, col := range idx.Columns() {
+		if col.Name() != pk[i].Name() {
+			return false
+		}
+	}
+	return true
+}
+
+// checkConstraints returns error if dialect does not support any of given constraints.
+func checkConstraints(d Dialect, cons []*dbmodel.Constraint) error {
+	for _, con := range cons {
+		if d.ConstraintDefinition(con) == "" {
+			return fmt.Errorf("%v constraint '%v' of table '%v.%v' is not supported.", con.Kind(), con.Name(), con.Schema(), con.TableName())
+		}
+	}
+	return nil
+}
+
+func quoteColumns(d Dialect, cols []*dbmodel.Column) string {
+	names := make([]string, 0, len(cols))
+	for _, col := range cols {
+		names = append(names, d.Quote(col.Name()))
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,107 @@
+package ddl
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+func TestCreateStatements(t *testing.T) {
+	stmts := CreateStatements(Postgres, loadTestTables(t))
+	expected := []string{
+		`CREATE TABLE foo.users (
+    id int4 NOT NULL,
+    email varchar(255) NOT NULL,
+    name text,
+    PRIMARY KEY (id)
+)`,
+		`CREATE UNIQUE INDEX users_email_key ON foo.users (email)`,
+		`COMMENT ON TABLE foo.users IS 'user accounts'`,
+		`COMMENT ON COLUMN foo.users.email IS 'login id'`,
+		`CREATE TABLE foo.posts (
+    id int4 NOT NULL,
+    user_id int4,
+    body text DEFAULT ''::text,
+    PRIMARY KEY (id),
+    CONSTRAINT posts_user_id_check CHECK (user_id > 0)
+)`,
+		`CREATE TABLE foo.comments (
+    id serial NOT NULL,
+    post_id int4 NOT NULL,
+    title varchar(100),
+    PRIMARY KEY (id),
+    CONSTRAINT comments_post_id_title_key UNIQUE (post_id, title)
+)`,
+		`CREATE INDEX comments_title_idx ON foo.comments (title)`,
+		`COMMENT ON TABLE foo.comments IS 'user''s comments'`,
+		`COMMENT ON COLUMN foo.comments.title IS 'comment title'`,
+		`ALTER TABLE foo.posts ADD CONSTRAINT posts_user_id_fkey FOREIGN KEY (user_id) REFERENCES foo.users (id)`,
+		`ALTER TABLE foo.comments ADD CONSTRAINT comments_post_id_fkey FOREIGN KEY (post_id) REFERENCES foo.posts (id)`,
+	}
+	if len(stmts) != len(expected) {
+		t.Fatalf("Statement count is invalid. expected: %v, actual: %v\n%v", len(expected), len(stmts), strings.Join(stmts, "\n"))
+	}
+	for i, stmt := range stmts {
+		if stmt != expected[i] {
+			t.Errorf("Statement is invalid.\nexpected:\n%v\nactual:\n%v", expected[i], stmt)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, Postgres, loadTestTables(t)[0:1]); err != nil {
+		t.Fatal(err)
+	}
+	expected := "CREATE TABLE foo.users (\n    id int4 NOT NULL,\n    email varchar(255) NOT NULL,\n    name text,\n    PRIMARY KEY (id)\n);\n\n" +
+		"CREATE UNIQUE INDEX users_email_key ON foo.users (email);\n\n" +
+		"COMMENT ON TABLE foo.users IS 'user accounts';\n\n" +
+		"COMMENT ON COLUMN foo.users.email IS 'login id';\n"
+	if actual := buf.String(); actual != expected {
+		t.Errorf("Write writes invalid value.\nexpected:\n%v\nactual:\n%v", expected, actual)
+	}
+}
+
+func TestWriteWithUnsupportedConstraint(t *testing.T) {
+	tbl := dbmodel.NewTable("foo", "bar", "")
+	con := dbmodel.NewConstraint("foo", "bar", "bar_during_excl", "EXCLUDE", "during WITH &&")
+	tbl.AddConstraint(&con)
+	var buf bytes.Buffer
+	if err := Write(&buf, Postgres, []*dbmodel.Table{&tbl}); err == nil {
+		t.Error("Write should return error for unsupported constraint.")
+	}
+	if buf.Len() > 0 {
+		t.Errorf("Write should not write anything. actual: %v", buf.String())
+	}
+}
+
+func TestPrimaryKeyColumns(t *testing.T) {
+	tbl := dbmodel.NewTable("foo", "bar", "")
+	b := dbmodel.NewColumn("foo", "bar", "b", "", "int4", dbmodel.Size{}, false, "", 2)
+	a := dbmodel.NewColumn("foo", "bar", "a", "", "int4", dbmodel.Size{}, false, "", 1)
+	c := dbmodel.NewColumn("foo", "bar", "c", "", "int4", dbmodel.Size{}, true, "", 0)
+	tbl.AddColumn(&b)
+	tbl.AddColumn(&a)
+	tbl.AddColumn(&c)
+	cols := PrimaryKeyColumns(&tbl)
+	if len(cols) != 2 || cols[0] != &a || cols[1] != &b {
+		t.Errorf("PrimaryKeyColumns returns invalid columns. (%v)", cols)
+	}
+}
+
+// loadTestTables loads tables from testdata/schema.yml.
+func loadTestTables(t *testing.T) []*dbmodel.Table {
+	f, err := os.Open("testdata/schema.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	db, err := dbmodel.LoadYAML(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db.Tables()
+}
@@ -0,0 +1,302 @@
+package ddl
+
+import (
+	"io"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+// Step is a statement of migration.
+type Step struct {
+	sql         string
+	destructive bool
+}
+
+// SQL returns statement without terminator.
+func (s Step) SQL() string {
+	return s.sql
+}
+
+// IsDestructive returns true if statement may lose data.
+// Dropping table, dropping column and narrowing data type or size of column are destructive.
+// When data type or size of column is narrowed, all statements that alter the column are destructive.
+// Dropping foreign keys, constraints, indices and primary keys is not destructive, because it does not lose data.
+func (s Step) IsDestructive() bool {
+	return s.destructive
+}
+
+// MigrationSteps returns steps that migrate old tables of diff to new tables.
+// Steps are ordered as below, so that every statement can run after previous statements.
+//  1. drop foreign keys, constraints and indices that are removed or changed,
+//     and foreign keys that reference changed primary keys
+//  2. create added tables with their indices
+//  3. add and alter columns, change primary keys and comments of changed tables
+//  4. add constraints and create indices that are added or changed
+//  5. drop removed columns and tables
+//  6. add foreign keys that are added or changed, foreign keys of added tables,
+//     and foreign keys that are dropped for changed primary keys
+//
+// Only steps that may lose data are destructive. (see Step.IsDestructive)
+// Rename candidates of diff are not renamed, but dropped and created.
+func MigrationSteps(d Dialect, diff *dbmodel.SchemaDiff) []*Step {
+	steps := make([]*Step, 0, 10)
+	add := func(destructive bool, stmts ...string) {
+		for _, stmt := range stmts {
+			steps = append(steps, &Step{sql: stmt, destructive: destructive})
+		}
+	}
+	pkRefs := primaryKeyReferences(diff)
+
+	for _, tbl := range diff.RemovedTables() {
+		for _, fk := range tbl.ForeignKeys() {
+			add(false, d.DropForeignKey(fk))
+		}
+	}
+	for _, td := range diff.ChangedTables() {
+		for _, fk := range td.RemovedForeignKeys() {
+			add(false, d.DropForeignKey(fk))
+		}
+		for _, fd := range td.ChangedForeignKeys() {
+			add(false, d.DropForeignKey(fd.Old()))
+		}
+		for _, con := range td.RemovedConstraints() {
+			add(false, d.DropConstraint(con))
+		}
+		for _, cd := range td.ChangedConstraints() {
+			add(false, d.DropConstraint(cd.Old()))
+		}
+		for _, idx := range td.RemovedIndices() {
+			if !isImplicitIndex(td.Old(), idx) {
+				add(false, d.DropIndex(idx))
+			}
+		}
+		for _, id := range td.ChangedIndices() {
+			if !isImplicitIndex(td.Old(), id.Old()) {
+				add(false, d.DropIndex(id.Old()))
+			}
+		}
+	}
+	for _, fk := range pkRefs {
+		add(false, d.DropForeignKey(fk))
+	}
+
+	for _, tbl := range diff.AddedTables() {
+		if tbl.Kind() != dbmodel.KindTable {
+			continue
+		}
+		add(false, CreateTable(d, tbl))
+		for _, idx := range tbl.Indices() {
+			if !isImplicitIndex(tbl, idx) {
+				add(false, CreateIndex(d, idx))
+			}
+		}
+		add(false, commentStatements(d, tbl)...)
+	}
+
+	for _, td := range diff.ChangedTables() {
+		if td.PrimaryKeyChanged() && len(PrimaryKeyColumns(td.Old())) > 0 {
+			add(false, d.DropPrimaryKey(td.Old()))
+		}
+		for _, col := range td.AddedColumns() {
+			add(false, "ALTER TABLE "+d.TableName(col.Schema(), col.TableName())+" ADD COLUMN "+d.ColumnDefinition(col))
+			if col.Comment() != "" {
+				add(false, d.ColumnComment(col))
+			}
+		}
+		for _, cd := range td.ChangedColumns() {
+			narrowing := (cd.TypeChanged() || cd.SizeChanged()) && d.IsNarrowing(cd.Old(), cd.New())
+			add(narrowing, d.AlterColumn(cd.Old(), cd.New())...)
+			if cd.CommentChanged() {
+				add(false, d.ColumnComment(cd.New()))
+			}
+		}
+		if td.PrimaryKeyChanged() {
+			if pk := PrimaryKeyColumns(td.New()); len(pk) > 0 {
+				add(false, "ALTER TABLE "+d.TableName(td.New().Schema(), td.New().Name())+" ADD PRIMARY KEY ("+quoteColumns(d, pk)+")")
+			}
+		}
+		if td.Old().Comment() != td.New().Comment() {
+			add(false, d.TableComment(td.New()))
+		}
+	}
+
+	for _, td := range diff.ChangedTables() {
+		cons := make([]*dbmodel.Constraint, 0, len(td.AddedConstraints())+len(td.ChangedConstraints()))
+		cons = append(cons, td.AddedConstraints()...)
+		for _, cd := range td.ChangedConstraints() {
+			cons = append(cons, cd.New())
+		}
+		for _, con := range cons {
+			if def := d.ConstraintDefinition(con); def != "" {
+				add(false, "ALTER TABLE "+d.TableName(con.Schema(), con.TableName())+" ADD "+def)
+			}
+		}
+		idxs := make([]*dbmodel.Index, 0, len(td.AddedIndices())+len(td.ChangedIndices()))
+		idxs = append(idxs, td.AddedIndices()...)
+		for _, id := range td.ChangedIndices() {
+			idxs = append(idxs, id.New())
+		}
+		for _, idx := range idxs {
+			if !isImplicitIndex(td.New(), idx) {
+				add(false, CreateIndex(d, idx))
+			}
+		}
+	}
+
+	for _, td := range diff.ChangedTables() {
+		for _, col := range td.RemovedColumns() {
+			add(true, "ALTER TABLE "+d.TableName(col.Schema(), col.TableName())+" DROP COLUMN "+d.Quote(col.Name()))
+		}
+	}
+	for _, tbl := range diff.RemovedTables() {
+		if tbl.Kind() == dbmodel.KindTable {
+			add(true, "DROP TABLE "+d.TableName(tbl.Schema(), tbl.Name()))
+		}
+	}
+
+	for _, tbl := range diff.AddedTables() {
+		if tbl.Kind() != dbmodel.KindTable {
+			continue
+		}
+		for _, fk := range tbl.ForeignKeys() {
+			add(false, AddForeignKey(d, fk))
+		}
+	}
+	for _, td := range diff.ChangedTables() {
+		for _, fk := range td.AddedForeignKeys() {
+			add(false, AddForeignKey(d, fk))
+		}
+		for _, fd := range td.ChangedForeignKeys() {
+			add(false, AddForeignKey(d, fd.New()))
+		}
+	}
+	for _, fk := range pkRefs {
+		add(false, AddForeignKey(d, fk))
+	}
+	return steps
+}
+
+// primaryKeyReferences returns kept foreign keys that reference old primary keys of changed tables.
+// They are searched in referenced keys and in foreign keys of changed tables,
+// because referenced keys are loaded from database only with Option.ReferencedKeys.
+func primaryKeyReferences(diff *dbmodel.SchemaDiff) []*dbmodel.ForeignKey {
+	dropped := make(map[string]bool)
+	for _, tbl := range diff.RemovedTables() {
+		for _, fk := range tbl.ForeignKeys() {
+			dropped[foreignKeyKey(fk)] = true
+		}
+	}
+	changedFKs := make([]*dbmodel.ForeignKey, 0, 5)
+	for _, td := range diff.ChangedTables() {
+		for _, fk := range td.RemovedForeignKeys() {
+			dropped[foreignKeyKey(fk)] = true
+		}
+		for _, fd := range td.ChangedForeignKeys() {
+			dropped[foreignKeyKey(fd.Old())] = true
+		}
+		changedFKs = append(changedFKs, td.Old().ForeignKeys()...)
+	}
+
+	fks := make([]*dbmodel.ForeignKey, 0, 5)
+	for _, td := range diff.ChangedTables() {
+		pk := PrimaryKeyColumns(td.Old())
+		if !td.PrimaryKeyChanged() || len(pk) == 0 {
+			continue
+		}
+		candidates := make([]*dbmodel.ForeignKey, 0, len(td.Old().ReferencedKeys())+len(changedFKs))
+		candidates = append(candidates, td.Old().ReferencedKeys()...)
+		candidates = append(candidates, changedFKs...)
+		for _, fk := range candidates {
+			key := foreignKeyKey(fk)
+			if dropped[key] || !referencesColumns(fk, td.Old(), pk) {
+				continue
+			}
+			dropped[key] = true
+			fks = append(fks, fk)
+		}
+	}
+	return fks
+}
+
+// referencesColumns returns true if foreign key references just given columns of table.
+func referencesColumns(fk *dbmodel.ForeignKey, tbl *dbmodel.Table, cols []*dbmodel.Column) bool {
+	if len(fk.ColumnReferences()) != len(cols) {
+		return false
+	}
+	names := make(map[string]bool, len(cols))
+	for _, col := range cols {
+		names[col.Name()] = true
+	}
+	for _, cr := range fk.ColumnReferences() {
+		to := cr.To()
+		if to == nil || to.Schema() != tbl.Schema() || to.TableName() != tbl.Name() || !names[to.Name()] {
+			return false
+		}
+	}
+	return true
+}
+
+func foreignKeyKey(fk *dbmodel.ForeignKey) string {
+	return fk.Schema() + "." + fk.TableName() + "." + fk.Name()
+}
+
+// MigrationStatements returns statements of MigrationSteps.
+// Destructive steps are returned only if allowDestructive is true,
+// and skipped destructive steps are returned as second value.
+func MigrationStatements(d Dialect, diff *dbmodel.SchemaDiff, allowDestructive bool) ([]string, []*Step) {
+	stmts := make([]string, 0, 10)
+	skipped := make([]*Step, 0, 5)
+	for _, step := range MigrationSteps(d, diff) {
+		if step.IsDestructive() && !allowDestructive {
+			skipped = append(skipped, step)
+			continue
+		}
+		stmts = append(stmts, step.SQL())
+	}
+	return stmts, skipped
+}
+
+// WriteMigration writes statements returned by MigrationStatements.
+// Skipped destructive steps are written as SQL comments at the end.
+// If added or changed constraints are not supported by dialect, WriteMigration returns error without writing.
+func WriteMigration(w io.Writer, d Dialect, diff *dbmodel.SchemaDiff, allowDestructive bool) error {
+	for _, tbl := range diff.AddedTables() {
+		if tbl.Kind() != dbmodel.KindTable {
+			continue
+		}
+		if err := checkConstraints(d, tbl.Constraints()); err != nil {
+			return err
+		}
+	}
+	for _, td := range diff.ChangedTables() {
+		if err := checkConstraints(d, td.AddedConstraints()); err != nil {
+			return err
+		}
+		for _, cd := range td.ChangedConstraints() {
+			if err := checkConstraints(d, []*dbmodel.Constraint{cd.New()}); err != nil {
+				return err
+			}
+		}
+	}
+	stmts, skipped := MigrationStatements(d, diff, allowDestructive)
+	if err := WriteStatements(w, stmts); err != nil {
+		return err
+	}
+	if len(skipped) == 0 {
+		return nil
+	}
+	if len(stmts) > 0 {
+		if _, err := io.WriteString(w, "\n"); err != nil {
+			return err
+		}
+	}
+	if _, err := io.WriteString(w, "-- Destructive steps are skipped:\n"); err != nil {
+		return err
+	}
+	for _, step := range skipped {
+		if _, err := io.WriteString(w, "-- "+step.SQL()+";\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
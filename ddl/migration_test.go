@@ -0,0 +1,375 @@
+package ddl
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+const migrationOldYAML = `
+schemas:
+  - name: foo
+    tables:
+      - name: users
+        columns:
+          - name: id
+            type: int4
+            primary_key: 1
+          - name: name
+            type: varchar
+            length: 100
+          - name: age
+            type: int4
+          - name: memo
+            type: text
+        indices:
+          - name: users_pkey
+            unique: true
+            columns: [id]
+          - name: users_name_idx
+            columns: [name]
+      - name: posts
+        columns:
+          - name: id
+            type: int4
+            primary_key: 1
+          - name: user_id
+            type: int4
+        foreign_keys:
+          - name: posts_user_id_fkey
+            columns: [user_id]
+            ref_table: users
+            ref_columns: [id]
+      - name: logs
+        columns:
+          - name: id
+            type: int4
+`
+
+const migrationNewYAML = `
+schemas:
+  - name: foo
+    tables:
+      - name: users
+        comment: accounts
+        columns:
+          - name: id
+            type: int4
+            primary_key: 1
+          - name: name
+            type: varchar
+            length: 50
+            nullable: false
+          - name: age
+            type: int8
+            default: "0"
+        indices:
+          - name: users_pkey
+            unique: true
+            columns: [id]
+          - name: users_name_idx
+            unique: true
+            columns: [name]
+        constraints:
+          - name: users_age_check
+            kind: CHECK
+            content: (age >= 0)
+      - name: posts
+        columns:
+          - name: id
+            type: int4
+            primary_key: 1
+          - name: user_id
+            type: int4
+          - name: tag_id
+            type: int4
+        foreign_keys:
+          - name: posts_user_id_fkey
+            columns: [user_id]
+            ref_table: users
+            ref_columns: [id]
+          - name: posts_tag_id_fkey
+            columns: [tag_id]
+            ref_table: tags
+            ref_columns: [id]
+      - name: tags
+        columns:
+          - name: id
+            type: int4
+            primary_key: 1
+`
+
+func TestMigrationSteps(t *testing.T) {
+	steps := MigrationSteps(Postgres, newTestMigrationDiff(t))
+	expected := []struct {
+		sql         string
+		destructive bool
+	}{
+		{"DROP INDEX foo.users_name_idx", false},
+		{"CREATE TABLE foo.tags (\n    id int4 NOT NULL,\n    PRIMARY KEY (id)\n)", false},
+		{"ALTER TABLE foo.posts ADD COLUMN tag_id int4", false},
+		{"ALTER TABLE foo.users ALTER COLUMN name TYPE varchar(50)", true},
+		{"ALTER TABLE foo.users ALTER COLUMN name SET NOT NULL", true},
+		{"ALTER TABLE foo.users ALTER COLUMN age TYPE int8", false},
+		{"ALTER TABLE foo.users ALTER COLUMN age SET DEFAULT 0", false},
+		{"COMMENT ON TABLE foo.users IS 'accounts'", false},
+		{"ALTER TABLE foo.users ADD CONSTRAINT users_age_check CHECK (age >= 0)", false},
+		{"CREATE UNIQUE INDEX users_name_idx ON foo.users (name)", false},
+		{"ALTER TABLE foo.users DROP COLUMN memo", true},
+		{"DROP TABLE foo.logs", true},
+		{"ALTER TABLE foo.posts ADD CONSTRAINT posts_tag_id_fkey FOREIGN KEY (tag_id) REFERENCES foo.tags (id)", false},
+	}
+	if len(steps) != len(expected) {
+		for _, s := range steps {
+			t.Log(s.SQL())
+		}
+		t.Fatalf("Step count is invalid. expected: %v, actual: %v", len(expected), len(steps))
+	}
+	for i, step := range steps {
+		if step.SQL() != expected[i].sql || step.IsDestructive() != expected[i].destructive {
+			t.Errorf("Step is invalid. expected: %v (%v), actual: %v (%v)", expected[i].sql, expected[i].destructive, step.SQL(), step.IsDestructive())
+		}
+	}
+}
+
+func TestMigrationStatementsSkipsDestructiveSteps(t *testing.T) {
+	diff := newTestMigrationDiff(t)
+	stmts, skipped := MigrationStatements(Postgres, diff, false)
+	if len(stmts) != 9 || len(skipped) != 4 {
+		t.Errorf("Destructive steps should be skipped. (%v, %v)", len(stmts), len(skipped))
+	}
+	for _, stmt := range stmts {
+		if strings.HasPrefix(stmt, "DROP TABLE") {
+			t.Errorf("DROP TABLE should be skipped. (%v)", stmt)
+		}
+	}
+	stmts, skipped = MigrationStatements(Postgres, diff, true)
+	if len(stmts) != 13 || len(skipped) != 0 {
+		t.Errorf("Destructive steps should be returned when allowed. (%v, %v)", len(stmts), len(skipped))
+	}
+}
+
+func TestMigrationStepsChangePrimaryKey(t *testing.T) {
+	old := dbmodel.NewTable("foo", "bar", "")
+	a := dbmodel.NewColumn("foo", "bar", "a", "", "int4", dbmodel.Size{}, false, "", 1)
+	old.AddColumn(&a)
+	new := dbmodel.NewTable("foo", "bar", "")
+	na := dbmodel.NewColumn("foo", "bar", "a", "", "int4", dbmodel.Size{}, false, "", 1)
+	nb := dbmodel.NewColumn("foo", "bar", "b", "", "int4", dbmodel.Size{}, false, "", 2)
+	new.AddColumn(&na)
+	new.AddColumn(&nb)
+
+	stmts, _ := MigrationStatements(Postgres, dbmodel.Diff([]*dbmodel.Table{&old}, []*dbmodel.Table{&new}), false)
+	expected := []string{
+		"ALTER TABLE foo.bar DROP CONSTRAINT bar_pkey",
+		"ALTER TABLE foo.bar ADD COLUMN b int4 NOT NULL",
+		"ALTER TABLE foo.bar ADD PRIMARY KEY (a, b)",
+	}
+	if strings.Join(stmts, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Statements are invalid.\nexpected:\n%v\nactual:\n%v", strings.Join(expected, "\n"), strings.Join(stmts, "\n"))
+	}
+}
+
+const primaryKeyOldYAML = `
+schemas:
+  - name: foo
+    tables:
+      - name: users
+        columns:
+          - name: id
+            type: int4
+            primary_key: 1
+          - name: code
+            type: text
+            nullable: false
+      - name: posts
+        columns:
+          - name: id
+            type: int4
+            primary_key: 1
+          - name: user_id
+            type: int4
+        foreign_keys:
+          - name: posts_user_id_fkey
+            columns: [user_id]
+            ref_table: users
+            ref_columns: [id]
+`
+
+const primaryKeyNewYAML = `
+schemas:
+  - name: foo
+    tables:
+      - name: users
+        columns:
+          - name: id
+            type: int4
+            nullable: false
+          - name: code
+            type: text
+            primary_key: 1
+        indices:
+          - name: users_id_key
+            unique: true
+            columns: [id]
+      - name: posts
+        comment: articles
+        columns:
+          - name: id
+            type: int4
+            primary_key: 1
+          - name: user_id
+            type: int4
+        foreign_keys:
+          - name: posts_user_id_fkey
+            columns: [user_id]
+            ref_table: users
+            ref_columns: [id]
+`
+
+func TestMigrationStepsChangeReferencedPrimaryKey(t *testing.T) {
+	old, err := dbmodel.LoadYAML(strings.NewReader(primaryKeyOldYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	new, err := dbmodel.LoadYAML(strings.NewReader(primaryKeyNewYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// likes is not in diff, so that its foreign key is found only in referenced keys of users.
+	usr, _ := old.FindTable("foo", "users")
+	id, _ := usr.FindColumn("id")
+	likes := dbmodel.NewTable("foo", "likes", "")
+	userID := dbmodel.NewColumn("foo", "likes", "user_id", "", "int4", dbmodel.Size{}, false, "", 0)
+	likes.AddColumn(&userID)
+	fk := dbmodel.NewForeignKey("foo", "likes", "likes_user_id_fkey")
+	cr := dbmodel.NewColumnReference(&userID, id)
+	fk.AddColumnReference(&cr)
+	likes.AddForeignKey(&fk)
+	usr.AddReferencedKey(&fk)
+
+	steps := MigrationSteps(Postgres, dbmodel.Diff(old.Tables(), new.Tables()))
+	expected := []string{
+		"ALTER TABLE foo.posts DROP CONSTRAINT posts_user_id_fkey",
+		"ALTER TABLE foo.likes DROP CONSTRAINT likes_user_id_fkey",
+		"COMMENT ON TABLE foo.posts IS 'articles'",
+		"ALTER TABLE foo.users DROP CONSTRAINT users_pkey",
+		"ALTER TABLE foo.users ADD PRIMARY KEY (code)",
+		"CREATE UNIQUE INDEX users_id_key ON foo.users (id)",
+		"ALTER TABLE foo.posts ADD CONSTRAINT posts_user_id_fkey FOREIGN KEY (user_id) REFERENCES foo.users (id)",
+		"ALTER TABLE foo.likes ADD CONSTRAINT likes_user_id_fkey FOREIGN KEY (user_id) REFERENCES foo.users (id)",
+	}
+	stmts := make([]string, 0, len(steps))
+	for _, step := range steps {
+		if step.IsDestructive() {
+			t.Errorf("Step should not be destructive. (%v)", step.SQL())
+		}
+		stmts = append(stmts, step.SQL())
+	}
+	if strings.Join(stmts, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Steps are invalid.\nexpected:\n%v\nactual:\n%v", strings.Join(expected, "\n"), strings.Join(stmts, "\n"))
+	}
+}
+
+func TestMigrationStepsDropsAreNotDestructive(t *testing.T) {
+	old, err := dbmodel.LoadYAML(strings.NewReader(primaryKeyNewYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	new := dbmodel.NewTable("foo", "posts", "articles")
+	for _, name := range []string{"id", "user_id"} {
+		col := dbmodel.NewColumn("foo", "posts", name, "", "int4", dbmodel.Size{}, true, "", 0)
+		new.AddColumn(&col)
+	}
+	usr, _ := old.FindTable("foo", "users")
+	steps := MigrationSteps(Postgres, dbmodel.Diff(old.Tables(), []*dbmodel.Table{usr, &new}))
+	expected := []struct {
+		sql         string
+		destructive bool
+	}{
+		{"ALTER TABLE foo.posts DROP CONSTRAINT posts_user_id_fkey", false},
+		{"ALTER TABLE foo.posts DROP CONSTRAINT posts_pkey", false},
+		{"ALTER TABLE foo.posts ALTER COLUMN id DROP NOT NULL", false},
+	}
+	if len(steps) != len(expected) {
+		for _, s := range steps {
+			t.Log(s.SQL())
+		}
+		t.Fatalf("Step count is invalid. expected: %v, actual: %v", len(expected), len(steps))
+	}
+	for i, step := range steps {
+		if step.SQL() != expected[i].sql || step.IsDestructive() != expected[i].destructive {
+			t.Errorf("Step is invalid. expected: %v (%v), actual: %v (%v)", expected[i].sql, expected[i].destructive, step.SQL(), step.IsDestructive())
+		}
+	}
+}
+
+func TestMigrationStepsChangeIntegerToNumeric(t *testing.T) {
+	tests := []struct {
+		precision   int64
+		destructive bool
+	}{
+		{5, true},
+		{12, false},
+	}
+	for _, test := range tests {
+		old := dbmodel.NewTable("foo", "bar", "")
+		a := dbmodel.NewColumn("foo", "bar", "a", "", "int4", dbmodel.Size{}, true, "", 0)
+		old.AddColumn(&a)
+		new := dbmodel.NewTable("foo", "bar", "")
+		na := dbmodel.NewColumn("foo", "bar", "a", "", "numeric", newSize(-1, test.precision, 0), true, "", 0)
+		new.AddColumn(&na)
+
+		steps := MigrationSteps(Postgres, dbmodel.Diff([]*dbmodel.Table{&old}, []*dbmodel.Table{&new}))
+		if len(steps) != 1 {
+			t.Fatalf("Step count is invalid. expected: %v, actual: %v", 1, len(steps))
+		}
+		if expected, actual := fmt.Sprintf("ALTER TABLE foo.bar ALTER COLUMN a TYPE numeric(%v, 0)", test.precision), steps[0].SQL(); actual != expected {
+			t.Errorf("Step is invalid. expected: %v, actual: %v", expected, actual)
+		}
+		if actual := steps[0].IsDestructive(); actual != test.destructive {
+			t.Errorf("IsDestructive() returns invalid value for numeric(%v, 0). expected: %v, actual: %v", test.precision, test.destructive, actual)
+		}
+	}
+}
+
+func TestWriteMigration(t *testing.T) {
+	old := dbmodel.NewTable("foo", "bar", "")
+	var buf bytes.Buffer
+	if err := WriteMigration(&buf, Postgres, dbmodel.Diff([]*dbmodel.Table{&old}, nil), false); err != nil {
+		t.Fatal(err)
+	}
+	expected := "-- Destructive steps are skipped:\n-- DROP TABLE foo.bar;\n"
+	if actual := buf.String(); actual != expected {
+		t.Errorf("WriteMigration writes invalid value.\nexpected:\n%v\nactual:\n%v", expected, actual)
+	}
+}
+
+func TestWriteMigrationWithUnsupportedConstraint(t *testing.T) {
+	old := dbmodel.NewTable("foo", "bar", "")
+	new := dbmodel.NewTable("foo", "bar", "")
+	con := dbmodel.NewConstraint("foo", "bar", "bar_during_excl", "EXCLUDE", "during WITH &&")
+	new.AddConstraint(&con)
+	var buf bytes.Buffer
+	if err := WriteMigration(&buf, Postgres, dbmodel.Diff([]*dbmodel.Table{&old}, []*dbmodel.Table{&new}), false); err == nil {
+		t.Error("WriteMigration should return error for unsupported constraint.")
+	}
+	if buf.Len() > 0 {
+		t.Errorf("WriteMigration should not write anything. actual: %v", buf.String())
+	}
+}
+
+func newTestMigrationDiff(t *testing.T) *dbmodel.SchemaDiff {
+	old, err := dbmodel.LoadYAML(strings.NewReader(migrationOldYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	new, err := dbmodel.LoadYAML(strings.NewReader(migrationNewYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dbmodel.Diff(old.Tables(), new.Tables())
+}
@@ -0,0 +1,243 @@
+package ddl
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+// Postgres is PostgreSQL dialect.
+// Data types are expected to be type names that dbmodel loads from PostgreSQL. (eg. "int4", "varchar")
+var Postgres Dialect = postgres{}
+
+type postgres struct{}
+
+var (
+	pgPlainIdentifier = regexp.MustCompile(`^[a-z_][a-z0-9_$]*$`)
+	pgReservedWords   = map[string]bool{
+		"all": true, "analyse": true, "analyze": true, "and": true, "any": true, "array": true, "as": true,
+		"asc": true, "asymmetric": true, "both": true, "case": true, "cast": true, "check": true,
+		"collate": true, "column": true, "constraint": true, "create": true, "current_catalog": true,
+		"current_date": true, "current_role": true, "current_time": true, "current_timestamp": true,
+		"current_user": true, "default": true, "deferrable": true, "desc": true, "distinct": true, "do": true,
+		"else": true, "end": true, "except": true, "false": true, "fetch": true, "for": true, "foreign": true,
+		"from": true, "grant": true, "group": true, "having": true, "in": true, "initially": true,
+		"intersect": true, "into": true, "lateral": true, "leading": true, "limit": true, "localtime": true,
+		"localtimestamp": true, "not": true, "null": true, "offset": true, "on": true, "only": true, "or": true,
+		"order": true, "placing": true, "primary": true, "references": true, "returning": true, "select": true,
+		"session_user": true, "some": true, "symmetric": true, "table": true, "then": true, "to": true,
+		"trailing": true, "true": true, "union": true, "unique": true, "user": true, "using": true,
+		"variadic": true, "when": true, "where": true, "window": true, "with": true,
+	}
+	pgWiderTypes = map[string][]string{
+		"int2":    {"int4", "int8", "numeric"},
+		"int4":    {"int8", "numeric"},
+		"int8":    {"numeric"},
+		"float4":  {"float8"},
+		"bpchar":  {"varchar", "text"},
+		"varchar": {"text"},
+	}
+	// pgIntegerDigits is number of decimal digits that numeric needs to hold any value of integer type.
+	pgIntegerDigits = map[string]int64{
+		"int2": 5,
+		"int4": 10,
+		"int8": 19,
+	}
+	pgSerialTypes = map[string]string{
+		"int2": "smallserial",
+		"int4": "serial",
+		"int8": "bigserial",
+	}
+)
+
+func (p postgres) Quote(name string) string {
+	if pgPlainIdentifier.MatchString(name) && !pgReservedWords[name] {
+		return name
+	}
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+func (p postgres) TableName(schema string, name string) string {
+	if schema == "" {
+		return p.Quote(name)
+	}
+	return p.Quote(schema) + "." + p.Quote(name)
+}
+
+// ColumnType returns type name with size.
+// Array type name (eg. "_int4") is converted to "int4[]".
+func (p postgres) ColumnType(col *dbmodel.Column) string {
+	typ := col.DataType()
+	if strings.HasPrefix(typ, "_") {
+		return typ[1:] + "[]"
+	}
+	size := col.Size()
+	switch typ {
+	case "varchar", "bpchar", "char", "bit", "varbit", "character varying", "character", "bit varying":
+		if size.Length().Valid {
+			return typ + "(" + strconv.FormatInt(size.Length().Int64, 10) + ")"
+		}
+	case "numeric", "decimal":
+		if size.Precision().Valid && size.Scale().Valid {
+			return typ + "(" + strconv.FormatInt(size.Precision().Int64, 10) + ", " + strconv.FormatInt(size.Scale().Int64, 10) + ")"
+		} else if size.Precision().Valid {
+			return typ + "(" + strconv.FormatInt(size.Precision().Int64, 10) + ")"
+		}
+	case "timestamp", "timestamptz", "time", "timetz", "interval":
+		if size.Precision().Valid {
+			return typ + "(" + strconv.FormatInt(size.Precision().Int64, 10) + ")"
+		}
+	}
+	return typ
+}
+
+// ColumnDefinition returns column definition.
+// Integer column whose default value is nextval of sequence is defined as serial type,
+// so that sequence is created with table.
+func (p postgres) ColumnDefinition(col *dbmodel.Column) string {
+	def := p.Quote(col.Name()) + " "
+	serial, isSerial := pgSerialTypes[col.DataType()]
+	isSerial = isSerial && strings.HasPrefix(col.DefaultValue(), "nextval(")
+	if isSerial {
+		def += serial
+	} else {
+		def += p.ColumnType(col)
+	}
+	if !col.IsNullable() {
+		def += " NOT NULL"
+	}
+	if col.DefaultValue() != "" && !isSerial {
+		def += " DEFAULT " + col.DefaultValue()
+	}
+	return def
+}
+
+// ConstraintDefinition returns CHECK, UNIQUE or EXCLUDE constraint definition.
+// EXCLUDE constraint requires index method in its content (eg. "USING gist (during WITH &&)"),
+// because postgres uses btree without it and btree does not support operators such as "&&".
+// Loaded EXCLUDE constraints do not have index method, so they are not supported.
+func (p postgres) ConstraintDefinition(con *dbmodel.Constraint) string {
+	def := "CONSTRAINT " + p.Quote(con.Name()) + " "
+	content := strings.TrimSpace(con.Content())
+	switch con.Kind() {
+	case "CHECK":
+		if strings.HasPrefix(strings.ToUpper(content), "CHECK") {
+			return def + content
+		}
+		if !strings.HasPrefix(content, "(") {
+			content = "(" + content + ")"
+		}
+		return def + "CHECK " + content
+	case "UNIQUE":
+		cols := strings.Split(content, ",")
+		for i, col := range cols {
+			cols[i] = p.Quote(strings.TrimSpace(col))
+		}
+		return def + "UNIQUE (" + strings.Join(cols, ", ") + ")"
+	case "EXCLUDE":
+		if strings.HasPrefix(strings.ToUpper(content), "USING ") {
+			return def + "EXCLUDE " + content
+		}
+	}
+	return ""
+}
+
+func (p postgres) TableComment(tbl *dbmodel.Table) string {
+	return "COMMENT ON TABLE " + p.TableName(tbl.Schema(), tbl.Name()) + " IS " + p.literal(tbl.Comment())
+}
+
+func (p postgres) ColumnComment(col *dbmodel.Column) string {
+	return "COMMENT ON COLUMN " + p.TableName(col.Schema(), col.TableName()) + "." + p.Quote(col.Name()) + " IS " + p.literal(col.Comment())
+}
+
+func (p postgres) AlterColumn(old *dbmodel.Column, new *dbmodel.Column) []string {
+	prefix := "ALTER TABLE " + p.TableName(new.Schema(), new.TableName()) + " ALTER COLUMN " + p.Quote(new.Name()) + " "
+	stmts := make([]string, 0, 3)
+	if old.DataType() != new.DataType() || old.Size().String() != new.Size().String() {
+		stmts = append(stmts, prefix+"TYPE "+p.ColumnType(new))
+	}
+	if old.IsNullable() != new.IsNullable() {
+		if new.IsNullable() {
+			stmts = append(stmts, prefix+"DROP NOT NULL")
+		} else {
+			stmts = append(stmts, prefix+"SET NOT NULL")
+		}
+	}
+	if old.DefaultValue() != new.DefaultValue() {
+		if new.DefaultValue() == "" {
+			stmts = append(stmts, prefix+"DROP DEFAULT")
+		} else {
+			stmts = append(stmts, prefix+"SET DEFAULT "+new.DefaultValue())
+		}
+	}
+	return stmts
+}
+
+// IsNarrowing returns false only for changes that are known to keep data.
+// (eg. int4 to int8, extending length of varchar, varchar to text)
+// Integer to numeric keeps data only if precision minus scale of numeric is not less than digits of integer type.
+func (p postgres) IsNarrowing(old *dbmodel.Column, new *dbmodel.Column) bool {
+	os, ns := old.Size(), new.Size()
+	if old.DataType() == new.DataType() {
+		if os.String() == ns.String() {
+			return false
+		}
+		if os.Length().Valid || ns.Length().Valid {
+			return ns.Length().Valid && (!os.Length().Valid || ns.Length().Int64 < os.Length().Int64)
+		}
+		if os.Scale().Valid || ns.Scale().Valid {
+			if !ns.Precision().Valid {
+				return false
+			}
+			if !os.Precision().Valid {
+				return true
+			}
+			return ns.Scale().Int64 < os.Scale().Int64 ||
+				ns.Precision().Int64-ns.Scale().Int64 < os.Precision().Int64-os.Scale().Int64
+		}
+		return ns.Precision().Valid && (!os.Precision().Valid || ns.Precision().Int64 < os.Precision().Int64)
+	}
+	for _, t := range pgWiderTypes[old.DataType()] {
+		if t != new.DataType() {
+			continue
+		}
+		if digits, ok := pgIntegerDigits[old.DataType()]; ok && new.DataType() == "numeric" {
+			return !ns.Precision().Valid || ns.Precision().Int64-ns.Scale().Int64 < digits
+		}
+		return new.DataType() == "varchar" && ns.Length().Valid && (!os.Length().Valid || ns.Length().Int64 < os.Length().Int64)
+	}
+	return true
+}
+
+// DropPrimaryKey drops primary key constraint.
+// Constraint name is name of unique index on primary key columns, or default name ("<table>_pkey") if index is not loaded.
+func (p postgres) DropPrimaryKey(tbl *dbmodel.Table) string {
+	name := tbl.Name() + "_pkey"
+	for _, idx := range tbl.Indices() {
+		if isImplicitIndex(tbl, idx) {
+			if _, ok := tbl.FindConstraint(idx.Name()); !ok {
+				name = idx.Name()
+				break
+			}
+		}
+	}
+	return "ALTER TABLE " + p.TableName(tbl.Schema(), tbl.Name()) + " DROP CONSTRAINT " + p.Quote(name)
+}
+
+func (p postgres) DropForeignKey(fk *dbmodel.ForeignKey) string {
+	return "ALTER TABLE " + p.TableName(fk.Schema(), fk.TableName()) + " DROP CONSTRAINT " + p.Quote(fk.Name())
+}
+
+func (p postgres) DropConstraint(con *dbmodel.Constraint) string {
+	return "ALTER TABLE " + p.TableName(con.Schema(), con.TableName()) + " DROP CONSTRAINT " + p.Quote(con.Name())
+}
+
+func (p postgres) DropIndex(idx *dbmodel.Index) string {
+	return "DROP INDEX " + p.TableName(idx.Schema(), idx.Name())
+}
+
+func (p postgres) literal(s string) string {
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
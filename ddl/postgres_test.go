@@ -0,0 +1,127 @@
+package ddl
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+func TestPostgresQuote(t *testing.T) {
+	cases := map[string]string{
+		"users":     "users",
+		"user_id2":  "user_id2",
+		"user":      `"user"`,
+		"UserName":  `"UserName"`,
+		"1st":       `"1st"`,
+		`say"hello`: `"say""hello"`,
+	}
+	for name, expected := range cases {
+		if actual := Postgres.Quote(name); actual != expected {
+			t.Errorf("Quote() returns invalid value. expected: %v, actual: %v", expected, actual)
+		}
+	}
+}
+
+func TestPostgresColumnType(t *testing.T) {
+	cases := []struct {
+		typ      string
+		size     dbmodel.Size
+		expected string
+	}{
+		{"int4", newSize(-1, 32, 0), "int4"},
+		{"varchar", newSize(255, -1, -1), "varchar(255)"},
+		{"bpchar", newSize(1, -1, -1), "bpchar(1)"},
+		{"numeric", newSize(-1, 10, 2), "numeric(10, 2)"},
+		{"numeric", newSize(-1, -1, -1), "numeric"},
+		{"timestamptz", newSize(-1, 3, -1), "timestamptz(3)"},
+		{"_int4", newSize(-1, -1, -1), "int4[]"},
+		{"text", newSize(-1, -1, -1), "text"},
+	}
+	for _, c := range cases {
+		col := dbmodel.NewColumn("foo", "bar", "baz", "", c.typ, c.size, true, "", 0)
+		if actual := Postgres.ColumnType(&col); actual != c.expected {
+			t.Errorf("ColumnType() returns invalid value. expected: %v, actual: %v", c.expected, actual)
+		}
+	}
+}
+
+func TestPostgresColumnDefinition(t *testing.T) {
+	col := dbmodel.NewColumn("foo", "bar", "order", "", "varchar", newSize(10, -1, -1), false, "'a'::character varying", 0)
+	if expected, actual := `"order" varchar(10) NOT NULL DEFAULT 'a'::character varying`, Postgres.ColumnDefinition(&col); actual != expected {
+		t.Errorf("ColumnDefinition() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	col = dbmodel.NewColumn("foo", "bar", "id", "", "int8", newSize(-1, 64, 0), false, "nextval('bar_id_seq'::regclass)", 1)
+	if expected, actual := `id bigserial NOT NULL`, Postgres.ColumnDefinition(&col); actual != expected {
+		t.Errorf("ColumnDefinition() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestPostgresConstraintDefinition(t *testing.T) {
+	cases := []struct {
+		kind     string
+		content  string
+		expected string
+	}{
+		{"CHECK", "(id > 0)", "CONSTRAINT c CHECK (id > 0)"},
+		{"CHECK", "CHECK (id > 0)", "CONSTRAINT c CHECK (id > 0)"},
+		{"CHECK", "id > 0", "CONSTRAINT c CHECK (id > 0)"},
+		{"UNIQUE", "id, user", `CONSTRAINT c UNIQUE (id, "user")`},
+		{"EXCLUDE", "USING gist (during WITH &&)", "CONSTRAINT c EXCLUDE USING gist (during WITH &&)"},
+		{"EXCLUDE", "during WITH &&", ""},
+		{"UNKNOWN", "", ""},
+	}
+	for _, c := range cases {
+		con := dbmodel.NewConstraint("foo", "bar", "c", c.kind, c.content)
+		if actual := Postgres.ConstraintDefinition(&con); actual != c.expected {
+			t.Errorf("ConstraintDefinition() returns invalid value. expected: %v, actual: %v", c.expected, actual)
+		}
+	}
+}
+
+func newSize(length int64, precision int64, scale int64) dbmodel.Size {
+	return dbmodel.NewSize(nullInt(length), nullInt(precision), nullInt(scale))
+}
+
+func nullInt(i int64) sql.NullInt64 {
+	if i < 0 {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: i, Valid: true}
+}
+
+func TestPostgresIsNarrowing(t *testing.T) {
+	cases := []struct {
+		oldType  string
+		oldSize  dbmodel.Size
+		newType  string
+		newSize  dbmodel.Size
+		expected bool
+	}{
+		{"varchar", newSize(10, -1, -1), "varchar", newSize(20, -1, -1), false},
+		{"varchar", newSize(20, -1, -1), "varchar", newSize(10, -1, -1), true},
+		{"varchar", newSize(-1, -1, -1), "varchar", newSize(10, -1, -1), true},
+		{"varchar", newSize(10, -1, -1), "text", newSize(-1, -1, -1), false},
+		{"bpchar", newSize(10, -1, -1), "varchar", newSize(5, -1, -1), true},
+		{"int4", newSize(-1, 32, 0), "int8", newSize(-1, 64, 0), false},
+		{"int8", newSize(-1, 64, 0), "int4", newSize(-1, 32, 0), true},
+		{"int4", newSize(-1, 32, 0), "numeric", newSize(-1, 12, 0), false},
+		{"int4", newSize(-1, 32, 0), "numeric", newSize(-1, 12, 2), false},
+		{"int4", newSize(-1, 32, 0), "numeric", newSize(-1, 12, 3), true},
+		{"int4", newSize(-1, 32, 0), "numeric", newSize(-1, 5, 0), true},
+		{"int8", newSize(-1, 64, 0), "numeric", newSize(-1, 19, 0), false},
+		{"int2", newSize(-1, 16, 0), "numeric", newSize(-1, -1, -1), true},
+		{"numeric", newSize(-1, 10, 2), "numeric", newSize(-1, 12, 2), false},
+		{"numeric", newSize(-1, 10, 2), "numeric", newSize(-1, 10, 3), true},
+		{"numeric", newSize(-1, 10, 2), "numeric", newSize(-1, -1, -1), false},
+		{"timestamp", newSize(-1, 6, -1), "timestamp", newSize(-1, 3, -1), true},
+		{"text", newSize(-1, -1, -1), "int4", newSize(-1, 32, 0), true},
+	}
+	for _, c := range cases {
+		old := dbmodel.NewColumn("foo", "bar", "baz", "", c.oldType, c.oldSize, true, "", 0)
+		new := dbmodel.NewColumn("foo", "bar", "baz", "", c.newType, c.newSize, true, "", 0)
+		if actual := Postgres.IsNarrowing(&old, &new); actual != c.expected {
+			t.Errorf("IsNarrowing() returns invalid value for %v(%v) -> %v(%v). expected: %v, actual: %v", c.oldType, c.oldSize, c.newType, c.newSize, c.expected, actual)
+		}
+	}
+}
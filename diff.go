@@ -0,0 +1,710 @@
+package dbmodel
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Change is difference of an attribute between old and new object.
+// Values are formatted as string. (eg. nullable is "true" or "false")
+type Change struct {
+	attribute string
+	old       string
+	new       string
+}
+
+// Attribute returns changed attribute name. (eg. "type", "nullable", "default")
+func (c Change) Attribute() string {
+	return c.attribute
+}
+
+// Old returns old value.
+func (c Change) Old() string {
+	return c.old
+}
+
+// New returns new value.
+func (c Change) New() string {
+	return c.new
+}
+
+// SchemaDiff is difference between two table lists.
+type SchemaDiff struct {
+	addedTables      []*Table
+	removedTables    []*Table
+	renameCandidates []*TableRename
+	changedTables    []*TableDiff
+}
+
+// AddedTables returns tables that exist only in new tables.
+func (d SchemaDiff) AddedTables() []*Table {
+	return d.addedTables
+}
+
+// RemovedTables returns tables that exist only in old tables.
+func (d SchemaDiff) RemovedTables() []*Table {
+	return d.removedTables
+}
+
+// RenameCandidates returns pairs of removed and added table that have same columns.
+// Tables in candidates are also contained in RemovedTables and AddedTables.
+func (d SchemaDiff) RenameCandidates() []*TableRename {
+	return d.renameCandidates
+}
+
+// ChangedTables returns differences of tables that exist in both old and new tables.
+func (d SchemaDiff) ChangedTables() []*TableDiff {
+	return d.changedTables
+}
+
+// IsEmpty returns true if there is no difference.
+func (d SchemaDiff) IsEmpty() bool {
+	return len(d.addedTables) == 0 && len(d.removedTables) == 0 && len(d.changedTables) == 0
+}
+
+// TableRename is pair of tables that may be renamed.
+type TableRename struct {
+	old *Table
+	new *Table
+}
+
+// Old returns removed table.
+func (r TableRename) Old() *Table {
+	return r.old
+}
+
+// New returns added table.
+func (r TableRename) New() *Table {
+	return r.new
+}
+
+// TableDiff is difference of a table.
+type TableDiff struct {
+	old                *Table
+	new                *Table
+	addedColumns       []*Column
+	removedColumns     []*Column
+	changedColumns     []*ColumnDiff
+	addedIndices       []*Index
+	removedIndices     []*Index
+	changedIndices     []*IndexDiff
+	addedForeignKeys   []*ForeignKey
+	removedForeignKeys []*ForeignKey
+	changedForeignKeys []*ForeignKeyDiff
+	addedConstraints   []*Constraint
+	removedConstraints []*Constraint
+	changedConstraints []*ConstraintDiff
+}
+
+// Old returns old table.
+func (d TableDiff) Old() *Table {
+	return d.old
+}
+
+// New returns new table.
+func (d TableDiff) New() *Table {
+	return d.new
+}
+
+// Changes returns changes of table's own attributes. ("comment", "kind" and "primary_key")
+func (d TableDiff) Changes() []*Change {
+	changes := make([]*Change, 0, 3)
+	changes = appendChange(changes, "comment", d.old.Comment(), d.new.Comment())
+	changes = appendChange(changes, "kind", string(d.old.Kind()), string(d.new.Kind()))
+	changes = appendChange(changes, "primary_key", formatNames(primaryKeyNames(d.old)), formatNames(primaryKeyNames(d.new)))
+	return changes
+}
+
+// PrimaryKeyChanged returns true if primary key columns are changed.
+func (d TableDiff) PrimaryKeyChanged() bool {
+	return formatNames(primaryKeyNames(d.old)) != formatNames(primaryKeyNames(d.new))
+}
+
+// AddedColumns returns columns that exist only in new table.
+func (d TableDiff) AddedColumns() []*Column {
+	return d.addedColumns
+}
+
+// RemovedColumns returns columns that exist only in old table.
+func (d TableDiff) RemovedColumns() []*Column {
+	return d.removedColumns
+}
+
+// ChangedColumns returns differences of columns that exist in both tables.
+func (d TableDiff) ChangedColumns() []*ColumnDiff {
+	return d.changedColumns
+}
+
+// AddedIndices returns indices that exist only in new table.
+func (d TableDiff) AddedIndices() []*Index {
+	return d.addedIndices
+}
+
+// RemovedIndices returns indices that exist only in old table.
+func (d TableDiff) RemovedIndices() []*Index {
+	return d.removedIndices
+}
+
+// ChangedIndices returns differences of indices that exist in both tables.
+func (d TableDiff) ChangedIndices() []*IndexDiff {
+	return d.changedIndices
+}
+
+// AddedForeignKeys returns foreign keys that exist only in new table.
+func (d TableDiff) AddedForeignKeys() []*ForeignKey {
+	return d.addedForeignKeys
+}
+
+// RemovedForeignKeys returns foreign keys that exist only in old table.
+func (d TableDiff) RemovedForeignKeys() []*ForeignKey {
+	return d.removedForeignKeys
+}
+
+// ChangedForeignKeys returns differences of foreign keys that exist in both tables.
+func (d TableDiff) ChangedForeignKeys() []*ForeignKeyDiff {
+	return d.changedForeignKeys
+}
+
+// AddedConstraints returns constraints that exist only in new table.
+func (d TableDiff) AddedConstraints() []*Constraint {
+	return d.addedConstraints
+}
+
+// RemovedConstraints returns constraints that exist only in old table.
+func (d TableDiff) RemovedConstraints() []*Constraint {
+	return d.removedConstraints
+}
+
+// ChangedConstraints returns differences of constraints that exist in both tables.
+func (d TableDiff) ChangedConstraints() []*ConstraintDiff {
+	return d.changedConstraints
+}
+
+// IsEmpty returns true if there is no difference.
+func (d TableDiff) IsEmpty() bool {
+	return len(d.Changes()) == 0 &&
+		len(d.addedColumns) == 0 && len(d.removedColumns) == 0 && len(d.changedColumns) == 0 &&
+		len(d.addedIndices) == 0 && len(d.removedIndices) == 0 && len(d.changedIndices) == 0 &&
+		len(d.addedForeignKeys) == 0 && len(d.removedForeignKeys) == 0 && len(d.changedForeignKeys) == 0 &&
+		len(d.addedConstraints) == 0 && len(d.removedConstraints) == 0 && len(d.changedConstraints) == 0
+}
+
+// ColumnDiff is difference of a column.
+type ColumnDiff struct {
+	old *Column
+	new *Column
+}
+
+// Old returns old column.
+func (d ColumnDiff) Old() *Column {
+	return d.old
+}
+
+// New returns new column.
+func (d ColumnDiff) New() *Column {
+	return d.new
+}
+
+// TypeChanged returns true if data type is changed.
+func (d ColumnDiff) TypeChanged() bool {
+	return d.old.DataType() != d.new.DataType()
+}
+
+// SizeChanged returns true if length, precision or scale is changed.
+func (d ColumnDiff) SizeChanged() bool {
+	return d.old.Size().String() != d.new.Size().String()
+}
+
+// NullableChanged returns true if nullability is changed.
+func (d ColumnDiff) NullableChanged() bool {
+	return d.old.IsNullable() != d.new.IsNullable()
+}
+
+// DefaultChanged returns true if default value is changed.
+func (d ColumnDiff) DefaultChanged() bool {
+	return d.old.DefaultValue() != d.new.DefaultValue()
+}
+
+// CommentChanged returns true if comment is changed.
+func (d ColumnDiff) CommentChanged() bool {
+	return d.old.Comment() != d.new.Comment()
+}
+
+// Changes returns changes of column. ("type", "size", "nullable", "default" and "comment")
+func (d ColumnDiff) Changes() []*Change {
+	changes := make([]*Change, 0, 5)
+	changes = appendChange(changes, "type", d.old.DataType(), d.new.DataType())
+	changes = appendChange(changes, "size", d.old.Size().String(), d.new.Size().String())
+	changes = appendChange(changes, "nullable", strconv.FormatBool(d.old.IsNullable()), strconv.FormatBool(d.new.IsNullable()))
+	changes = appendChange(changes, "default", d.old.DefaultValue(), d.new.DefaultValue())
+	changes = appendChange(changes, "comment", d.old.Comment(), d.new.Comment())
+	return changes
+}
+
+// IndexDiff is difference of an index.
+type IndexDiff struct {
+	old *Index
+	new *Index
+}
+
+// Old returns old index.
+func (d IndexDiff) Old() *Index {
+	return d.old
+}
+
+// New returns new index.
+func (d IndexDiff) New() *Index {
+	return d.new
+}
+
+// Changes returns changes of index. ("unique" and "columns")
+func (d IndexDiff) Changes() []*Change {
+	changes := make([]*Change, 0, 2)
+	changes = appendChange(changes, "unique", strconv.FormatBool(d.old.IsUnique()), strconv.FormatBool(d.new.IsUnique()))
+	changes = appendChange(changes, "columns", formatNames(indexColumnNames(d.old)), formatNames(indexColumnNames(d.new)))
+	return changes
+}
+
+// ForeignKeyDiff is difference of a foreign key.
+type ForeignKeyDiff struct {
+	old *ForeignKey
+	new *ForeignKey
+}
+
+// Old returns old foreign key.
+func (d ForeignKeyDiff) Old() *ForeignKey {
+	return d.old
+}
+
+// New returns new foreign key.
+func (d ForeignKeyDiff) New() *ForeignKey {
+	return d.new
+}
+
+// Changes returns changes of foreign key. ("columns")
+func (d ForeignKeyDiff) Changes() []*Change {
+	return appendChange(make([]*Change, 0, 1), "columns", formatReferences(d.old), formatReferences(d.new))
+}
+
+// ConstraintDiff is difference of a constraint.
+type ConstraintDiff struct {
+	old *Constraint
+	new *Constraint
+}
+
+// Old returns old constraint.
+func (d ConstraintDiff) Old() *Constraint {
+	return d.old
+}
+
+// New returns new constraint.
+func (d ConstraintDiff) New() *Constraint {
+	return d.new
+}
+
+// Changes returns changes of constraint. ("kind" and "content")
+func (d ConstraintDiff) Changes() []*Change {
+	changes := make([]*Change, 0, 2)
+	changes = appendChange(changes, "kind", d.old.Kind(), d.new.Kind())
+	changes = appendChange(changes, "content", d.old.Content(), d.new.Content())
+	return changes
+}
+
+// Diff compares old tables with new tables.
+// Tables are identified by schema and name, and columns, indices, foreign keys and constraints are identified by name.
+// Removed and added tables in same schema that have same column names and data types are reported as rename candidates.
+// Tables are sorted by schema and name in returned diff,
+// and objects in each table are in order of old table (removed) or new table (added and changed).
+func Diff(old []*Table, new []*Table) *SchemaDiff {
+	d := &SchemaDiff{
+		addedTables:      make([]*Table, 0, 5),
+		removedTables:    make([]*Table, 0, 5),
+		renameCandidates: make([]*TableRename, 0, 5),
+		changedTables:    make([]*TableDiff, 0, 5),
+	}
+	oldMap := make(map[string]*Table, len(old))
+	for _, tbl := range old {
+		oldMap[tableKey(tbl.Schema(), tbl.Name())] = tbl
+	}
+	newMap := make(map[string]*Table, len(new))
+	for _, tbl := range new {
+		newMap[tableKey(tbl.Schema(), tbl.Name())] = tbl
+	}
+
+	for _, key := range sortedTableKeys(old) {
+		if _, ok := newMap[key]; !ok {
+			d.removedTables = append(d.removedTables, oldMap[key])
+		}
+	}
+	for _, key := range sortedTableKeys(new) {
+		nt := newMap[key]
+		ot, ok := oldMap[key]
+		if !ok {
+			d.addedTables = append(d.addedTables, nt)
+			continue
+		}
+		if td := diffTable(ot, nt); !td.IsEmpty() {
+			d.changedTables = append(d.changedTables, td)
+		}
+	}
+
+	used := make(map[*Table]bool)
+	for _, rt := range d.removedTables {
+		sig := columnSignature(rt)
+		if sig == "" {
+			continue
+		}
+		for _, at := range d.addedTables {
+			if used[at] || rt.Schema() != at.Schema() || columnSignature(at) != sig {
+				continue
+			}
+			d.renameCandidates = append(d.renameCandidates, &TableRename{old: rt, new: at})
+			used[at] = true
+			break
+		}
+	}
+	return d
+}
+
+func diffTable(old *Table, new *Table) *TableDiff {
+	d := &TableDiff{old: old, new: new}
+
+	for _, col := range old.Columns() {
+		if _, ok := new.FindColumn(col.Name()); !ok {
+			d.removedColumns = append(d.removedColumns, col)
+		}
+	}
+	for _, col := range new.Columns() {
+		oc, ok := old.FindColumn(col.Name())
+		if !ok {
+			d.addedColumns = append(d.addedColumns, col)
+		} else if cd := (&ColumnDiff{old: oc, new: col}); len(cd.Changes()) > 0 {
+			d.changedColumns = append(d.changedColumns, cd)
+		}
+	}
+
+	for _, idx := range old.Indices() {
+		if _, ok := new.FindIndex(idx.Name()); !ok {
+			d.removedIndices = append(d.removedIndices, idx)
+		}
+	}
+	for _, idx := range new.Indices() {
+		oi, ok := old.FindIndex(idx.Name())
+		if !ok {
+			d.addedIndices = append(d.addedIndices, idx)
+		} else if id := (&IndexDiff{old: oi, new: idx}); len(id.Changes()) > 0 {
+			d.changedIndices = append(d.changedIndices, id)
+		}
+	}
+
+	for _, fk := range old.ForeignKeys() {
+		if _, ok := new.FindForeignKey(fk.Name()); !ok {
+			d.removedForeignKeys = append(d.removedForeignKeys, fk)
+		}
+	}
+	for _, fk := range new.ForeignKeys() {
+		ofk, ok := old.FindForeignKey(fk.Name())
+		if !ok {
+			d.addedForeignKeys = append(d.addedForeignKeys, fk)
+		} else if fd := (&ForeignKeyDiff{old: ofk, new: fk}); len(fd.Changes()) > 0 {
+			d.changedForeignKeys = append(d.changedForeignKeys, fd)
+		}
+	}
+
+	for _, con := range old.Constraints() {
+		if _, ok := new.FindConstraint(con.Name()); !ok {
+			d.removedConstraints = append(d.removedConstraints, con)
+		}
+	}
+	for _, con := range new.Constraints() {
+		oc, ok := old.FindConstraint(con.Name())
+		if !ok {
+			d.addedConstraints = append(d.addedConstraints, con)
+		} else if cd := (&ConstraintDiff{old: oc, new: con}); len(cd.Changes()) > 0 {
+			d.changedConstraints = append(d.changedConstraints, cd)
+		}
+	}
+	return d
+}
+
+// String returns textual rendering of diff.
+// Each line starts with "+" (added), "-" (removed), "~" (changed) or "?" (rename candidate),
+// and changes of objects in table are indented.
+//
+//	~ table public.posts: comment "" -> user posts
+//	    - column title
+//	    + column body
+//	    ~ column user_id: type integer -> bigint, nullable true -> false
+func (d SchemaDiff) String() string {
+	var buf bytes.Buffer
+	for _, tbl := range d.removedTables {
+		fmt.Fprintf(&buf, "- table %v\n", tableKey(tbl.Schema(), tbl.Name()))
+	}
+	for _, tbl := range d.addedTables {
+		fmt.Fprintf(&buf, "+ table %v\n", tableKey(tbl.Schema(), tbl.Name()))
+	}
+	for _, r := range d.renameCandidates {
+		fmt.Fprintf(&buf, "? rename %v -> %v\n", tableKey(r.old.Schema(), r.old.Name()), tableKey(r.new.Schema(), r.new.Name()))
+	}
+	for _, td := range d.changedTables {
+		fmt.Fprintf(&buf, "~ table %v%v\n", tableKey(td.new.Schema(), td.new.Name()), formatChanges(td.Changes()))
+		for _, col := range td.removedColumns {
+			fmt.Fprintf(&buf, "    - column %v\n", col.Name())
+		}
+		for _, col := range td.addedColumns {
+			fmt.Fprintf(&buf, "    + column %v\n", col.Name())
+		}
+		for _, cd := range td.changedColumns {
+			fmt.Fprintf(&buf, "    ~ column %v%v\n", cd.new.Name(), formatChanges(cd.Changes()))
+		}
+		for _, idx := range td.removedIndices {
+			fmt.Fprintf(&buf, "    - index %v\n", idx.Name())
+		}
+		for _, idx := range td.addedIndices {
+			fmt.Fprintf(&buf, "    + index %v\n", idx.Name())
+		}
+		for _, id := range td.changedIndices {
+			fmt.Fprintf(&buf, "    ~ index %v%v\n", id.new.Name(), formatChanges(id.Changes()))
+		}
+		for _, fk := range td.removedForeignKeys {
+			fmt.Fprintf(&buf, "    - foreign key %v\n", fk.Name())
+		}
+		for _, fk := range td.addedForeignKeys {
+			fmt.Fprintf(&buf, "    + foreign key %v\n", fk.Name())
+		}
+		for _, fd := range td.changedForeignKeys {
+			fmt.Fprintf(&buf, "    ~ foreign key %v%v\n", fd.new.Name(), formatChanges(fd.Changes()))
+		}
+		for _, con := range td.removedConstraints {
+			fmt.Fprintf(&buf, "    - constraint %v\n", con.Name())
+		}
+		for _, con := range td.addedConstraints {
+			fmt.Fprintf(&buf, "    + constraint %v\n", con.Name())
+		}
+		for _, cd := range td.changedConstraints {
+			fmt.Fprintf(&buf, "    ~ constraint %v%v\n", cd.new.Name(), formatChanges(cd.Changes()))
+		}
+	}
+	return buf.String()
+}
+
+type jsonChange struct {
+	Attribute string `json:"attribute"`
+	Old       string `json:"old"`
+	New       string `json:"new"`
+}
+
+type jsonObjectDiff struct {
+	Name    string        `json:"name"`
+	Changes []*jsonChange `json:"changes"`
+}
+
+type jsonTableRename struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+type jsonTableDiff struct {
+	Table              string            `json:"table"`
+	Changes            []*jsonChange     `json:"changes"`
+	AddedColumns       []string          `json:"added_columns"`
+	RemovedColumns     []string          `json:"removed_columns"`
+	ChangedColumns     []*jsonObjectDiff `json:"changed_columns"`
+	AddedIndices       []string          `json:"added_indices"`
+	RemovedIndices     []string          `json:"removed_indices"`
+	ChangedIndices     []*jsonObjectDiff `json:"changed_indices"`
+	AddedForeignKeys   []string          `json:"added_foreign_keys"`
+	RemovedForeignKeys []string          `json:"removed_foreign_keys"`
+	ChangedForeignKeys []*jsonObjectDiff `json:"changed_foreign_keys"`
+	AddedConstraints   []string          `json:"added_constraints"`
+	RemovedConstraints []string          `json:"removed_constraints"`
+	ChangedConstraints []*jsonObjectDiff `json:"changed_constraints"`
+}
+
+type jsonSchemaDiff struct {
+	AddedTables      []string           `json:"added_tables"`
+	RemovedTables    []string           `json:"removed_tables"`
+	RenameCandidates []*jsonTableRename `json:"rename_candidates"`
+	ChangedTables    []*jsonTableDiff   `json:"changed_tables"`
+}
+
+// MarshalJSON returns JSON rendering of diff.
+// Tables are rendered as "schema.name", and other objects are rendered as name.
+// Every list is rendered as array even if it is empty.
+func (d SchemaDiff) MarshalJSON() ([]byte, error) {
+	jd := jsonSchemaDiff{
+		AddedTables:      make([]string, 0, len(d.addedTables)),
+		RemovedTables:    make([]string, 0, len(d.removedTables)),
+		RenameCandidates: make([]*jsonTableRename, 0, len(d.renameCandidates)),
+		ChangedTables:    make([]*jsonTableDiff, 0, len(d.changedTables)),
+	}
+	for _, tbl := range d.addedTables {
+		jd.AddedTables = append(jd.AddedTables, tableKey(tbl.Schema(), tbl.Name()))
+	}
+	for _, tbl := range d.removedTables {
+		jd.RemovedTables = append(jd.RemovedTables, tableKey(tbl.Schema(), tbl.Name()))
+	}
+	for _, r := range d.renameCandidates {
+		jd.RenameCandidates = append(jd.RenameCandidates, &jsonTableRename{
+			Old: tableKey(r.old.Schema(), r.old.Name()),
+			New: tableKey(r.new.Schema(), r.new.Name()),
+		})
+	}
+	for _, td := range d.changedTables {
+		jtd := &jsonTableDiff{
+			Table:              tableKey(td.new.Schema(), td.new.Name()),
+			Changes:            jsonChanges(td.Changes()),
+			AddedColumns:       make([]string, 0, len(td.addedColumns)),
+			RemovedColumns:     make([]string, 0, len(td.removedColumns)),
+			ChangedColumns:     make([]*jsonObjectDiff, 0, len(td.changedColumns)),
+			AddedIndices:       make([]string, 0, len(td.addedIndices)),
+			RemovedIndices:     make([]string, 0, len(td.removedIndices)),
+			ChangedIndices:     make([]*jsonObjectDiff, 0, len(td.changedIndices)),
+			AddedForeignKeys:   make([]string, 0, len(td.addedForeignKeys)),
+			RemovedForeignKeys: make([]string, 0, len(td.removedForeignKeys)),
+			ChangedForeignKeys: make([]*jsonObjectDiff, 0, len(td.changedForeignKeys)),
+			AddedConstraints:   make([]string, 0, len(td.addedConstraints)),
+			RemovedConstraints: make([]string, 0, len(td.removedConstraints)),
+			ChangedConstraints: make([]*jsonObjectDiff, 0, len(td.changedConstraints)),
+		}
+		for _, col := range td.addedColumns {
+			jtd.AddedColumns = append(jtd.AddedColumns, col.Name())
+		}
+		for _, col := range td.removedColumns {
+			jtd.RemovedColumns = append(jtd.RemovedColumns, col.Name())
+		}
+		for _, cd := range td.changedColumns {
+			jtd.ChangedColumns = append(jtd.ChangedColumns, &jsonObjectDiff{Name: cd.new.Name(), Changes: jsonChanges(cd.Changes())})
+		}
+		for _, idx := range td.addedIndices {
+			jtd.AddedIndices = append(jtd.AddedIndices, idx.Name())
+		}
+		for _, idx := range td.removedIndices {
+			jtd.RemovedIndices = append(jtd.RemovedIndices, idx.Name())
+		}
+		for _, id := range td.changedIndices {
+			jtd.ChangedIndices = append(jtd.ChangedIndices, &jsonObjectDiff{Name: id.new.Name(), Changes: jsonChanges(id.Changes())})
+		}
+		for _, fk := range td.addedForeignKeys {
+			jtd.AddedForeignKeys = append(jtd.AddedForeignKeys, fk.Name())
+		}
+		for _, fk := range td.removedForeignKeys {
+			jtd.RemovedForeignKeys = append(jtd.RemovedForeignKeys, fk.Name())
+		}
+		for _, fd := range td.changedForeignKeys {
+			jtd.ChangedForeignKeys = append(jtd.ChangedForeignKeys, &jsonObjectDiff{Name: fd.new.Name(), Changes: jsonChanges(fd.Changes())})
+		}
+		for _, con := range td.addedConstraints {
+			jtd.AddedConstraints = append(jtd.AddedConstraints, con.Name())
+		}
+		for _, con := range td.removedConstraints {
+			jtd.RemovedConstraints = append(jtd.RemovedConstraints, con.Name())
+		}
+		for _, cd := range td.changedConstraints {
+			jtd.ChangedConstraints = append(jtd.ChangedConstraints, &jsonObjectDiff{Name: cd.new.Name(), Changes: jsonChanges(cd.Changes())})
+		}
+		jd.ChangedTables = append(jd.ChangedTables, jtd)
+	}
+	return json.Marshal(jd)
+}
+
+func jsonChanges(changes []*Change) []*jsonChange {
+	jcs := make([]*jsonChange, 0, len(changes))
+	for _, c := range changes {
+		jcs = append(jcs, &jsonChange{Attribute: c.attribute, Old: c.old, New: c.new})
+	}
+	return jcs
+}
+
+func appendChange(changes []*Change, attr string, old string, new string) []*Change {
+	if old == new {
+		return changes
+	}
+	return append(changes, &Change{attribute: attr, old: old, new: new})
+}
+
+func formatChanges(changes []*Change) string {
+	if len(changes) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(changes))
+	for _, c := range changes {
+		parts = append(parts, fmt.Sprintf("%v %v -> %v", c.attribute, formatValue(c.old), formatValue(c.new)))
+	}
+	return ": " + strings.Join(parts, ", ")
+}
+
+func formatValue(v string) string {
+	if v == "" {
+		return `""`
+	}
+	return v
+}
+
+func formatNames(names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	return "(" + strings.Join(names, ", ") + ")"
+}
+
+func formatReferences(fk *ForeignKey) string {
+	froms := make([]string, 0, len(fk.ColumnReferences()))
+	tos := make([]string, 0, len(fk.ColumnReferences()))
+	target := ""
+	for _, cr := range fk.ColumnReferences() {
+		froms = append(froms, cr.From().Name())
+		tos = append(tos, cr.To().Name())
+		target = tableKey(cr.To().Schema(), cr.To().TableName())
+	}
+	return formatNames(froms) + " -> " + target + formatNames(tos)
+}
+
+func primaryKeyNames(tbl *Table) []string {
+	cols := make([]*Column, 0, 2)
+	for _, col := range tbl.Columns() {
+		if col.PrimaryKeyPosition() > 0 {
+			cols = append(cols, col)
+		}
+	}
+	sort.SliceStable(cols, func(i, j int) bool {
+		return cols[i].PrimaryKeyPosition() < cols[j].PrimaryKeyPosition()
+	})
+	names := make([]string, 0, len(cols))
+	for _, col := range cols {
+		names = append(names, col.Name())
+	}
+	return names
+}
+
+func indexColumnNames(idx *Index) []string {
+	names := make([]string, 0, len(idx.Columns()))
+	for _, col := range idx.Columns() {
+		names = append(names, col.Name())
+	}
+	return names
+}
+
+// columnSignature returns sorted column names and data types of table.
+// Table without columns has empty signature, and it is not matched as rename candidate.
+func columnSignature(tbl *Table) string {
+	sigs := make([]string, 0, len(tbl.Columns()))
+	for _, col := range tbl.Columns() {
+		sigs = append(sigs, col.Name()+" "+col.DataType())
+	}
+	sort.Strings(sigs)
+	return strings.Join(sigs, ",")
+}
+
+func sortedTableKeys(tbls []*Table) []string {
+	keys := make([]string, 0, len(tbls))
+	for _, tbl := range tbls {
+		keys = append(keys, tableKey(tbl.Schema(), tbl.Name()))
+	}
+	sort.Strings(keys)
+	return keys
+}
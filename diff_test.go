@@ -0,0 +1,272 @@
+package dbmodel
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const diffOldYAML = `
+schemas:
+  - name: foo
+    tables:
+      - name: users
+        columns:
+          - name: id
+            type: integer
+            primary_key: 1
+          - name: name
+            type: character varying
+            length: 50
+          - name: title
+            type: text
+        indices:
+          - name: users_name_idx
+            columns: [name]
+      - name: posts
+        columns:
+          - name: id
+            type: integer
+            primary_key: 1
+          - name: user_id
+            type: integer
+        foreign_keys:
+          - name: posts_user_id_fkey
+            columns: [user_id]
+            ref_table: users
+            ref_columns: [id]
+        constraints:
+          - name: posts_id_check
+            kind: CHECK
+            content: (id > 0)
+      - name: old_tags
+        columns:
+          - name: id
+            type: integer
+          - name: label
+            type: text
+      - name: logs
+        columns:
+          - name: id
+            type: integer
+`
+
+const diffNewYAML = `
+schemas:
+  - name: foo
+    tables:
+      - name: users
+        comment: user accounts
+        columns:
+          - name: id
+            type: integer
+            primary_key: 1
+          - name: name
+            type: character varying
+            length: 100
+            nullable: false
+          - name: body
+            type: text
+        indices:
+          - name: users_name_idx
+            unique: true
+            columns: [name]
+          - name: users_body_idx
+            columns: [body]
+      - name: posts
+        columns:
+          - name: id
+            type: integer
+            primary_key: 1
+          - name: user_id
+            type: bigint
+        constraints:
+          - name: posts_id_check
+            kind: CHECK
+            content: (id > 10)
+      - name: tags
+        columns:
+          - name: label
+            type: text
+          - name: id
+            type: integer
+      - name: logs
+        columns:
+          - name: id
+            type: integer
+`
+
+func TestDiffTablesWithoutColumnsAreNotRenameCandidates(t *testing.T) {
+	old := NewTable("foo", "old_empty", "")
+	new := NewTable("foo", "new_empty", "")
+	d := Diff([]*Table{&old}, []*Table{&new})
+	if len(d.RenameCandidates()) != 0 {
+		t.Errorf("Tables without columns should not be rename candidates. (%v)", d.RenameCandidates())
+	}
+}
+
+func TestDiffTables(t *testing.T) {
+	d := newTestDiff(t)
+	if len(d.RemovedTables()) != 1 || d.RemovedTables()[0].Name() != "old_tags" {
+		t.Errorf("RemovedTables() returns invalid tables. (%v)", d.RemovedTables())
+	}
+	if len(d.AddedTables()) != 1 || d.AddedTables()[0].Name() != "tags" {
+		t.Errorf("AddedTables() returns invalid tables. (%v)", d.AddedTables())
+	}
+	if len(d.RenameCandidates()) != 1 || d.RenameCandidates()[0].Old().Name() != "old_tags" || d.RenameCandidates()[0].New().Name() != "tags" {
+		t.Errorf("RenameCandidates() returns invalid pairs. (%v)", d.RenameCandidates())
+	}
+	if len(d.ChangedTables()) != 2 || d.ChangedTables()[0].New().Name() != "posts" || d.ChangedTables()[1].New().Name() != "users" {
+		t.Errorf("ChangedTables() should return posts and users. (%v)", d.ChangedTables())
+	}
+	if d.IsEmpty() {
+		t.Error("IsEmpty() should return false.")
+	}
+}
+
+func TestDiffColumns(t *testing.T) {
+	td := newTestDiff(t).ChangedTables()[1]
+	if len(td.RemovedColumns()) != 1 || td.RemovedColumns()[0].Name() != "title" {
+		t.Errorf("RemovedColumns() returns invalid columns. (%v)", td.RemovedColumns())
+	}
+	if len(td.AddedColumns()) != 1 || td.AddedColumns()[0].Name() != "body" {
+		t.Errorf("AddedColumns() returns invalid columns. (%v)", td.AddedColumns())
+	}
+	if len(td.ChangedColumns()) != 1 {
+		t.Fatalf("ChangedColumns() returns invalid columns. (%v)", td.ChangedColumns())
+	}
+	cd := td.ChangedColumns()[0]
+	if cd.TypeChanged() || !cd.SizeChanged() || !cd.NullableChanged() || cd.DefaultChanged() || cd.CommentChanged() {
+		t.Errorf("ColumnDiff reports invalid changes. (%v)", cd.Changes())
+	}
+	changes := cd.Changes()
+	if len(changes) != 2 || changes[0].Attribute() != "size" || changes[0].Old() != "50" || changes[0].New() != "100" {
+		t.Errorf("Changes() returns invalid changes. (%#v)", changes)
+	}
+	if expected, actual := "comment", td.Changes()[0].Attribute(); len(td.Changes()) != 1 || actual != expected {
+		t.Errorf("Table changes are invalid. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestDiffIndicesForeignKeysAndConstraints(t *testing.T) {
+	d := newTestDiff(t)
+	posts, users := d.ChangedTables()[0], d.ChangedTables()[1]
+	if len(users.AddedIndices()) != 1 || users.AddedIndices()[0].Name() != "users_body_idx" {
+		t.Errorf("AddedIndices() returns invalid indices. (%v)", users.AddedIndices())
+	}
+	if len(users.ChangedIndices()) != 1 || users.ChangedIndices()[0].Changes()[0].Attribute() != "unique" {
+		t.Errorf("ChangedIndices() returns invalid indices. (%v)", users.ChangedIndices())
+	}
+	if len(posts.RemovedForeignKeys()) != 1 || posts.RemovedForeignKeys()[0].Name() != "posts_user_id_fkey" {
+		t.Errorf("RemovedForeignKeys() returns invalid foreign keys. (%v)", posts.RemovedForeignKeys())
+	}
+	if len(posts.ChangedConstraints()) != 1 || posts.ChangedConstraints()[0].Changes()[0].New() != "(id > 10)" {
+		t.Errorf("ChangedConstraints() returns invalid constraints. (%v)", posts.ChangedConstraints())
+	}
+	if posts.PrimaryKeyChanged() {
+		t.Error("PrimaryKeyChanged() should return false.")
+	}
+}
+
+func TestDiffPrimaryKey(t *testing.T) {
+	old := NewTable("foo", "users", "")
+	id := NewColumn("foo", "users", "id", "", "integer", Size{}, false, "", 1)
+	old.AddColumn(&id)
+	new := NewTable("foo", "users", "")
+	nid := NewColumn("foo", "users", "id", "", "integer", Size{}, false, "", 0)
+	new.AddColumn(&nid)
+
+	d := Diff([]*Table{&old}, []*Table{&new})
+	if len(d.ChangedTables()) != 1 || !d.ChangedTables()[0].PrimaryKeyChanged() {
+		t.Fatal("PrimaryKeyChanged() should return true.")
+	}
+	c := d.ChangedTables()[0].Changes()[0]
+	if c.Attribute() != "primary_key" || c.Old() != "(id)" || c.New() != "" {
+		t.Errorf("Primary key change is invalid. (%#v)", c)
+	}
+}
+
+func TestDiffSameTables(t *testing.T) {
+	db := loadTestYAML(t)
+	d := Diff(db.Tables(), db.Tables())
+	if !d.IsEmpty() {
+		t.Errorf("Diff of same tables should be empty. (%v)", d)
+	}
+	if expected, actual := "", d.String(); actual != expected {
+		t.Errorf("String() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestSchemaDiffString(t *testing.T) {
+	expected := `- table foo.old_tags
++ table foo.tags
+? rename foo.old_tags -> foo.tags
+~ table foo.posts
+    ~ column user_id: type integer -> bigint
+    - foreign key posts_user_id_fkey
+    ~ constraint posts_id_check: content (id > 0) -> (id > 10)
+~ table foo.users: comment "" -> user accounts
+    - column title
+    + column body
+    ~ column name: size 50 -> 100, nullable true -> false
+    + index users_body_idx
+    ~ index users_name_idx: unique false -> true
+`
+	if actual := newTestDiff(t).String(); actual != expected {
+		t.Errorf("String() returns invalid value.\nexpected:\n%v\nactual:\n%v", expected, actual)
+	}
+}
+
+func TestSchemaDiffMarshalJSON(t *testing.T) {
+	b, err := json.Marshal(newTestDiff(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m struct {
+		AddedTables      []string `json:"added_tables"`
+		RenameCandidates []struct {
+			Old string `json:"old"`
+			New string `json:"new"`
+		} `json:"rename_candidates"`
+		ChangedTables []struct {
+			Table          string   `json:"table"`
+			AddedColumns   []string `json:"added_columns"`
+			ChangedColumns []struct {
+				Name    string `json:"name"`
+				Changes []struct {
+					Attribute string `json:"attribute"`
+					Old       string `json:"old"`
+					New       string `json:"new"`
+				} `json:"changes"`
+			} `json:"changed_columns"`
+		} `json:"changed_tables"`
+	}
+	if err = json.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.AddedTables) != 1 || m.AddedTables[0] != "foo.tags" {
+		t.Errorf("added_tables is invalid. (%v)", string(b))
+	}
+	if len(m.RenameCandidates) != 1 || m.RenameCandidates[0].Old != "foo.old_tags" {
+		t.Errorf("rename_candidates is invalid. (%v)", string(b))
+	}
+	cc := m.ChangedTables[0].ChangedColumns[0]
+	if cc.Name != "user_id" || cc.Changes[0].Attribute != "type" || cc.Changes[0].New != "bigint" {
+		t.Errorf("changed_columns is invalid. (%v)", string(b))
+	}
+	if !strings.Contains(string(b), `"added_foreign_keys":[]`) {
+		t.Errorf("Empty list should be rendered as array. (%v)", string(b))
+	}
+}
+
+func newTestDiff(t *testing.T) *SchemaDiff {
+	old, err := LoadYAML(strings.NewReader(diffOldYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	new, err := LoadYAML(strings.NewReader(diffNewYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Diff(old.Tables(), new.Tables())
+}
@@ -0,0 +1,96 @@
+package doc
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+// WriteColumnsCSV writes columns of tables in CSV with header.
+// Columns of CSV are schema, table, column, type, length, precision, scale, nullable, default, pk_position and comment.
+// Null size and pk_position of non primary key column are written as empty.
+func WriteColumnsCSV(w io.Writer, tbls []*dbmodel.Table) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"schema", "table", "column", "type", "length", "precision", "scale", "nullable", "default", "pk_position", "comment"})
+	for _, tbl := range tbls {
+		for _, col := range tbl.Columns() {
+			pk := ""
+			if col.PrimaryKeyPosition() > 0 {
+				pk = strconv.FormatInt(col.PrimaryKeyPosition(), 10)
+			}
+			cw.Write([]string{
+				col.Schema(),
+				col.TableName(),
+				col.Name(),
+				col.DataType(),
+				csvNullInt(col.Size().Length()),
+				csvNullInt(col.Size().Precision()),
+				csvNullInt(col.Size().Scale()),
+				strconv.FormatBool(col.IsNullable()),
+				col.DefaultValue(),
+				pk,
+				col.Comment(),
+			})
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteIndicesCSV writes a row per index column in CSV with header.
+// Columns of CSV are schema, table, index, unique, position and column.
+func WriteIndicesCSV(w io.Writer, tbls []*dbmodel.Table) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"schema", "table", "index", "unique", "position", "column"})
+	for _, tbl := range tbls {
+		for _, idx := range tbl.Indices() {
+			for i, col := range idx.Columns() {
+				cw.Write([]string{
+					idx.Schema(),
+					idx.TableName(),
+					idx.Name(),
+					strconv.FormatBool(idx.IsUnique()),
+					strconv.Itoa(i + 1),
+					col.Name(),
+				})
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteForeignKeysCSV writes a row per column pair of foreign key in CSV with header.
+// Columns of CSV are schema, table, foreign_key, position, column, ref_schema, ref_table and ref_column.
+func WriteForeignKeysCSV(w io.Writer, tbls []*dbmodel.Table) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"schema", "table", "foreign_key", "position", "column", "ref_schema", "ref_table", "ref_column"})
+	for _, tbl := range tbls {
+		for _, fk := range tbl.ForeignKeys() {
+			for i, cr := range fk.ColumnReferences() {
+				cw.Write([]string{
+					fk.Schema(),
+					fk.TableName(),
+					fk.Name(),
+					strconv.Itoa(i + 1),
+					cr.From().Name(),
+					cr.To().Schema(),
+					cr.To().TableName(),
+					cr.To().Name(),
+				})
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvNullInt(n sql.NullInt64) string {
+	if !n.Valid {
+		return ""
+	}
+	return strconv.FormatInt(n.Int64, 10)
+}
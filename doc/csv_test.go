@@ -0,0 +1,56 @@
+package doc
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteColumnsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteColumnsCSV(&buf, loadTestTables(t)); err != nil {
+		t.Fatal(err)
+	}
+	expected := `schema,table,column,type,length,precision,scale,nullable,default,pk_position,comment
+foo,users,id,int4,,32,0,false,,1,
+foo,users,email,varchar,255,,,false,,,login id
+foo,users,name,text,,,,true,,,
+foo,posts,id,int4,,32,0,false,,1,
+foo,posts,user_id,int4,,32,0,true,,,
+foo,posts,body,text,,,,true,''::text,,
+foo,items,id,int4,,,,false,nextval('items_id_seq'::regclass),1,
+foo,items,user_id,int4,,,,false,,,
+foo,items,price,numeric,,10,2,true,,,price | tax included
+bar,active_users,id,int4,,,,true,,,
+`
+	if actual := buf.String(); actual != expected {
+		t.Errorf("WriteColumnsCSV() writes invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestWriteIndicesCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteIndicesCSV(&buf, loadTestTables(t)); err != nil {
+		t.Fatal(err)
+	}
+	expected := `schema,table,index,unique,position,column
+foo,users,users_email_key,true,1,email
+foo,items,items_user_id_idx,false,1,user_id
+`
+	if actual := buf.String(); actual != expected {
+		t.Errorf("WriteIndicesCSV() writes invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestWriteForeignKeysCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteForeignKeysCSV(&buf, loadTestTables(t)); err != nil {
+		t.Fatal(err)
+	}
+	expected := `schema,table,foreign_key,position,column,ref_schema,ref_table,ref_column
+foo,posts,posts_user_id_fkey,1,user_id,foo,users,id
+foo,items,items_user_id_fkey,1,user_id,foo,users,id
+`
+	if actual := buf.String(); actual != expected {
+		t.Errorf("WriteForeignKeysCSV() writes invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
@@ -0,0 +1,122 @@
+// Package doc generates documents of tables loaded by dbmodel.
+package doc
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+// ErrTablesEmpty is raised when document requires tables but no table is given.
+var ErrTablesEmpty = errors.New("Tables are empty")
+
+// Page is a file of generated document.
+type Page struct {
+	name    string
+	content []byte
+}
+
+// Name returns file name of page.
+func (p Page) Name() string {
+	return p.name
+}
+
+// Content returns content of page.
+func (p Page) Content() []byte {
+	return p.content
+}
+
+// NewPage returns new Page initialized with arguments.
+func NewPage(name string, content []byte) Page {
+	return Page{
+		name:    name,
+		content: content,
+	}
+}
+
+// WritePages writes pages into given directory.
+// Directory is created if it does not exist.
+// If name of some page is not a file name in directory (eg. "../foo.md"), WritePages returns error without writing.
+func WritePages(dir string, pages []*Page) error {
+	for _, p := range pages {
+		if name := p.Name(); name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+			return fmt.Errorf("Page name '%v' is not a file name.", name)
+		}
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, p := range pages {
+		if err := ioutil.WriteFile(filepath.Join(dir, p.Name()), p.Content(), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FullType returns data type of column with size. (eg. "varchar(255)", "numeric(10, 2)")
+func FullType(col *dbmodel.Column) string {
+	if col.Size().IsValid() {
+		return col.DataType() + "(" + col.Size().String() + ")"
+	}
+	return col.DataType()
+}
+
+// QualifiedName returns table name qualified by schema.
+func QualifiedName(tbl *dbmodel.Table) string {
+	return tbl.Schema() + "." + tbl.Name()
+}
+
+var pageNameReplacer = strings.NewReplacer("/", "_", `\`, "_")
+
+// PageBaseName returns file name of table page without extension. (eg. "public.users")
+// Path separators in schema and table name are replaced with "_", so that page is written just in directory.
+func PageBaseName(schema string, name string) string {
+	return pageNameReplacer.Replace(schema + "." + name)
+}
+
+// ColumnNames returns names of columns joined by ", ".
+func ColumnNames(cols []*dbmodel.Column) string {
+	names := make([]string, 0, len(cols))
+	for _, col := range cols {
+		names = append(names, col.Name())
+	}
+	return strings.Join(names, ", ")
+}
+
+// referencedTable returns schema and name of table that foreign key references.
+func referencedTable(fk *dbmodel.ForeignKey) (string, string) {
+	if crs := fk.ColumnReferences(); len(crs) > 0 {
+		return crs[0].To().Schema(), crs[0].To().TableName()
+	}
+	return "", ""
+}
+
+func fromColumns(fk *dbmodel.ForeignKey) []*dbmodel.Column {
+	cols := make([]*dbmodel.Column, 0, len(fk.ColumnReferences()))
+	for _, cr := range fk.ColumnReferences() {
+		cols = append(cols, cr.From())
+	}
+	return cols
+}
+
+func toColumns(fk *dbmodel.ForeignKey) []*dbmodel.Column {
+	cols := make([]*dbmodel.Column, 0, len(fk.ColumnReferences()))
+	for _, cr := range fk.ColumnReferences() {
+		cols = append(cols, cr.To())
+	}
+	return cols
+}
+
+func tableSet(tbls []*dbmodel.Table) map[string]bool {
+	set := make(map[string]bool, len(tbls))
+	for _, tbl := range tbls {
+		set[QualifiedName(tbl)] = true
+	}
+	return set
+}
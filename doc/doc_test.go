@@ -0,0 +1,158 @@
+package doc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+// loadTestTables loads tables from testdata/schema.yml.
+func loadTestTables(t *testing.T) []*dbmodel.Table {
+	f, err := os.Open("testdata/schema.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	db, err := dbmodel.LoadYAML(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db.Tables()
+}
+
+func findTestColumn(t *testing.T, tbls []*dbmodel.Table, table string, column string) *dbmodel.Column {
+	for _, tbl := range tbls {
+		if tbl.Name() == table {
+			if col, ok := tbl.FindColumn(column); ok {
+				return col
+			}
+		}
+	}
+	t.Fatalf("Column '%v.%v' is not found.", table, column)
+	return nil
+}
+
+func TestFullType(t *testing.T) {
+	tbls := loadTestTables(t)
+	tests := []struct {
+		table    string
+		column   string
+		expected string
+	}{
+		{"users", "id", "int4(32, 0)"},
+		{"users", "email", "varchar(255)"},
+		{"users", "name", "text"},
+		{"items", "price", "numeric(10, 2)"},
+	}
+	for _, test := range tests {
+		col := findTestColumn(t, tbls, test.table, test.column)
+		if actual := FullType(col); actual != test.expected {
+			t.Errorf("FullType() returns invalid value. expected: %v, actual: %v", test.expected, actual)
+		}
+	}
+}
+
+func TestWritePages(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dbmodel-doc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	out := filepath.Join(dir, "out")
+	p := NewPage("index.md", []byte("# Tables\n"))
+	if err = WritePages(out, []*Page{&p}); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(out, "index.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected, actual := "# Tables\n", string(b); actual != expected {
+		t.Errorf("WritePages() writes invalid content. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestWritePagesWithInvalidName(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dbmodel-doc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	out := filepath.Join(dir, "out")
+	for _, name := range []string{"", "..", "../index.md", `a\b.md`} {
+		p := NewPage(name, []byte("# Tables\n"))
+		if err := WritePages(out, []*Page{&p}); err == nil {
+			t.Errorf("WritePages() should return error for page name '%v'.", name)
+		}
+	}
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Errorf("WritePages() should not create directory for invalid page name. (%v)", err)
+	}
+}
+
+func TestPageBaseName(t *testing.T) {
+	tests := []struct {
+		schema   string
+		name     string
+		expected string
+	}{
+		{"foo", "users", "foo.users"},
+		{"foo", "a/../../x", "foo.a_.._.._x"},
+		{`a\b`, "c", "a_b.c"},
+	}
+	for _, test := range tests {
+		if actual := PageBaseName(test.schema, test.name); actual != test.expected {
+			t.Errorf("PageBaseName() returns invalid value. expected: %v, actual: %v", test.expected, actual)
+		}
+	}
+}
+
+func TestPagesOfTableWithPathSeparator(t *testing.T) {
+	usr := dbmodel.NewTable("foo", "a/../../x", "")
+	id := dbmodel.NewColumn("foo", "a/../../x", "id", "", "int4", dbmodel.Size{}, false, "", 1)
+	usr.AddColumn(&id)
+	pst := dbmodel.NewTable("foo", "posts", "")
+	userID := dbmodel.NewColumn("foo", "posts", "user_id", "", "int4", dbmodel.Size{}, true, "", 0)
+	pst.AddColumn(&userID)
+	fk := dbmodel.NewForeignKey("foo", "posts", "posts_user_id_fkey")
+	cr := dbmodel.NewColumnReference(&userID, &id)
+	fk.AddColumnReference(&cr)
+	pst.AddForeignKey(&fk)
+	tbls := []*dbmodel.Table{&usr, &pst}
+
+	html, err := HTML(tbls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected, actual := "foo.a_.._.._x.html", html[1].Name(); actual != expected {
+		t.Errorf("HTMLPageName() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := `<a href="foo.a_.._.._x.html">`, string(html[2].Content()); !strings.Contains(actual, expected) {
+		t.Errorf("HTML() should link %v. actual: %v", expected, actual)
+	}
+	md := Markdown(tbls)
+	if expected, actual := "foo.a_.._.._x.md", md[1].Name(); actual != expected {
+		t.Errorf("MarkdownPageName() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := "(foo.a_.._.._x.md)", string(md[2].Content()); !strings.Contains(actual, expected) {
+		t.Errorf("Markdown() should link %v. actual: %v", expected, actual)
+	}
+
+	dir, err := ioutil.TempDir("", "dbmodel-doc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := WritePages(dir, md); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "foo.a_.._.._x.md")); err != nil {
+		t.Errorf("Page should be written in directory. (%v)", err)
+	}
+}
@@ -0,0 +1,261 @@
+package doc
+
+import (
+	"bytes"
+	"html/template"
+	"strconv"
+	"strings"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+// HTMLIndexName is file name of index page of HTML document.
+const HTMLIndexName = "index.html"
+
+// HTML returns pages of self-contained HTML site that browses given tables.
+// First page is index page that has searchable table list, and following pages are pages of each table named "<schema>.<table>.html".
+// Pages do not require any network assets, so that site can be browsed offline.
+// Tables and columns in foreign keys and referenced keys are linked to their pages if they are given.
+func HTML(tbls []*dbmodel.Table) ([]*Page, error) {
+	pages := make([]*Page, 0, len(tbls)+1)
+	index, err := HTMLIndex(tbls)
+	if err != nil {
+		return nil, err
+	}
+	p := NewPage(HTMLIndexName, index)
+	pages = append(pages, &p)
+	set := tableSet(tbls)
+	for _, tbl := range tbls {
+		b, err := htmlTable(tbl, set)
+		if err != nil {
+			return nil, err
+		}
+		p := NewPage(HTMLPageName(tbl), b)
+		pages = append(pages, &p)
+	}
+	return pages, nil
+}
+
+// HTMLPageName returns file name of table page. (see PageBaseName)
+func HTMLPageName(tbl *dbmodel.Table) string {
+	return PageBaseName(tbl.Schema(), tbl.Name()) + ".html"
+}
+
+// HTMLIndex returns index page that lists given tables with search box.
+func HTMLIndex(tbls []*dbmodel.Table) ([]byte, error) {
+	var buf bytes.Buffer
+	err := htmlTemplate.ExecuteTemplate(&buf, "index", tbls)
+	return buf.Bytes(), err
+}
+
+// HTMLTable returns page of given table.
+// Tables in foreign keys and referenced keys are always linked.
+func HTMLTable(tbl *dbmodel.Table) ([]byte, error) {
+	return htmlTable(tbl, nil)
+}
+
+type htmlTableData struct {
+	*dbmodel.Table
+	set map[string]bool
+}
+
+type htmlReference struct {
+	Name        string
+	Table       string
+	Link        string
+	FromColumns []*dbmodel.Column
+	ToColumns   []*dbmodel.Column
+}
+
+func (d htmlTableData) References(fks []*dbmodel.ForeignKey, referenced bool) []htmlReference {
+	refs := make([]htmlReference, 0, len(fks))
+	for _, fk := range fks {
+		schema, name := referencedTable(fk)
+		if referenced {
+			schema, name = fk.Schema(), fk.TableName()
+		}
+		ref := htmlReference{
+			Name:        fk.Name(),
+			Table:       schema + "." + name,
+			FromColumns: fromColumns(fk),
+			ToColumns:   toColumns(fk),
+		}
+		if d.set == nil || d.set[ref.Table] {
+			ref.Link = PageBaseName(schema, name) + ".html"
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+type htmlRefColumns struct {
+	Link    string
+	Columns []*dbmodel.Column
+}
+
+func htmlTable(tbl *dbmodel.Table, set map[string]bool) ([]byte, error) {
+	var buf bytes.Buffer
+	err := htmlTemplate.ExecuteTemplate(&buf, "table", htmlTableData{Table: tbl, set: set})
+	return buf.Bytes(), err
+}
+
+var htmlFuncs = template.FuncMap{
+	"fullType":      FullType,
+	"qualifiedName": QualifiedName,
+	"columnNames":   ColumnNames,
+	"pageName":      HTMLPageName,
+	"yesNo":         yesNo,
+	"columnID":      htmlColumnID,
+	"comment":       htmlComment,
+	"pk": func(pos int64) string {
+		if pos > 0 {
+			return strconv.FormatInt(pos, 10)
+		}
+		return ""
+	},
+	"inc": func(i int) int {
+		return i + 1
+	},
+	"refColumns": func(link string, cols []*dbmodel.Column) htmlRefColumns {
+		return htmlRefColumns{Link: link, Columns: cols}
+	},
+	"search": func(tbl *dbmodel.Table) string {
+		return strings.ToLower(QualifiedName(tbl) + " " + tbl.Comment())
+	},
+}
+
+func htmlColumnID(col *dbmodel.Column) string {
+	return "column-" + col.Name()
+}
+
+// htmlComment escapes comment and keeps line breaks.
+func htmlComment(s string) template.HTML {
+	lines := strings.Split(strings.Replace(s, "\r\n", "\n", -1), "\n")
+	for i, line := range lines {
+		lines[i] = template.HTMLEscapeString(line)
+	}
+	return template.HTML(strings.Join(lines, "<br>"))
+}
+
+const htmlStyle = `<style>
+body { font-family: sans-serif; margin: 2em; color: #333; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #f0f0f0; }
+tr:target { background: #ffc; }
+code { font-family: monospace; }
+input { font-size: 1em; padding: 4px; width: 20em; margin-bottom: 1em; }
+</style>`
+
+var htmlTemplate = template.Must(template.New("html").Funcs(htmlFuncs).Parse(`
+{{- define "index" -}}
+<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Tables</title>
+` + htmlStyle + `
+</head>
+<body>
+<h1>Tables</h1>
+<input id="search" type="search" placeholder="Search tables" oninput="filterTables(this.value)">
+<table id="tables">
+<thead><tr><th>Schema</th><th>Name</th><th>Kind</th><th>Comment</th></tr></thead>
+<tbody>
+{{- range . }}
+<tr data-search="{{ search . }}"><td>{{ .Schema }}</td><td><a href="{{ pageName . }}">{{ .Name }}</a></td><td>{{ .Kind }}</td><td>{{ comment .Comment }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+<script>
+function filterTables(q) {
+  var words = q.toLowerCase().split(/\s+/).filter(function (w) { return w !== ""; });
+  var rows = document.querySelectorAll("#tables tbody tr");
+  for (var i = 0; i < rows.length; i++) {
+    var s = rows[i].getAttribute("data-search");
+    rows[i].style.display = words.every(function (w) { return s.indexOf(w) >= 0; }) ? "" : "none";
+  }
+}
+</script>
+</body>
+</html>
+{{ end -}}
+
+{{- define "refColumns" -}}
+{{- $link := .Link }}{{ range $i, $col := .Columns }}{{ if $i }}, {{ end }}{{ if $link }}<a href="{{ $link }}#{{ columnID $col }}">{{ $col.Name }}</a>{{ else }}{{ $col.Name }}{{ end }}{{ end -}}
+{{- end -}}
+
+{{- define "table" -}}
+<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{ qualifiedName .Table }}</title>
+` + htmlStyle + `
+</head>
+<body>
+<p><a href="index.html">Tables</a></p>
+<h1>{{ qualifiedName .Table }}</h1>
+{{- if .Comment }}
+<p>{{ comment .Comment }}</p>
+{{- end }}
+{{- if ne .Kind "TABLE" }}
+<p>Kind: {{ .Kind }}</p>
+{{- end }}
+<h2>Columns</h2>
+<table>
+<thead><tr><th>#</th><th>Name</th><th>Type</th><th>Nullable</th><th>Default</th><th>PK</th><th>Comment</th></tr></thead>
+<tbody>
+{{- range $i, $col := .Columns }}
+<tr id="{{ columnID $col }}"><td>{{ inc $i }}</td><td>{{ $col.Name }}</td><td>{{ fullType $col }}</td><td>{{ yesNo $col.IsNullable }}</td><td>{{ with $col.DefaultValue }}<code>{{ . }}</code>{{ end }}</td><td>{{ pk $col.PrimaryKeyPosition }}</td><td>{{ comment $col.Comment }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- if .Indices }}
+<h2>Indices</h2>
+<table>
+<thead><tr><th>Name</th><th>Columns</th><th>Unique</th></tr></thead>
+<tbody>
+{{- range .Indices }}
+<tr><td>{{ .Name }}</td><td>{{ columnNames .Columns }}</td><td>{{ yesNo .IsUnique }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- end }}
+{{- if .Constraints }}
+<h2>Constraints</h2>
+<table>
+<thead><tr><th>Name</th><th>Kind</th><th>Content</th></tr></thead>
+<tbody>
+{{- range .Constraints }}
+<tr><td>{{ .Name }}</td><td>{{ .Kind }}</td><td><code>{{ .Content }}</code></td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- end }}
+{{- if .ForeignKeys }}
+<h2>Foreign keys</h2>
+<table>
+<thead><tr><th>Name</th><th>Columns</th><th>Referenced table</th><th>Referenced columns</th></tr></thead>
+<tbody>
+{{- range .References .ForeignKeys false }}
+<tr><td>{{ .Name }}</td><td>{{ columnNames .FromColumns }}</td><td>{{ if .Link }}<a href="{{ .Link }}">{{ .Table }}</a>{{ else }}{{ .Table }}{{ end }}</td><td>{{ template "refColumns" (refColumns .Link .ToColumns) }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- end }}
+{{- if .ReferencedKeys }}
+<h2>Referenced keys</h2>
+<table>
+<thead><tr><th>Name</th><th>Referencing table</th><th>Referencing columns</th><th>Columns</th></tr></thead>
+<tbody>
+{{- range .References .ReferencedKeys true }}
+<tr><td>{{ .Name }}</td><td>{{ if .Link }}<a href="{{ .Link }}">{{ .Table }}</a>{{ else }}{{ .Table }}{{ end }}</td><td>{{ template "refColumns" (refColumns .Link .FromColumns) }}</td><td>{{ columnNames .ToColumns }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- end }}
+</body>
+</html>
+{{ end -}}
+`))
@@ -0,0 +1,93 @@
+package doc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHTML(t *testing.T) {
+	pages, err := HTML(loadTestTables(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pages) != 5 {
+		t.Fatalf("Page count is invalid. expected: %v, actual: %v", 5, len(pages))
+	}
+	for i, name := range []string{"index.html", "foo.users.html", "foo.posts.html", "foo.items.html", "bar.active_users.html"} {
+		if actual := pages[i].Name(); actual != name {
+			t.Errorf("Name() returns invalid value. expected: %v, actual: %v", name, actual)
+		}
+	}
+	for _, p := range pages {
+		if content := string(p.Content()); strings.Contains(content, "http://") || strings.Contains(content, "https://") {
+			t.Errorf("%v should not refer network assets.", p.Name())
+		}
+	}
+}
+
+func TestHTMLIndex(t *testing.T) {
+	b, err := HTMLIndex(loadTestTables(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(b)
+	for _, expected := range []string{
+		`<input id="search" type="search"`,
+		`<tr data-search="foo.users user accounts"><td>foo</td><td><a href="foo.users.html">users</a></td><td>TABLE</td><td>user accounts</td></tr>`,
+		`<td>user&#39;s items</td>`,
+		`function filterTables(q)`,
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("HTMLIndex() should contain %v. actual: %v", expected, content)
+		}
+	}
+}
+
+func TestHTMLTable(t *testing.T) {
+	tbls := loadTestTables(t)
+	b, err := HTMLTable(tbls[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(b)
+	for _, expected := range []string{
+		`<title>foo.items</title>`,
+		`<tr id="column-price"><td>3</td><td>price</td><td>numeric(10, 2)</td><td>YES</td><td></td><td></td><td>price | tax included</td></tr>`,
+		`<td><code>nextval(&#39;items_id_seq&#39;::regclass)</code></td><td>1</td>`,
+		`<tr><td>items_user_id_idx</td><td>user_id</td><td>NO</td></tr>`,
+		`<tr><td>items_price_check</td><td>CHECK</td><td><code>(price &gt; 0)</code></td></tr>`,
+		`<tr><td>items_user_id_fkey</td><td>user_id</td><td><a href="foo.users.html">foo.users</a></td><td><a href="foo.users.html#column-id">id</a></td></tr>`,
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("HTMLTable() should contain %v. actual: %v", expected, content)
+		}
+	}
+}
+
+func TestHTMLTableReferencedKeys(t *testing.T) {
+	b, err := HTMLTable(loadTestTables(t)[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `<tr><td>posts_user_id_fkey</td><td><a href="foo.posts.html">foo.posts</a></td><td><a href="foo.posts.html#column-user_id">user_id</a></td><td>id</td></tr>`
+	if content := string(b); !strings.Contains(content, expected) {
+		t.Errorf("HTMLTable() should contain %v. actual: %v", expected, content)
+	}
+}
+
+func TestHTMLComment(t *testing.T) {
+	if expected, actual := "a &lt;b&gt;<br>c", string(htmlComment("a <b>\r\nc")); actual != expected {
+		t.Errorf("htmlComment() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestHTMLDoesNotLinkMissingTable(t *testing.T) {
+	pages, err := HTML(loadTestTables(t)[1:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `<td>foo.users</td><td>id</td>`
+	if content := string(pages[1].Content()); !strings.Contains(content, expected) {
+		t.Errorf("HTML() should not link table that is not given. actual: %v", content)
+	}
+}
@@ -0,0 +1,174 @@
+package doc
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+// MarkdownIndexName is file name of index page of Markdown document.
+const MarkdownIndexName = "index.md"
+
+// Markdown returns pages of data dictionary written in Markdown.
+// First page is index page that lists tables, and following pages are pages of each table named "<schema>.<table>.md".
+// Table page has columns, indices, constraints, foreign keys and referenced keys,
+// and tables in foreign keys and referenced keys are linked to their pages if they are given.
+func Markdown(tbls []*dbmodel.Table) []*Page {
+	pages := make([]*Page, 0, len(tbls)+1)
+	index := NewPage(MarkdownIndexName, MarkdownIndex(tbls))
+	pages = append(pages, &index)
+	set := tableSet(tbls)
+	for _, tbl := range tbls {
+		p := NewPage(MarkdownPageName(tbl), markdownTable(tbl, set))
+		pages = append(pages, &p)
+	}
+	return pages
+}
+
+// MarkdownPageName returns file name of table page. (see PageBaseName)
+func MarkdownPageName(tbl *dbmodel.Table) string {
+	return PageBaseName(tbl.Schema(), tbl.Name()) + ".md"
+}
+
+// MarkdownIndex returns index page that lists given tables.
+func MarkdownIndex(tbls []*dbmodel.Table) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("# Tables\n\n")
+	writeMarkdownRow(&buf, "Schema", "Name", "Kind", "Comment")
+	writeMarkdownRow(&buf, "---", "---", "---", "---")
+	for _, tbl := range tbls {
+		writeMarkdownRow(&buf,
+			escapeMarkdown(tbl.Schema()),
+			markdownLink(tbl.Name(), MarkdownPageName(tbl)),
+			string(tbl.Kind()),
+			escapeMarkdown(tbl.Comment()))
+	}
+	return buf.Bytes()
+}
+
+// MarkdownTable returns page of given table.
+// Tables in foreign keys and referenced keys are always linked.
+func MarkdownTable(tbl *dbmodel.Table) []byte {
+	return markdownTable(tbl, nil)
+}
+
+// markdownTable renders table page.
+// If set is nil, every table is linked, otherwise only tables in set are linked.
+func markdownTable(tbl *dbmodel.Table, set map[string]bool) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# %v\n\n", escapeMarkdown(QualifiedName(tbl)))
+	if tbl.Comment() != "" {
+		fmt.Fprintf(&buf, "%v\n\n", escapeMarkdown(tbl.Comment()))
+	}
+	if tbl.Kind() != dbmodel.KindTable {
+		fmt.Fprintf(&buf, "Kind: %v\n\n", tbl.Kind())
+	}
+	fmt.Fprintf(&buf, "[Tables](%v)\n\n", MarkdownIndexName)
+
+	buf.WriteString("## Columns\n\n")
+	writeMarkdownRow(&buf, "#", "Name", "Type", "Nullable", "Default", "PK", "Comment")
+	writeMarkdownRow(&buf, "---:", "---", "---", "---", "---", "---:", "---")
+	for i, col := range tbl.Columns() {
+		pk := ""
+		if col.PrimaryKeyPosition() > 0 {
+			pk = strconv.FormatInt(col.PrimaryKeyPosition(), 10)
+		}
+		writeMarkdownRow(&buf,
+			strconv.Itoa(i+1),
+			escapeMarkdown(col.Name()),
+			escapeMarkdown(FullType(col)),
+			yesNo(col.IsNullable()),
+			markdownCode(col.DefaultValue()),
+			pk,
+			escapeMarkdown(col.Comment()))
+	}
+
+	if len(tbl.Indices()) > 0 {
+		buf.WriteString("\n## Indices\n\n")
+		writeMarkdownRow(&buf, "Name", "Columns", "Unique")
+		writeMarkdownRow(&buf, "---", "---", "---")
+		for _, idx := range tbl.Indices() {
+			writeMarkdownRow(&buf, escapeMarkdown(idx.Name()), escapeMarkdown(ColumnNames(idx.Columns())), yesNo(idx.IsUnique()))
+		}
+	}
+
+	if len(tbl.Constraints()) > 0 {
+		buf.WriteString("\n## Constraints\n\n")
+		writeMarkdownRow(&buf, "Name", "Kind", "Content")
+		writeMarkdownRow(&buf, "---", "---", "---")
+		for _, con := range tbl.Constraints() {
+			writeMarkdownRow(&buf, escapeMarkdown(con.Name()), escapeMarkdown(con.Kind()), markdownCode(con.Content()))
+		}
+	}
+
+	if len(tbl.ForeignKeys()) > 0 {
+		buf.WriteString("\n## Foreign keys\n\n")
+		writeMarkdownRow(&buf, "Name", "Columns", "Referenced table", "Referenced columns")
+		writeMarkdownRow(&buf, "---", "---", "---", "---")
+		for _, fk := range tbl.ForeignKeys() {
+			schema, name := referencedTable(fk)
+			writeMarkdownRow(&buf,
+				escapeMarkdown(fk.Name()),
+				escapeMarkdown(ColumnNames(fromColumns(fk))),
+				markdownTableLink(schema, name, set),
+				escapeMarkdown(ColumnNames(toColumns(fk))))
+		}
+	}
+
+	if len(tbl.ReferencedKeys()) > 0 {
+		buf.WriteString("\n## Referenced keys\n\n")
+		writeMarkdownRow(&buf, "Name", "Referencing table", "Referencing columns", "Columns")
+		writeMarkdownRow(&buf, "---", "---", "---", "---")
+		for _, rk := range tbl.ReferencedKeys() {
+			writeMarkdownRow(&buf,
+				escapeMarkdown(rk.Name()),
+				markdownTableLink(rk.Schema(), rk.TableName(), set),
+				escapeMarkdown(ColumnNames(fromColumns(rk))),
+				escapeMarkdown(ColumnNames(toColumns(rk))))
+		}
+	}
+	return buf.Bytes()
+}
+
+func markdownTableLink(schema string, name string, set map[string]bool) string {
+	qname := schema + "." + name
+	if set != nil && !set[qname] {
+		return escapeMarkdown(qname)
+	}
+	return markdownLink(qname, PageBaseName(schema, name)+".md")
+}
+
+func markdownLink(text string, target string) string {
+	return "[" + escapeMarkdown(text) + "](" + strings.Replace(target, " ", "%20", -1) + ")"
+}
+
+func markdownCode(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "`" + strings.Replace(escapeMarkdown(s), "`", "'", -1) + "`"
+}
+
+func writeMarkdownRow(buf *bytes.Buffer, cells ...string) {
+	buf.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+}
+
+var markdownReplacer = strings.NewReplacer(
+	`|`, `\|`,
+	"\r\n", "<br>",
+	"\n", "<br>",
+)
+
+func escapeMarkdown(s string) string {
+	return markdownReplacer.Replace(s)
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "YES"
+	}
+	return "NO"
+}
@@ -0,0 +1,81 @@
+package doc
+
+import (
+	"testing"
+)
+
+func TestMarkdown(t *testing.T) {
+	pages := Markdown(loadTestTables(t))
+	if len(pages) != 5 {
+		t.Fatalf("Page count is invalid. expected: %v, actual: %v", 5, len(pages))
+	}
+	for i, name := range []string{"index.md", "foo.users.md", "foo.posts.md", "foo.items.md", "bar.active_users.md"} {
+		if actual := pages[i].Name(); actual != name {
+			t.Errorf("Name() returns invalid value. expected: %v, actual: %v", name, actual)
+		}
+	}
+}
+
+func TestMarkdownIndex(t *testing.T) {
+	expected := `# Tables
+
+| Schema | Name | Kind | Comment |
+| --- | --- | --- | --- |
+| foo | [users](foo.users.md) | TABLE | user accounts |
+| foo | [posts](foo.posts.md) | TABLE |  |
+| foo | [items](foo.items.md) | TABLE | user's items |
+| bar | [active_users](bar.active_users.md) | VIEW |  |
+`
+	if actual := string(MarkdownIndex(loadTestTables(t))); actual != expected {
+		t.Errorf("MarkdownIndex() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestMarkdownTable(t *testing.T) {
+	expected := "# foo.items\n\n" +
+		"user's items\n\n" +
+		"[Tables](index.md)\n\n" +
+		"## Columns\n\n" +
+		"| # | Name | Type | Nullable | Default | PK | Comment |\n" +
+		"| ---: | --- | --- | --- | --- | ---: | --- |\n" +
+		"| 1 | id | int4 | NO | `nextval('items_id_seq'::regclass)` | 1 |  |\n" +
+		"| 2 | user_id | int4 | NO |  |  |  |\n" +
+		"| 3 | price | numeric(10, 2) | YES |  |  | price \\| tax included |\n" +
+		"\n## Indices\n\n" +
+		"| Name | Columns | Unique |\n" +
+		"| --- | --- | --- |\n" +
+		"| items_user_id_idx | user_id | NO |\n" +
+		"\n## Constraints\n\n" +
+		"| Name | Kind | Content |\n" +
+		"| --- | --- | --- |\n" +
+		"| items_price_check | CHECK | `(price > 0)` |\n" +
+		"\n## Foreign keys\n\n" +
+		"| Name | Columns | Referenced table | Referenced columns |\n" +
+		"| --- | --- | --- | --- |\n" +
+		"| items_user_id_fkey | user_id | [foo.users](foo.users.md) | id |\n"
+	tbls := loadTestTables(t)
+	if actual := string(MarkdownTable(tbls[2])); actual != expected {
+		t.Errorf("MarkdownTable() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestMarkdownTableReferencedKeys(t *testing.T) {
+	expected := "\n## Referenced keys\n\n" +
+		"| Name | Referencing table | Referencing columns | Columns |\n" +
+		"| --- | --- | --- | --- |\n" +
+		"| posts_user_id_fkey | [foo.posts](foo.posts.md) | user_id | id |\n" +
+		"| items_user_id_fkey | [foo.items](foo.items.md) | user_id | id |\n"
+	actual := string(MarkdownTable(loadTestTables(t)[0]))
+	if len(actual) < len(expected) || actual[len(actual)-len(expected):] != expected {
+		t.Errorf("MarkdownTable() returns invalid value. expected suffix: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestMarkdownDoesNotLinkMissingTable(t *testing.T) {
+	tbls := loadTestTables(t)
+	pages := Markdown(tbls[1:])
+	expected := "| posts_user_id_fkey | user_id | foo.users | id |\n"
+	if actual := string(pages[1].Content()); actual[len(actual)-len(expected):] != expected {
+		t.Errorf("Markdown() should not link table that is not given. actual: %v", actual)
+	}
+}
@@ -0,0 +1,279 @@
+package doc
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+// xlsxMaxSheetName is max length of sheet name that Excel accepts.
+const xlsxMaxSheetName = 31
+
+// xlsxCell is cell of worksheet.
+// If number is true, value is written as number.
+type xlsxCell struct {
+	value  string
+	number bool
+	bold   bool
+}
+
+// xlsxFile is file in XLSX archive.
+type xlsxFile struct {
+	name    string
+	content string
+}
+
+func xlsxText(s string) xlsxCell {
+	return xlsxCell{value: s}
+}
+
+func xlsxHeader(s string) xlsxCell {
+	return xlsxCell{value: s, bold: true}
+}
+
+func xlsxNumber(n int64) xlsxCell {
+	return xlsxCell{value: strconv.FormatInt(n, 10), number: true}
+}
+
+func xlsxNullNumber(n int64, valid bool) xlsxCell {
+	if !valid {
+		return xlsxText("")
+	}
+	return xlsxNumber(n)
+}
+
+// WriteXLSX writes table definition document in XLSX format.
+// Workbook has a sheet per table, and each sheet has logical name (comment), physical name,
+// columns with type, length, precision, scale, NOT NULL, default and PK position, and indices.
+// It is written without any office software, so that it can run on any environment.
+// Workbook must have a sheet at least, so raise ErrTablesEmpty when no table is given.
+func WriteXLSX(w io.Writer, tbls []*dbmodel.Table) error {
+	if len(tbls) == 0 {
+		return ErrTablesEmpty
+	}
+	zw := zip.NewWriter(w)
+	names := xlsxSheetNames(tbls)
+	files := []xlsxFile{
+		{"[Content_Types].xml", xlsxContentTypes(len(tbls))},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook(names)},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels(len(tbls))},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for i, tbl := range tbls {
+		files = append(files, xlsxFile{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), xlsxSheet(xlsxTableRows(tbl))})
+	}
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return err
+		}
+		if _, err = io.WriteString(fw, f.content); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// xlsxTableRows returns rows of sheet for table.
+func xlsxTableRows(tbl *dbmodel.Table) [][]xlsxCell {
+	rows := [][]xlsxCell{
+		{xlsxHeader("Logical name"), xlsxText(tbl.Comment())},
+		{xlsxHeader("Physical name"), xlsxText(QualifiedName(tbl))},
+		nil,
+		{
+			xlsxHeader("No"),
+			xlsxHeader("Logical name"),
+			xlsxHeader("Physical name"),
+			xlsxHeader("Type"),
+			xlsxHeader("Length"),
+			xlsxHeader("Precision"),
+			xlsxHeader("Scale"),
+			xlsxHeader("Not null"),
+			xlsxHeader("Default"),
+			xlsxHeader("PK"),
+		},
+	}
+	for i, col := range tbl.Columns() {
+		notNull := ""
+		if !col.IsNullable() {
+			notNull = "YES"
+		}
+		pk := xlsxText("")
+		if col.PrimaryKeyPosition() > 0 {
+			pk = xlsxNumber(col.PrimaryKeyPosition())
+		}
+		size := col.Size()
+		rows = append(rows, []xlsxCell{
+			xlsxNumber(int64(i + 1)),
+			xlsxText(col.Comment()),
+			xlsxText(col.Name()),
+			xlsxText(col.DataType()),
+			xlsxNullNumber(size.Length().Int64, size.Length().Valid),
+			xlsxNullNumber(size.Precision().Int64, size.Precision().Valid),
+			xlsxNullNumber(size.Scale().Int64, size.Scale().Valid),
+			xlsxText(notNull),
+			xlsxText(col.DefaultValue()),
+			pk,
+		})
+	}
+	if len(tbl.Indices()) > 0 {
+		rows = append(rows, nil, []xlsxCell{
+			xlsxHeader("No"),
+			xlsxHeader("Index name"),
+			xlsxHeader("Columns"),
+			xlsxHeader("Unique"),
+		})
+		for i, idx := range tbl.Indices() {
+			unique := ""
+			if idx.IsUnique() {
+				unique = "YES"
+			}
+			rows = append(rows, []xlsxCell{
+				xlsxNumber(int64(i + 1)),
+				xlsxText(idx.Name()),
+				xlsxText(ColumnNames(idx.Columns())),
+				xlsxText(unique),
+			})
+		}
+	}
+	return rows
+}
+
+// xlsxSheetNames returns unique sheet names that Excel accepts.
+// Table name is used, and schema is prefixed when tables in some schemas are given.
+func xlsxSheetNames(tbls []*dbmodel.Table) []string {
+	multiSchema := false
+	for _, tbl := range tbls {
+		if tbl.Schema() != tbls[0].Schema() {
+			multiSchema = true
+			break
+		}
+	}
+	replacer := strings.NewReplacer("[", "_", "]", "_", ":", "_", "*", "_", "?", "_", "/", "_", `\`, "_")
+	used := make(map[string]bool, len(tbls))
+	names := make([]string, 0, len(tbls))
+	for _, tbl := range tbls {
+		base := tbl.Name()
+		if multiSchema {
+			base = QualifiedName(tbl)
+		}
+		base = replacer.Replace(base)
+		name := xlsxTruncate(base, xlsxMaxSheetName)
+		for i := 2; used[strings.ToLower(name)]; i++ {
+			suffix := "~" + strconv.Itoa(i)
+			name = xlsxTruncate(base, xlsxMaxSheetName-len(suffix)) + suffix
+		}
+		used[strings.ToLower(name)] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+func xlsxTruncate(s string, n int) string {
+	rs := []rune(s)
+	if len(rs) > n {
+		return string(rs[:n])
+	}
+	return s
+}
+
+// xlsxColumnName returns column name of cell reference. (eg. 0 -> "A", 26 -> "AA")
+func xlsxColumnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+func xlsxEscape(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+func xlsxSheet(rows [][]xlsxCell) string {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for r, row := range rows {
+		if len(row) == 0 {
+			continue
+		}
+		fmt.Fprintf(&buf, `<row r="%d">`, r+1)
+		for c, cell := range row {
+			ref := xlsxColumnName(c) + strconv.Itoa(r+1)
+			style := ""
+			if cell.bold {
+				style = ` s="1"`
+			}
+			if cell.number {
+				fmt.Fprintf(&buf, `<c r="%s"%s><v>%s</v></c>`, ref, style, cell.value)
+			} else if cell.value != "" || cell.bold {
+				fmt.Fprintf(&buf, `<c r="%s"%s t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, style, xlsxEscape(cell.value))
+			}
+		}
+		buf.WriteString(`</row>`)
+	}
+	buf.WriteString(`</sheetData></worksheet>`)
+	return buf.String()
+}
+
+func xlsxContentTypes(sheets int) string {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	buf.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	buf.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	buf.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	buf.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&buf, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+	}
+	buf.WriteString(`</Types>`)
+	return buf.String()
+}
+
+func xlsxWorkbook(names []string) string {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, name := range names {
+		fmt.Fprintf(&buf, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xlsxEscape(name), i+1, i+1)
+	}
+	buf.WriteString(`</sheets></workbook>`)
+	return buf.String()
+}
+
+func xlsxWorkbookRels(sheets int) string {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&buf, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i, i)
+	}
+	fmt.Fprintf(&buf, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, sheets+1)
+	buf.WriteString(`</Relationships>`)
+	return buf.String()
+}
+
+const xlsxRootRels = xml.Header +
+	`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const xlsxStyles = xml.Header +
+	`<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+	`</styleSheet>`
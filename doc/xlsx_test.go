@@ -0,0 +1,111 @@
+package doc
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+func readXLSX(t *testing.T, b []byte) map[string]string {
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string]string, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[f.Name] = string(content)
+	}
+	return files
+}
+
+func TestWriteXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, loadTestTables(t)); err != nil {
+		t.Fatal(err)
+	}
+	files := readXLSX(t, buf.Bytes())
+	for _, name := range []string{
+		"[Content_Types].xml",
+		"_rels/.rels",
+		"xl/workbook.xml",
+		"xl/_rels/workbook.xml.rels",
+		"xl/styles.xml",
+		"xl/worksheets/sheet1.xml",
+		"xl/worksheets/sheet4.xml",
+	} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("%v should be written.", name)
+		}
+	}
+	if expected, actual := `<sheet name="foo.users" sheetId="1" r:id="rId1"/><sheet name="foo.posts" sheetId="2" r:id="rId2"/><sheet name="foo.items" sheetId="3" r:id="rId3"/><sheet name="bar.active_users" sheetId="4" r:id="rId4"/>`, files["xl/workbook.xml"]; !strings.Contains(actual, expected) {
+		t.Errorf("Workbook should contain %v. actual: %v", expected, actual)
+	}
+	sheet := files["xl/worksheets/sheet3.xml"]
+	for _, expected := range []string{
+		`<c r="B1" t="inlineStr"><is><t xml:space="preserve">user&#39;s items</t></is></c>`,
+		`<c r="B2" t="inlineStr"><is><t xml:space="preserve">foo.items</t></is></c>`,
+		`<c r="J4" s="1" t="inlineStr"><is><t xml:space="preserve">PK</t></is></c>`,
+		`<row r="7"><c r="A7"><v>3</v></c><c r="B7" t="inlineStr"><is><t xml:space="preserve">price | tax included</t></is></c><c r="C7" t="inlineStr"><is><t xml:space="preserve">price</t></is></c><c r="D7" t="inlineStr"><is><t xml:space="preserve">numeric</t></is></c><c r="F7"><v>10</v></c><c r="G7"><v>2</v></c></row>`,
+		`<c r="H5" t="inlineStr"><is><t xml:space="preserve">YES</t></is></c>`,
+		`<c r="J5"><v>1</v></c>`,
+		`<c r="B10" t="inlineStr"><is><t xml:space="preserve">items_user_id_idx</t></is></c>`,
+	} {
+		if !strings.Contains(sheet, expected) {
+			t.Errorf("Sheet should contain %v. actual: %v", expected, sheet)
+		}
+	}
+}
+
+func TestWriteXLSXWithoutTables(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, nil); err != ErrTablesEmpty {
+		t.Errorf("WriteXLSX() should raise ErrTablesEmpty. actual: %v", err)
+	}
+}
+
+func TestXLSXSheetNames(t *testing.T) {
+	long := strings.Repeat("a", 40)
+	tbls := []*dbmodel.Table{}
+	for _, n := range [][]string{{"foo", "users"}, {"bar", "users"}, {"foo", long}, {"foo", long + "b"}, {"foo", "a/b"}} {
+		tbl := dbmodel.NewTable(n[0], n[1], "")
+		tbls = append(tbls, &tbl)
+	}
+	expected := []string{"foo.users", "bar.users", "foo." + long[:27], "foo." + long[:25] + "~2", "foo.a_b"}
+	actual := xlsxSheetNames(tbls)
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("xlsxSheetNames() returns invalid value. expected: %v, actual: %v", expected[i], actual[i])
+		}
+	}
+}
+
+func TestXLSXColumnName(t *testing.T) {
+	tests := []struct {
+		index    int
+		expected string
+	}{
+		{0, "A"},
+		{25, "Z"},
+		{26, "AA"},
+		{701, "ZZ"},
+		{702, "AAA"},
+	}
+	for _, test := range tests {
+		if actual := xlsxColumnName(test.index); actual != test.expected {
+			t.Errorf("xlsxColumnName() returns invalid value. expected: %v, actual: %v", test.expected, actual)
+		}
+	}
+}
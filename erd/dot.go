@@ -0,0 +1,92 @@
+package erd
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/pinzolo/dbmodel"
+	"github.com/pinzolo/dbmodel/doc"
+)
+
+// WriteDOT writes ER diagram of tables in Graphviz DOT language.
+// Table is drawn as node that has HTML-like label listing columns with PK and FK markers,
+// and foreign key is drawn as edge labelled with column pairs.
+// Foreign keys that reference tables not given are not drawn.
+// If opt.ClusterBySchema is true, tables are grouped into cluster per schema.
+func WriteDOT(w io.Writer, tbls []*dbmodel.Table, opt Option) error {
+	var buf bytes.Buffer
+	buf.WriteString("digraph erd {\n")
+	buf.WriteString("    graph [rankdir=LR];\n")
+	buf.WriteString("    node [shape=plaintext];\n")
+	buf.WriteString("    edge [arrowhead=normal];\n")
+
+	if opt.ClusterBySchema {
+		for i, schema := range schemas(tbls) {
+			fmt.Fprintf(&buf, "    subgraph cluster_%d {\n", i)
+			fmt.Fprintf(&buf, "        label=%v;\n", dotID(schema))
+			for _, tbl := range tbls {
+				if tbl.Schema() == schema {
+					writeDOTNode(&buf, "        ", tbl)
+				}
+			}
+			buf.WriteString("    }\n")
+		}
+	} else {
+		for _, tbl := range tbls {
+			writeDOTNode(&buf, "    ", tbl)
+		}
+	}
+
+	relationships(tbls, func(tbl *dbmodel.Table, fk *dbmodel.ForeignKey, ref *dbmodel.Table) {
+		crs := fk.ColumnReferences()
+		pairs := make([]string, 0, len(crs))
+		for _, cr := range crs {
+			pairs = append(pairs, cr.From().Name()+" -> "+cr.To().Name())
+		}
+		fmt.Fprintf(&buf, "    %v:%v -> %v:%v [label=%v];\n",
+			dotID(qualifiedName(tbl)), dotID(crs[0].From().Name()),
+			dotID(qualifiedName(ref)), dotID(crs[0].To().Name()),
+			dotID(strings.Join(pairs, "\n")))
+	})
+	buf.WriteString("}\n")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func writeDOTNode(buf *bytes.Buffer, indent string, tbl *dbmodel.Table) {
+	fmt.Fprintf(buf, "%v%v [label=<\n", indent, dotID(qualifiedName(tbl)))
+	fmt.Fprintf(buf, "%v    <table border=\"0\" cellborder=\"1\" cellspacing=\"0\">\n", indent)
+	fmt.Fprintf(buf, "%v    <tr><td colspan=\"3\" bgcolor=\"lightgray\"><b>%v</b></td></tr>\n", indent, html.EscapeString(qualifiedName(tbl)))
+	for _, col := range tbl.Columns() {
+		fmt.Fprintf(buf, "%v    <tr><td align=\"left\">%v</td><td align=\"left\" port=\"%v\">%v</td><td align=\"left\">%v</td></tr>\n",
+			indent,
+			columnMarker(tbl, col),
+			html.EscapeString(col.Name()),
+			html.EscapeString(col.Name()),
+			html.EscapeString(doc.FullType(col)))
+	}
+	fmt.Fprintf(buf, "%v    </table>\n", indent)
+	fmt.Fprintf(buf, "%v>];\n", indent)
+}
+
+// columnMarker returns "PK", "FK" or "PK,FK" for column.
+func columnMarker(tbl *dbmodel.Table, col *dbmodel.Column) string {
+	markers := make([]string, 0, 2)
+	if col.PrimaryKeyPosition() > 0 {
+		markers = append(markers, "PK")
+	}
+	if isForeignKeyColumn(tbl, col) {
+		markers = append(markers, "FK")
+	}
+	return strings.Join(markers, ",")
+}
+
+var dotReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// dotID returns quoted ID of DOT language.
+func dotID(s string) string {
+	return `"` + dotReplacer.Replace(s) + `"`
+}
@@ -0,0 +1,85 @@
+package erd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+func TestWriteDOT(t *testing.T) {
+	tbls := loadTestTables(t)
+	var buf bytes.Buffer
+	if err := WriteDOT(&buf, tbls[:2], Option{}); err != nil {
+		t.Fatal(err)
+	}
+	expected := `digraph erd {
+    graph [rankdir=LR];
+    node [shape=plaintext];
+    edge [arrowhead=normal];
+    "foo.users" [label=<
+        <table border="0" cellborder="1" cellspacing="0">
+        <tr><td colspan="3" bgcolor="lightgray"><b>foo.users</b></td></tr>
+        <tr><td align="left">PK</td><td align="left" port="id">id</td><td align="left">int4(32, 0)</td></tr>
+        <tr><td align="left"></td><td align="left" port="email">email</td><td align="left">varchar(255)</td></tr>
+        <tr><td align="left"></td><td align="left" port="name">name</td><td align="left">text</td></tr>
+        </table>
+    >];
+    "foo.posts" [label=<
+        <table border="0" cellborder="1" cellspacing="0">
+        <tr><td colspan="3" bgcolor="lightgray"><b>foo.posts</b></td></tr>
+        <tr><td align="left">PK</td><td align="left" port="id">id</td><td align="left">int4(32, 0)</td></tr>
+        <tr><td align="left">FK</td><td align="left" port="user_id">user_id</td><td align="left">int4(32, 0)</td></tr>
+        <tr><td align="left"></td><td align="left" port="body">body</td><td align="left">text</td></tr>
+        </table>
+    >];
+    "foo.posts":"user_id" -> "foo.users":"id" [label="user_id -> id"];
+}
+`
+	if actual := buf.String(); actual != expected {
+		t.Errorf("WriteDOT() writes invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestWriteDOTClusterBySchema(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteDOT(&buf, loadTestTables(t), Option{ClusterBySchema: true}); err != nil {
+		t.Fatal(err)
+	}
+	actual := buf.String()
+	for _, expected := range []string{
+		"    subgraph cluster_0 {\n        label=\"foo\";\n        \"foo.users\" [label=<\n",
+		"    subgraph cluster_1 {\n        label=\"bar\";\n        \"bar.active_users\" [label=<\n",
+		"            <tr><td align=\"left\">PK,FK</td><td align=\"left\" port=\"user_id\">user_id</td><td align=\"left\">int4</td></tr>\n",
+		"    \"bar.comments\":\"post_id\" -> \"foo.posts\":\"id\" [label=\"post_id -> id\"];\n",
+	} {
+		if !strings.Contains(actual, expected) {
+			t.Errorf("WriteDOT() should write %v. actual: %v", expected, actual)
+		}
+	}
+}
+
+func TestWriteDOTEscapesPort(t *testing.T) {
+	tbl := dbmodel.NewTable("foo", "bar", "")
+	col := dbmodel.NewColumn("foo", "bar", `a"b&c`, "", "int4", dbmodel.Size{}, true, "", 0)
+	tbl.AddColumn(&col)
+	var buf bytes.Buffer
+	if err := WriteDOT(&buf, []*dbmodel.Table{&tbl}, Option{}); err != nil {
+		t.Fatal(err)
+	}
+	expected := `<td align="left" port="a&#34;b&amp;c">a&#34;b&amp;c</td>`
+	if actual := buf.String(); !strings.Contains(actual, expected) {
+		t.Errorf("WriteDOT() should write %v. actual: %v", expected, actual)
+	}
+}
+
+func TestWriteDOTSkipsTablesNotGiven(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteDOT(&buf, loadTestTables(t)[1:2], Option{}); err != nil {
+		t.Fatal(err)
+	}
+	if actual := buf.String(); strings.Contains(actual, "->") {
+		t.Errorf("WriteDOT() should not write edge to table that is not given. actual: %v", actual)
+	}
+}
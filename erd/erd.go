@@ -0,0 +1,187 @@
+// Package erd generates ER diagrams of tables loaded by dbmodel.
+package erd
+
+import (
+	"github.com/pinzolo/dbmodel"
+)
+
+// Option is option of diagram.
+type Option struct {
+	// ClusterBySchema groups tables by schema.
+	ClusterBySchema bool
+}
+
+// Neighborhood returns tables that are reachable from center table within depth
+// through foreign keys and referenced keys.
+// Returned tables keep order of given tables, and center table is contained even if depth is 0.
+// If center table is not in given tables, it is placed first.
+// Tables that are not in given tables are not traversed.
+func Neighborhood(tbls []*dbmodel.Table, center *dbmodel.Table, depth int) []*dbmodel.Table {
+	tblMap := tableMap(tbls)
+	found := map[string]bool{qualifiedName(center): true}
+	current := []*dbmodel.Table{center}
+	for i := 0; i < depth && len(current) > 0; i++ {
+		next := make([]*dbmodel.Table, 0, len(current))
+		for _, tbl := range current {
+			for _, name := range neighborNames(tbl) {
+				if found[name] {
+					continue
+				}
+				if t, ok := tblMap[name]; ok {
+					found[name] = true
+					next = append(next, t)
+				}
+			}
+		}
+		current = next
+	}
+
+	result := make([]*dbmodel.Table, 0, len(found))
+	if _, ok := tblMap[qualifiedName(center)]; !ok {
+		result = append(result, center)
+	}
+	for _, tbl := range tbls {
+		if found[qualifiedName(tbl)] {
+			result = append(result, tbl)
+		}
+	}
+	return result
+}
+
+func neighborNames(tbl *dbmodel.Table) []string {
+	names := make([]string, 0, len(tbl.ForeignKeys())+len(tbl.ReferencedKeys()))
+	for _, fk := range tbl.ForeignKeys() {
+		if schema, name, ok := referencedTable(fk); ok {
+			names = append(names, schema+"."+name)
+		}
+	}
+	for _, rk := range tbl.ReferencedKeys() {
+		names = append(names, rk.Schema()+"."+rk.TableName())
+	}
+	return names
+}
+
+// referencedTable returns schema and name of table that foreign key references.
+func referencedTable(fk *dbmodel.ForeignKey) (string, string, bool) {
+	if crs := fk.ColumnReferences(); len(crs) > 0 {
+		return crs[0].To().Schema(), crs[0].To().TableName(), true
+	}
+	return "", "", false
+}
+
+func qualifiedName(tbl *dbmodel.Table) string {
+	return tbl.Schema() + "." + tbl.Name()
+}
+
+func tableMap(tbls []*dbmodel.Table) map[string]*dbmodel.Table {
+	m := make(map[string]*dbmodel.Table, len(tbls))
+	for _, tbl := range tbls {
+		m[qualifiedName(tbl)] = tbl
+	}
+	return m
+}
+
+// isForeignKeyColumn returns true if column is used in any foreign key of table.
+func isForeignKeyColumn(tbl *dbmodel.Table, col *dbmodel.Column) bool {
+	for _, fk := range tbl.ForeignKeys() {
+		for _, cr := range fk.ColumnReferences() {
+			if cr.From().Name() == col.Name() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// schemas returns schemas of tables in order of appearance.
+func schemas(tbls []*dbmodel.Table) []string {
+	found := make(map[string]bool)
+	names := make([]string, 0, 2)
+	for _, tbl := range tbls {
+		if !found[tbl.Schema()] {
+			found[tbl.Schema()] = true
+			names = append(names, tbl.Schema())
+		}
+	}
+	return names
+}
+
+// cardinality is cardinality of an end of relationship.
+type cardinality int
+
+const (
+	zeroOrOne cardinality = iota
+	exactlyOne
+	zeroOrMany
+)
+
+// foreignKeyCardinality returns cardinalities of referenced side and referencing side of foreign key.
+// Referenced side is zero or one if any column of foreign key is nullable, otherwise exactly one.
+// Referencing side is zero or one if columns of foreign key are unique, otherwise zero or many.
+func foreignKeyCardinality(tbl *dbmodel.Table, fk *dbmodel.ForeignKey) (cardinality, cardinality) {
+	parent := exactlyOne
+	cols := make([]*dbmodel.Column, 0, len(fk.ColumnReferences()))
+	for _, cr := range fk.ColumnReferences() {
+		if cr.From().IsNullable() {
+			parent = zeroOrOne
+		}
+		cols = append(cols, cr.From())
+	}
+	child := zeroOrMany
+	if isUnique(tbl, cols) {
+		child = zeroOrOne
+	}
+	return parent, child
+}
+
+// isUnique returns true if columns are primary key or columns of unique index.
+func isUnique(tbl *dbmodel.Table, cols []*dbmodel.Column) bool {
+	pks := make([]*dbmodel.Column, 0, len(cols))
+	for _, col := range tbl.Columns() {
+		if col.PrimaryKeyPosition() > 0 {
+			pks = append(pks, col)
+		}
+	}
+	if sameColumns(pks, cols) {
+		return true
+	}
+	for _, idx := range tbl.Indices() {
+		if idx.IsUnique() && sameColumns(idx.Columns(), cols) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameColumns returns true if both have same column names regardless of order.
+func sameColumns(a []*dbmodel.Column, b []*dbmodel.Column) bool {
+	if len(a) == 0 || len(a) != len(b) {
+		return false
+	}
+	names := make(map[string]bool, len(a))
+	for _, col := range a {
+		names[col.Name()] = true
+	}
+	for _, col := range b {
+		if !names[col.Name()] {
+			return false
+		}
+	}
+	return true
+}
+
+// relationships calls fn with foreign keys whose referenced tables are given.
+func relationships(tbls []*dbmodel.Table, fn func(tbl *dbmodel.Table, fk *dbmodel.ForeignKey, ref *dbmodel.Table)) {
+	tblMap := tableMap(tbls)
+	for _, tbl := range tbls {
+		for _, fk := range tbl.ForeignKeys() {
+			schema, name, ok := referencedTable(fk)
+			if !ok {
+				continue
+			}
+			if ref, ok := tblMap[schema+"."+name]; ok {
+				fn(tbl, fk, ref)
+			}
+		}
+	}
+}
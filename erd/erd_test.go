@@ -0,0 +1,104 @@
+package erd
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+// loadTestTables loads tables from testdata/schema.yml.
+func loadTestTables(t *testing.T) []*dbmodel.Table {
+	f, err := os.Open("testdata/schema.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	db, err := dbmodel.LoadYAML(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db.Tables()
+}
+
+func tableNames(tbls []*dbmodel.Table) string {
+	names := make([]string, 0, len(tbls))
+	for _, tbl := range tbls {
+		names = append(names, qualifiedName(tbl))
+	}
+	return strings.Join(names, ", ")
+}
+
+func TestNeighborhood(t *testing.T) {
+	tbls := loadTestTables(t)
+	tests := []struct {
+		center   int
+		depth    int
+		expected string
+	}{
+		{0, 0, "foo.users"},
+		{0, 1, "foo.users, foo.posts, foo.profiles"},
+		{0, 2, "foo.users, foo.posts, foo.profiles, bar.comments"},
+		{4, 1, "foo.posts, bar.comments"},
+		{4, 2, "foo.users, foo.posts, bar.comments"},
+	}
+	for _, test := range tests {
+		if actual := tableNames(Neighborhood(tbls, tbls[test.center], test.depth)); actual != test.expected {
+			t.Errorf("Neighborhood() returns invalid value. expected: %v, actual: %v", test.expected, actual)
+		}
+	}
+}
+
+func TestNeighborhoodIgnoresTablesNotGiven(t *testing.T) {
+	tbls := loadTestTables(t)
+	if expected, actual := "foo.posts, bar.comments", tableNames(Neighborhood([]*dbmodel.Table{tbls[1], tbls[4]}, tbls[4], 2)); actual != expected {
+		t.Errorf("Neighborhood() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestNeighborhoodContainsCenterNotGiven(t *testing.T) {
+	tbls := loadTestTables(t)
+	if expected, actual := "foo.users, foo.posts", tableNames(Neighborhood(tbls[1:2], tbls[0], 1)); actual != expected {
+		t.Errorf("Neighborhood() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := "foo.users", tableNames(Neighborhood(nil, tbls[0], 0)); actual != expected {
+		t.Errorf("Neighborhood() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestForeignKeyCardinality(t *testing.T) {
+	tbls := loadTestTables(t)
+	tests := []struct {
+		table  int
+		parent cardinality
+		child  cardinality
+	}{
+		{1, zeroOrOne, zeroOrMany},
+		{2, exactlyOne, zeroOrOne},
+		{4, zeroOrOne, zeroOrMany},
+	}
+	for _, test := range tests {
+		tbl := tbls[test.table]
+		parent, child := foreignKeyCardinality(tbl, tbl.ForeignKeys()[0])
+		if parent != test.parent || child != test.child {
+			t.Errorf("foreignKeyCardinality() returns invalid value for %v. expected: %v, %v, actual: %v, %v", tbl.Name(), test.parent, test.child, parent, child)
+		}
+	}
+}
+
+func TestForeignKeyCardinalityWithUniqueIndex(t *testing.T) {
+	tbls := loadTestTables(t)
+	usr, pst := tbls[0], tbls[1]
+	col, _ := pst.FindColumn("user_id")
+	idx := dbmodel.NewIndex("foo", "posts", "posts_user_id_key", true)
+	idx.AddColumn(col)
+	pst.AddIndex(&idx)
+	if _, child := foreignKeyCardinality(pst, pst.ForeignKeys()[0]); child != zeroOrOne {
+		t.Errorf("foreignKeyCardinality() returns invalid value. expected: %v, actual: %v", zeroOrOne, child)
+	}
+	email, _ := usr.FindColumn("email")
+	if !isUnique(usr, []*dbmodel.Column{email}) {
+		t.Error("isUnique() should return true for column of unique index.")
+	}
+}
@@ -0,0 +1,104 @@
+package erd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+var (
+	mermaidInvalidTypeChars = regexp.MustCompile(`[^A-Za-z0-9_\-\[\]()]`)
+	mermaidInvalidNameChars = regexp.MustCompile(`[^A-Za-z0-9_\-]`)
+)
+
+// WriteMermaid writes ER diagram of tables in Mermaid erDiagram syntax.
+// Attribute type is data type of column without size, because Mermaid does not accept spaces and commas in type.
+// Column name that Mermaid does not accept as attribute name is sanitized, and original name is written in comment.
+// Cardinality of relationship is inferred from nullability and uniqueness of foreign key columns.
+// Foreign keys that reference tables not given are not drawn.
+func WriteMermaid(w io.Writer, tbls []*dbmodel.Table) error {
+	var buf bytes.Buffer
+	buf.WriteString("erDiagram\n")
+	for _, tbl := range tbls {
+		fmt.Fprintf(&buf, "    %v {\n", mermaidQuote(qualifiedName(tbl)))
+		for _, col := range tbl.Columns() {
+			name := mermaidName(col.Name())
+			fmt.Fprintf(&buf, "        %v %v", mermaidInvalidTypeChars.ReplaceAllString(col.DataType(), "_"), name)
+			if m := columnMarker(tbl, col); m != "" {
+				buf.WriteString(" " + m)
+			}
+			comment := col.Comment()
+			if name != col.Name() {
+				if comment == "" {
+					comment = col.Name()
+				} else {
+					comment = col.Name() + ": " + comment
+				}
+			}
+			if comment != "" {
+				buf.WriteString(" " + mermaidQuote(comment))
+			}
+			buf.WriteString("\n")
+		}
+		buf.WriteString("    }\n")
+	}
+	relationships(tbls, func(tbl *dbmodel.Table, fk *dbmodel.ForeignKey, ref *dbmodel.Table) {
+		parent, child := foreignKeyCardinality(tbl, fk)
+		fmt.Fprintf(&buf, "    %v %v--%v %v : %v\n",
+			mermaidQuote(qualifiedName(ref)),
+			crowFootLeft(parent),
+			crowFootRight(child),
+			mermaidQuote(qualifiedName(tbl)),
+			mermaidQuote(fk.Name()))
+	})
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// mermaidName returns attribute name that matches [A-Za-z_][A-Za-z0-9_-]*.
+// Invalid characters are replaced with "_", and name that does not start with letter is prefixed with "_".
+func mermaidName(s string) string {
+	name := mermaidInvalidNameChars.ReplaceAllString(s, "_")
+	if name == "" || !isASCIILetter(name[0]) && name[0] != '_' {
+		return "_" + name
+	}
+	return name
+}
+
+func isASCIILetter(b byte) bool {
+	return 'A' <= b && b <= 'Z' || 'a' <= b && b <= 'z'
+}
+
+// mermaidQuote quotes string with double quotes.
+// Mermaid cannot escape double quote, so that it is replaced with single quote.
+func mermaidQuote(s string) string {
+	return `"` + strings.NewReplacer(`"`, `'`, "\r\n", " ", "\n", " ").Replace(s) + `"`
+}
+
+// crowFootLeft returns crow's foot notation of left end of relationship, that is used by Mermaid and PlantUML.
+func crowFootLeft(c cardinality) string {
+	switch c {
+	case zeroOrOne:
+		return "|o"
+	case exactlyOne:
+		return "||"
+	default:
+		return "}o"
+	}
+}
+
+// crowFootRight returns crow's foot notation of right end of relationship, that is used by Mermaid and PlantUML.
+func crowFootRight(c cardinality) string {
+	switch c {
+	case zeroOrOne:
+		return "o|"
+	case exactlyOne:
+		return "||"
+	default:
+		return "o{"
+	}
+}
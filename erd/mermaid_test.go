@@ -0,0 +1,90 @@
+package erd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+func TestWriteMermaid(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteMermaid(&buf, loadTestTables(t)); err != nil {
+		t.Fatal(err)
+	}
+	expected := `erDiagram
+    "foo.users" {
+        int4 id PK
+        varchar email "login id"
+        text name
+    }
+    "foo.posts" {
+        int4 id PK
+        int4 user_id FK
+        text body
+    }
+    "foo.profiles" {
+        int4 user_id PK,FK
+    }
+    "bar.active_users" {
+        int4 id
+    }
+    "bar.comments" {
+        int4 id PK
+        int4 post_id FK
+    }
+    "foo.users" |o--o{ "foo.posts" : "posts_user_id_fkey"
+    "foo.users" ||--o| "foo.profiles" : "profiles_user_id_fkey"
+    "foo.posts" |o--o{ "bar.comments" : "comments_post_id_fkey"
+`
+	if actual := buf.String(); actual != expected {
+		t.Errorf("WriteMermaid() writes invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestWriteMermaidSanitizesName(t *testing.T) {
+	tbl := dbmodel.NewTable("foo", "bar", "")
+	plain := dbmodel.NewColumn("foo", "bar", "1st name", "", "text", dbmodel.Size{}, true, "", 0)
+	commented := dbmodel.NewColumn("foo", "bar", "e-mail.addr", "login id", "text", dbmodel.Size{}, true, "", 0)
+	tbl.AddColumn(&plain)
+	tbl.AddColumn(&commented)
+	var buf bytes.Buffer
+	if err := WriteMermaid(&buf, []*dbmodel.Table{&tbl}); err != nil {
+		t.Fatal(err)
+	}
+	expected := `erDiagram
+    "foo.bar" {
+        text _1st_name "1st name"
+        text e-mail_addr "e-mail.addr: login id"
+    }
+`
+	if actual := buf.String(); actual != expected {
+		t.Errorf("WriteMermaid() writes invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestMermaidName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"user_id", "user_id"},
+		{"_id", "_id"},
+		{"created-at", "created-at"},
+		{"user id", "user_id"},
+		{"1st", "_1st"},
+		{"-x", "_-x"},
+		{"", "_"},
+	}
+	for _, test := range tests {
+		if actual := mermaidName(test.name); actual != test.expected {
+			t.Errorf("mermaidName() returns invalid value. expected: %v, actual: %v", test.expected, actual)
+		}
+	}
+}
+
+func TestMermaidQuote(t *testing.T) {
+	if expected, actual := `"say 'hello' world"`, mermaidQuote("say \"hello\"\nworld"); actual != expected {
+		t.Errorf("mermaidQuote() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
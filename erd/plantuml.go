@@ -0,0 +1,93 @@
+package erd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pinzolo/dbmodel"
+	"github.com/pinzolo/dbmodel/doc"
+)
+
+var plantUMLInvalidAliasChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// WritePlantUML writes ER diagram of tables in PlantUML IE notation.
+// Primary key columns are listed above separator, and not null columns are marked with "*".
+// Cardinality of relationship is inferred from nullability and uniqueness of foreign key columns.
+// Foreign keys that reference tables not given are not drawn.
+func WritePlantUML(w io.Writer, tbls []*dbmodel.Table) error {
+	aliases := plantUMLAliases(tbls)
+	var buf bytes.Buffer
+	buf.WriteString("@startuml\n")
+	for _, tbl := range tbls {
+		fmt.Fprintf(&buf, "entity %v as %v {\n", plantUMLQuote(qualifiedName(tbl)), aliases[tbl])
+		pks := make([]*dbmodel.Column, 0, len(tbl.Columns()))
+		others := make([]*dbmodel.Column, 0, len(tbl.Columns()))
+		for _, col := range tbl.Columns() {
+			if col.PrimaryKeyPosition() > 0 {
+				pks = append(pks, col)
+			} else {
+				others = append(others, col)
+			}
+		}
+		for _, col := range pks {
+			writePlantUMLColumn(&buf, tbl, col)
+		}
+		buf.WriteString("    --\n")
+		for _, col := range others {
+			writePlantUMLColumn(&buf, tbl, col)
+		}
+		buf.WriteString("}\n")
+	}
+	relationships(tbls, func(tbl *dbmodel.Table, fk *dbmodel.ForeignKey, ref *dbmodel.Table) {
+		parent, child := foreignKeyCardinality(tbl, fk)
+		fmt.Fprintf(&buf, "%v %v--%v %v : %v\n",
+			aliases[ref],
+			crowFootLeft(parent),
+			crowFootRight(child),
+			aliases[tbl],
+			fk.Name())
+	})
+	buf.WriteString("@enduml\n")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func writePlantUMLColumn(buf *bytes.Buffer, tbl *dbmodel.Table, col *dbmodel.Column) {
+	buf.WriteString("    ")
+	if !col.IsNullable() {
+		buf.WriteString("* ")
+	}
+	fmt.Fprintf(buf, "%v : %v", col.Name(), doc.FullType(col))
+	if m := columnMarker(tbl, col); m != "" {
+		buf.WriteString(" <<" + strings.Replace(m, ",", ">> <<", -1) + ">>")
+	}
+	if col.Comment() != "" {
+		buf.WriteString(" // " + strings.NewReplacer("\r\n", " ", "\n", " ").Replace(col.Comment()))
+	}
+	buf.WriteString("\n")
+}
+
+// plantUMLAliases returns unique aliases of entities that consist of word characters.
+// When aliases of some tables are same (eg. "a_b.c" and "a.b_c"), number is suffixed to following aliases.
+func plantUMLAliases(tbls []*dbmodel.Table) map[*dbmodel.Table]string {
+	used := make(map[string]bool, len(tbls))
+	aliases := make(map[*dbmodel.Table]string, len(tbls))
+	for _, tbl := range tbls {
+		base := plantUMLInvalidAliasChars.ReplaceAllString(tbl.Schema()+"_"+tbl.Name(), "_")
+		alias := base
+		for i := 2; used[alias]; i++ {
+			alias = base + "_" + strconv.Itoa(i)
+		}
+		used[alias] = true
+		aliases[tbl] = alias
+	}
+	return aliases
+}
+
+func plantUMLQuote(s string) string {
+	return `"` + strings.Replace(s, `"`, `'`, -1) + `"`
+}
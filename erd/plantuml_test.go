@@ -0,0 +1,80 @@
+package erd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pinzolo/dbmodel"
+)
+
+func TestWritePlantUML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WritePlantUML(&buf, loadTestTables(t)); err != nil {
+		t.Fatal(err)
+	}
+	expected := `@startuml
+entity "foo.users" as foo_users {
+    * id : int4(32, 0) <<PK>>
+    --
+    * email : varchar(255) // login id
+    name : text
+}
+entity "foo.posts" as foo_posts {
+    * id : int4(32, 0) <<PK>>
+    --
+    user_id : int4(32, 0) <<FK>>
+    body : text
+}
+entity "foo.profiles" as foo_profiles {
+    * user_id : int4 <<PK>> <<FK>>
+    --
+}
+entity "bar.active_users" as bar_active_users {
+    --
+    id : int4
+}
+entity "bar.comments" as bar_comments {
+    * id : int4 <<PK>>
+    --
+    post_id : int4 <<FK>>
+}
+foo_users |o--o{ foo_posts : posts_user_id_fkey
+foo_users ||--o| foo_profiles : profiles_user_id_fkey
+foo_posts |o--o{ bar_comments : comments_post_id_fkey
+@enduml
+`
+	if actual := buf.String(); actual != expected {
+		t.Errorf("WritePlantUML() writes invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestWritePlantUMLWithSameAliases(t *testing.T) {
+	parent := dbmodel.NewTable("a_b", "c", "")
+	id := dbmodel.NewColumn("a_b", "c", "id", "", "int4", dbmodel.Size{}, false, "", 1)
+	parent.AddColumn(&id)
+	child := dbmodel.NewTable("a", "b_c", "")
+	parentID := dbmodel.NewColumn("a", "b_c", "parent_id", "", "int4", dbmodel.Size{}, true, "", 0)
+	child.AddColumn(&parentID)
+	fk := dbmodel.NewForeignKey("a", "b_c", "b_c_parent_id_fkey")
+	cr := dbmodel.NewColumnReference(&parentID, &id)
+	fk.AddColumnReference(&cr)
+	child.AddForeignKey(&fk)
+	other := dbmodel.NewTable("a", "b-c", "")
+
+	var buf bytes.Buffer
+	if err := WritePlantUML(&buf, []*dbmodel.Table{&parent, &child, &other}); err != nil {
+		t.Fatal(err)
+	}
+	actual := buf.String()
+	for _, expected := range []string{
+		"entity \"a_b.c\" as a_b_c {\n",
+		"entity \"a.b_c\" as a_b_c_2 {\n",
+		"entity \"a.b-c\" as a_b_c_3 {\n",
+		"a_b_c |o--o{ a_b_c_2 : b_c_parent_id_fkey\n",
+	} {
+		if !strings.Contains(actual, expected) {
+			t.Errorf("WritePlantUML() should write %v. actual: %v", expected, actual)
+		}
+	}
+}
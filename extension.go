@@ -0,0 +1,83 @@
+package dbmodel
+
+// Extension is installed extension's meta data.
+type Extension struct {
+	name    string
+	version string
+	schema  string
+	comment string
+	objects []*ExtensionObject
+}
+
+// Name returns extension name.
+func (e Extension) Name() string {
+	return e.name
+}
+
+// Version returns installed version.
+func (e Extension) Version() string {
+	return e.version
+}
+
+// Schema returns schema that contains extension's objects.
+func (e Extension) Schema() string {
+	return e.schema
+}
+
+// Comment returns extension comment.
+func (e Extension) Comment() string {
+	return e.comment
+}
+
+// Objects returns database objects that belong to this extension.
+func (e Extension) Objects() []*ExtensionObject {
+	return e.objects
+}
+
+// AddObject appends object to Objects.
+func (e *Extension) AddObject(o *ExtensionObject) {
+	e.objects = append(e.objects, o)
+}
+
+// NewExtension returns new Extension initialized with arguments.
+func NewExtension(name string, version string, schema string, comment string) Extension {
+	return Extension{
+		name:    name,
+		version: version,
+		schema:  schema,
+		comment: comment,
+		objects: make([]*ExtensionObject, 0, 10),
+	}
+}
+
+// ExtensionObject is database object that belongs to extension.
+type ExtensionObject struct {
+	kind   string
+	schema string
+	name   string
+}
+
+// Kind returns object kind. (eg. 'TABLE', 'VIEW', 'SEQUENCE', 'TYPE', 'FUNCTION')
+func (o ExtensionObject) Kind() string {
+	return o.kind
+}
+
+// Schema returns object schema.
+func (o ExtensionObject) Schema() string {
+	return o.schema
+}
+
+// Name returns object name.
+// If object is function, Name contains argument types. (eg. 'similarity(text, text)')
+func (o ExtensionObject) Name() string {
+	return o.name
+}
+
+// NewExtensionObject returns new ExtensionObject initialized with arguments.
+func NewExtensionObject(kind string, schema string, name string) ExtensionObject {
+	return ExtensionObject{
+		kind:   kind,
+		schema: schema,
+		name:   name,
+	}
+}
@@ -0,0 +1,40 @@
+package dbmodel
+
+import "testing"
+
+func TestNewExtension(t *testing.T) {
+	e := NewExtension("pg_trgm", "1.3", "public", "text similarity")
+	if expected, actual := "pg_trgm", e.Name(); actual != expected {
+		t.Errorf("Name() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := "1.3", e.Version(); actual != expected {
+		t.Errorf("Version() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := "public", e.Schema(); actual != expected {
+		t.Errorf("Schema() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := "text similarity", e.Comment(); actual != expected {
+		t.Errorf("Comment() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if e.Objects() == nil || len(e.Objects()) != 0 {
+		t.Error("Objects() should be initialized.")
+	}
+}
+
+func TestAddObjectToExtension(t *testing.T) {
+	e := NewExtension("pg_trgm", "1.3", "public", "")
+	o := NewExtensionObject("FUNCTION", "public", "similarity(text, text)")
+	e.AddObject(&o)
+	if len(e.Objects()) != 1 || e.Objects()[0] != &o {
+		t.Errorf("Failed to add object. (%#v)", e.Objects())
+	}
+	if expected, actual := "FUNCTION", e.Objects()[0].Kind(); actual != expected {
+		t.Errorf("Kind() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := "public", e.Objects()[0].Schema(); actual != expected {
+		t.Errorf("Schema() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := "similarity(text, text)", e.Objects()[0].Name(); actual != expected {
+		t.Errorf("Name() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
@@ -6,6 +6,7 @@ type ForeignKey struct {
 	tableName string
 	name      string
 	colRefs   []*ColumnReference
+	refTable  *Table
 }
 
 // Schema returns foreign key's schema.
@@ -28,6 +29,12 @@ func (fk ForeignKey) ColumnReferences() []*ColumnReference {
 	return fk.colRefs
 }
 
+// ReferencedTable returns table that this foreign key references.
+// ReferencedTable returns nil until referenced table is loaded together by Client.AllTables.
+func (fk ForeignKey) ReferencedTable() *Table {
+	return fk.refTable
+}
+
 // AddColumnReference appends column reference to ColumnReferences.
 func (fk *ForeignKey) AddColumnReference(r *ColumnReference) {
 	fk.colRefs = append(fk.colRefs, r)
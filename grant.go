@@ -0,0 +1,33 @@
+package dbmodel
+
+// Grant is privilege granted to a role.
+type Grant struct {
+	grantee   string
+	privilege string
+	grantable bool
+}
+
+// Grantee returns role name that privilege is granted to.
+// If privilege is granted to all roles, Grantee returns 'PUBLIC'.
+func (g Grant) Grantee() string {
+	return g.grantee
+}
+
+// Privilege returns privilege. (eg. 'SELECT', 'INSERT', 'UPDATE')
+func (g Grant) Privilege() string {
+	return g.privilege
+}
+
+// IsGrantable returns true if grantee can grant this privilege to other roles.
+func (g Grant) IsGrantable() bool {
+	return g.grantable
+}
+
+// NewGrant returns new Grant initialized with arguments.
+func NewGrant(grantee string, privilege string, grantable bool) Grant {
+	return Grant{
+		grantee:   grantee,
+		privilege: privilege,
+		grantable: grantable,
+	}
+}
@@ -0,0 +1,16 @@
+package dbmodel
+
+import "testing"
+
+func TestNewGrant(t *testing.T) {
+	g := NewGrant("app", "SELECT", true)
+	if expected, actual := "app", g.Grantee(); actual != expected {
+		t.Errorf("Grantee() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := "SELECT", g.Privilege(); actual != expected {
+		t.Errorf("Privilege() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if !g.IsGrantable() {
+		t.Error("Given true, IsGrantable should return true.")
+	}
+}
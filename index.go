@@ -7,6 +7,7 @@ type Index struct {
 	name      string
 	unique    bool
 	columns   []*Column
+	stats     *IndexStats
 }
 
 // Schema returns index schema.
@@ -34,6 +35,12 @@ func (i Index) Columns() []*Column {
 	return i.columns
 }
 
+// Stats returns index statistics.
+// If statistics are not loaded, Stats returns nil.
+func (i Index) Stats() *IndexStats {
+	return i.stats
+}
+
 // NewIndex returns new Index initialized with arguments.
 func NewIndex(schema string, tableName string, name string, unique bool) Index {
 	return Index{
@@ -49,3 +56,8 @@ func NewIndex(schema string, tableName string, name string, unique bool) Index {
 func (i *Index) AddColumn(col *Column) {
 	i.columns = append(i.columns, col)
 }
+
+// SetStats sets index statistics.
+func (i *Index) SetStats(s *IndexStats) {
+	i.stats = s
+}
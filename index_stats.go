@@ -0,0 +1,39 @@
+package dbmodel
+
+// IndexStats is index statistics collected by database.
+type IndexStats struct {
+	sizeBytes     int64
+	scans         int64
+	tuplesRead    int64
+	tuplesFetched int64
+}
+
+// SizeBytes returns index size.
+func (s IndexStats) SizeBytes() int64 {
+	return s.sizeBytes
+}
+
+// Scans returns number of index scans.
+func (s IndexStats) Scans() int64 {
+	return s.scans
+}
+
+// TuplesRead returns number of index entries returned by scans.
+func (s IndexStats) TuplesRead() int64 {
+	return s.tuplesRead
+}
+
+// TuplesFetched returns number of table rows fetched by scans.
+func (s IndexStats) TuplesFetched() int64 {
+	return s.tuplesFetched
+}
+
+// NewIndexStats returns new IndexStats initialized with arguments.
+func NewIndexStats(sizeBytes int64, scans int64, tuplesRead int64, tuplesFetched int64) IndexStats {
+	return IndexStats{
+		sizeBytes:     sizeBytes,
+		scans:         scans,
+		tuplesRead:    tuplesRead,
+		tuplesFetched: tuplesFetched,
+	}
+}
@@ -0,0 +1,31 @@
+package dbmodel
+
+import "testing"
+
+func TestNewIndexStats(t *testing.T) {
+	s := NewIndexStats(16384, 10, 20, 15)
+	if expected, actual := int64(16384), s.SizeBytes(); actual != expected {
+		t.Errorf("SizeBytes() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := int64(10), s.Scans(); actual != expected {
+		t.Errorf("Scans() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := int64(20), s.TuplesRead(); actual != expected {
+		t.Errorf("TuplesRead() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := int64(15), s.TuplesFetched(); actual != expected {
+		t.Errorf("TuplesFetched() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestSetStatsToIndex(t *testing.T) {
+	idx := NewIndex("foo", "users", "users_pk", true)
+	if idx.Stats() != nil {
+		t.Error("Stats() should return nil when statistics are not loaded.")
+	}
+	s := NewIndexStats(8192, 0, 0, 0)
+	idx.SetStats(&s)
+	if idx.Stats() != &s {
+		t.Error("Stats() should return set statistics.")
+	}
+}
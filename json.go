@@ -0,0 +1,664 @@
+package dbmodel
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// LinkTables links indices, foreign keys and referenced keys of given tables to columns of given tables.
+// Tables loaded by Client are already linked.
+// Use this after decoding tables from JSON, because foreign keys are encoded as column names only.
+func LinkTables(tbls []*Table) {
+	linkTables(tbls)
+}
+
+type jsonSize struct {
+	Length    *int64 `json:"length"`
+	Precision *int64 `json:"precision"`
+	Scale     *int64 `json:"scale"`
+}
+
+// MarshalJSON encodes size as object that has length, precision and scale.
+// Invalid values are encoded as null.
+//
+//	{"length": 10, "precision": null, "scale": null}
+func (s Size) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonSize{
+		Length:    int64Ptr(s.length),
+		Precision: int64Ptr(s.precision),
+		Scale:     int64Ptr(s.scale),
+	})
+}
+
+// UnmarshalJSON decodes size encoded by MarshalJSON.
+func (s *Size) UnmarshalJSON(b []byte) error {
+	var js jsonSize
+	if err := json.Unmarshal(b, &js); err != nil {
+		return err
+	}
+	*s = NewSize(nullInt64(js.Length), nullInt64(js.Precision), nullInt64(js.Scale))
+	return nil
+}
+
+type jsonColumn struct {
+	Schema             string       `json:"schema"`
+	TableName          string       `json:"table"`
+	Name               string       `json:"name"`
+	Comment            string       `json:"comment"`
+	DataType           string       `json:"data_type"`
+	Size               Size         `json:"size"`
+	Nullable           bool         `json:"nullable"`
+	DefaultValue       string       `json:"default_value"`
+	PrimaryKeyPosition int64        `json:"primary_key_position"`
+	Stats              *ColumnStats `json:"stats,omitempty"`
+	Grants             []*Grant     `json:"grants,omitempty"`
+	LogicalType        LogicalType  `json:"logical_type,omitempty"`
+}
+
+// MarshalJSON encodes column as object.
+// stats and grants are omitted when they are not loaded,
+// and logical_type is omitted when column is not loaded by Client.
+func (c Column) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonColumn{
+		Schema:             c.schema,
+		TableName:          c.tableName,
+		Name:               c.name,
+		Comment:            c.comment,
+		DataType:           c.dataType,
+		Size:               c.size,
+		Nullable:           c.nullable,
+		DefaultValue:       c.defaultValue,
+		PrimaryKeyPosition: c.pkPosition,
+		Stats:              c.stats,
+		Grants:             c.grants,
+		LogicalType:        c.logicalType,
+	})
+}
+
+// UnmarshalJSON decodes column encoded by MarshalJSON.
+func (c *Column) UnmarshalJSON(b []byte) error {
+	var jc jsonColumn
+	if err := json.Unmarshal(b, &jc); err != nil {
+		return err
+	}
+	for i, g := range jc.Grants {
+		if g == nil {
+			return fmt.Errorf("Grant is empty at index %v of column '%v'.", i, jc.Name)
+		}
+	}
+	*c = NewColumn(jc.Schema, jc.TableName, jc.Name, jc.Comment, jc.DataType, jc.Size, jc.Nullable, jc.DefaultValue, jc.PrimaryKeyPosition)
+	c.stats = jc.Stats
+	c.grants = jc.Grants
+	c.logicalType = jc.LogicalType
+	return nil
+}
+
+type jsonIndex struct {
+	Schema    string      `json:"schema"`
+	TableName string      `json:"table"`
+	Name      string      `json:"name"`
+	Unique    bool        `json:"unique"`
+	Columns   []string    `json:"columns"`
+	Stats     *IndexStats `json:"stats,omitempty"`
+}
+
+// MarshalJSON encodes index as object.
+// Columns are encoded as column names.
+func (i Index) MarshalJSON() ([]byte, error) {
+	cols := make([]string, 0, len(i.columns))
+	for _, col := range i.columns {
+		cols = append(cols, col.Name())
+	}
+	return json.Marshal(jsonIndex{
+		Schema:    i.schema,
+		TableName: i.tableName,
+		Name:      i.name,
+		Unique:    i.unique,
+		Columns:   cols,
+		Stats:     i.stats,
+	})
+}
+
+// UnmarshalJSON decodes index encoded by MarshalJSON.
+// Columns of decoded index have only schema, table name and name until they are linked by LinkTables.
+func (i *Index) UnmarshalJSON(b []byte) error {
+	var ji jsonIndex
+	if err := json.Unmarshal(b, &ji); err != nil {
+		return err
+	}
+	*i = NewIndex(ji.Schema, ji.TableName, ji.Name, ji.Unique)
+	for _, name := range ji.Columns {
+		col := placeholderColumn(ji.Schema, ji.TableName, name)
+		i.AddColumn(col)
+	}
+	i.stats = ji.Stats
+	return nil
+}
+
+type jsonColumnName struct {
+	Schema    string `json:"schema"`
+	TableName string `json:"table"`
+	Name      string `json:"column"`
+}
+
+type jsonColumnReference struct {
+	From *jsonColumnName `json:"from"`
+	To   *jsonColumnName `json:"to"`
+}
+
+// MarshalJSON encodes column reference as pair of schema, table and column name.
+//
+//	{"from": {"schema": "public", "table": "posts", "column": "user_id"}, "to": {"schema": "public", "table": "users", "column": "id"}}
+func (cr ColumnReference) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonColumnReference{
+		From: columnName(cr.from),
+		To:   columnName(cr.to),
+	})
+}
+
+// UnmarshalJSON decodes column reference encoded by MarshalJSON.
+// Columns of decoded reference have only schema, table name and name until they are linked by LinkTables.
+func (cr *ColumnReference) UnmarshalJSON(b []byte) error {
+	var jcr jsonColumnReference
+	if err := json.Unmarshal(b, &jcr); err != nil {
+		return err
+	}
+	if jcr.From == nil || jcr.To == nil {
+		return errors.New("Column reference requires both from and to.")
+	}
+	*cr = NewColumnReference(jcr.From.column(), jcr.To.column())
+	return nil
+}
+
+type jsonForeignKey struct {
+	Schema           string             `json:"schema"`
+	TableName        string             `json:"table"`
+	Name             string             `json:"name"`
+	ColumnReferences []*ColumnReference `json:"column_references"`
+}
+
+// MarshalJSON encodes foreign key as object.
+// Referenced table is not encoded, because it is derived from column references.
+func (fk ForeignKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonForeignKey{
+		Schema:           fk.schema,
+		TableName:        fk.tableName,
+		Name:             fk.name,
+		ColumnReferences: fk.colRefs,
+	})
+}
+
+// UnmarshalJSON decodes foreign key encoded by MarshalJSON.
+func (fk *ForeignKey) UnmarshalJSON(b []byte) error {
+	var jfk jsonForeignKey
+	if err := json.Unmarshal(b, &jfk); err != nil {
+		return err
+	}
+	*fk = NewForeignKey(jfk.Schema, jfk.TableName, jfk.Name)
+	for i, cr := range jfk.ColumnReferences {
+		if cr == nil {
+			return fmt.Errorf("Column reference is empty at index %v of foreign key '%v'.", i, jfk.Name)
+		}
+		fk.AddColumnReference(cr)
+	}
+	return nil
+}
+
+type jsonConstraint struct {
+	Schema    string `json:"schema"`
+	TableName string `json:"table"`
+	Name      string `json:"name"`
+	Kind      string `json:"kind"`
+	Content   string `json:"content"`
+}
+
+// MarshalJSON encodes constraint as object.
+func (c Constraint) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonConstraint{
+		Schema:    c.schema,
+		TableName: c.tableName,
+		Name:      c.name,
+		Kind:      c.kind,
+		Content:   c.content,
+	})
+}
+
+// UnmarshalJSON decodes constraint encoded by MarshalJSON.
+func (c *Constraint) UnmarshalJSON(b []byte) error {
+	var jc jsonConstraint
+	if err := json.Unmarshal(b, &jc); err != nil {
+		return err
+	}
+	*c = NewConstraint(jc.Schema, jc.TableName, jc.Name, jc.Kind, jc.Content)
+	return nil
+}
+
+type jsonTable struct {
+	Schema           string        `json:"schema"`
+	Name             string        `json:"name"`
+	Comment          string        `json:"comment"`
+	Kind             TableKind     `json:"kind"`
+	Columns          []*Column     `json:"columns"`
+	Indices          []*Index      `json:"indices"`
+	ForeignKeys      []*ForeignKey `json:"foreign_keys"`
+	ReferencedKeys   []*ForeignKey `json:"referenced_keys"`
+	Constraints      []*Constraint `json:"constraints"`
+	Stats            *TableStats   `json:"stats,omitempty"`
+	Owner            string        `json:"owner,omitempty"`
+	Grants           []*Grant      `json:"grants,omitempty"`
+	RowSecurity      bool          `json:"row_security,omitempty"`
+	ForceRowSecurity bool          `json:"force_row_security,omitempty"`
+	Policies         []*Policy     `json:"policies,omitempty"`
+	Extension        string        `json:"extension,omitempty"`
+}
+
+// MarshalJSON encodes table as object with columns, indices, foreign keys, referenced keys and constraints.
+// Values loaded by optional settings (stats, owner, grants, row security, policies and extension) are omitted when they are empty.
+func (t Table) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonTable{
+		Schema:           t.schema,
+		Name:             t.name,
+		Comment:          t.comment,
+		Kind:             t.kind,
+		Columns:          t.columns,
+		Indices:          t.indices,
+		ForeignKeys:      t.foreignKeys,
+		ReferencedKeys:   t.refKeys,
+		Constraints:      t.constraints,
+		Stats:            t.stats,
+		Owner:            t.owner,
+		Grants:           t.grants,
+		RowSecurity:      t.rowSecurity,
+		ForceRowSecurity: t.forceRLS,
+		Policies:         t.policies,
+		Extension:        t.extension,
+	})
+}
+
+// UnmarshalJSON decodes table encoded by MarshalJSON.
+// Indices and foreign keys are linked to columns of decoded table.
+// Use LinkTables to link foreign keys and referenced keys among decoded tables.
+func (t *Table) UnmarshalJSON(b []byte) error {
+	var jt jsonTable
+	if err := json.Unmarshal(b, &jt); err != nil {
+		return err
+	}
+	*t = NewTable(jt.Schema, jt.Name, jt.Comment)
+	if jt.Kind != "" {
+		t.kind = jt.Kind
+	}
+	for i, col := range jt.Columns {
+		if col == nil {
+			return fmt.Errorf("Column is empty at index %v of table '%v.%v'.", i, jt.Schema, jt.Name)
+		}
+		t.AddColumn(col)
+	}
+	for i, idx := range jt.Indices {
+		if idx == nil {
+			return fmt.Errorf("Index is empty at index %v of table '%v.%v'.", i, jt.Schema, jt.Name)
+		}
+		t.AddIndex(idx)
+	}
+	for i, fk := range jt.ForeignKeys {
+		if fk == nil {
+			return fmt.Errorf("Foreign key is empty at index %v of table '%v.%v'.", i, jt.Schema, jt.Name)
+		}
+		t.AddForeignKey(fk)
+	}
+	for i, rk := range jt.ReferencedKeys {
+		if rk == nil {
+			return fmt.Errorf("Referenced key is empty at index %v of table '%v.%v'.", i, jt.Schema, jt.Name)
+		}
+		t.AddReferencedKey(rk)
+	}
+	for i, con := range jt.Constraints {
+		if con == nil {
+			return fmt.Errorf("Constraint is empty at index %v of table '%v.%v'.", i, jt.Schema, jt.Name)
+		}
+		t.AddConstraint(con)
+	}
+	for i, g := range jt.Grants {
+		if g == nil {
+			return fmt.Errorf("Grant is empty at index %v of table '%v.%v'.", i, jt.Schema, jt.Name)
+		}
+	}
+	for i, pol := range jt.Policies {
+		if pol == nil {
+			return fmt.Errorf("Policy is empty at index %v of table '%v.%v'.", i, jt.Schema, jt.Name)
+		}
+	}
+	t.stats = jt.Stats
+	t.owner = jt.Owner
+	t.grants = jt.Grants
+	t.rowSecurity = jt.RowSecurity
+	t.forceRLS = jt.ForceRowSecurity
+	t.policies = jt.Policies
+	t.extension = jt.Extension
+	linkTables([]*Table{t})
+	return nil
+}
+
+type jsonTableStats struct {
+	EstimatedRows int64      `json:"estimated_rows"`
+	TotalBytes    int64      `json:"total_bytes"`
+	HeapBytes     int64      `json:"heap_bytes"`
+	ToastBytes    int64      `json:"toast_bytes"`
+	IndexBytes    int64      `json:"index_bytes"`
+	LastVacuum    *time.Time `json:"last_vacuum"`
+	LastAnalyze   *time.Time `json:"last_analyze"`
+}
+
+// MarshalJSON encodes table statistics as object.
+// Zero times are encoded as null.
+func (s TableStats) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonTableStats{
+		EstimatedRows: s.estimatedRows,
+		TotalBytes:    s.totalBytes,
+		HeapBytes:     s.heapBytes,
+		ToastBytes:    s.toastBytes,
+		IndexBytes:    s.indexBytes,
+		LastVacuum:    timePtr(s.lastVacuum),
+		LastAnalyze:   timePtr(s.lastAnalyze),
+	})
+}
+
+// UnmarshalJSON decodes table statistics encoded by MarshalJSON.
+func (s *TableStats) UnmarshalJSON(b []byte) error {
+	var js jsonTableStats
+	if err := json.Unmarshal(b, &js); err != nil {
+		return err
+	}
+	*s = NewTableStats(js.EstimatedRows, js.TotalBytes, js.HeapBytes, js.ToastBytes, js.IndexBytes, timeOrZero(js.LastVacuum), timeOrZero(js.LastAnalyze))
+	return nil
+}
+
+type jsonIndexStats struct {
+	SizeBytes     int64 `json:"size_bytes"`
+	Scans         int64 `json:"scans"`
+	TuplesRead    int64 `json:"tuples_read"`
+	TuplesFetched int64 `json:"tuples_fetched"`
+}
+
+// MarshalJSON encodes index statistics as object.
+func (s IndexStats) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonIndexStats{
+		SizeBytes:     s.sizeBytes,
+		Scans:         s.scans,
+		TuplesRead:    s.tuplesRead,
+		TuplesFetched: s.tuplesFetched,
+	})
+}
+
+// UnmarshalJSON decodes index statistics encoded by MarshalJSON.
+func (s *IndexStats) UnmarshalJSON(b []byte) error {
+	var js jsonIndexStats
+	if err := json.Unmarshal(b, &js); err != nil {
+		return err
+	}
+	*s = NewIndexStats(js.SizeBytes, js.Scans, js.TuplesRead, js.TuplesFetched)
+	return nil
+}
+
+type jsonColumnStats struct {
+	NullFraction          float64   `json:"null_fraction"`
+	Distinct              float64   `json:"distinct"`
+	AverageWidth          int64     `json:"average_width"`
+	MostCommonValues      []string  `json:"most_common_values"`
+	MostCommonFrequencies []float64 `json:"most_common_frequencies"`
+	HistogramBounds       []string  `json:"histogram_bounds"`
+}
+
+// MarshalJSON encodes column statistics as object.
+func (s ColumnStats) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonColumnStats{
+		NullFraction:          s.nullFraction,
+		Distinct:              s.distinct,
+		AverageWidth:          s.averageWidth,
+		MostCommonValues:      s.mostCommonValues,
+		MostCommonFrequencies: s.mostCommonFrequencies,
+		HistogramBounds:       s.histogramBounds,
+	})
+}
+
+// UnmarshalJSON decodes column statistics encoded by MarshalJSON.
+func (s *ColumnStats) UnmarshalJSON(b []byte) error {
+	var js jsonColumnStats
+	if err := json.Unmarshal(b, &js); err != nil {
+		return err
+	}
+	*s = NewColumnStats(js.NullFraction, js.Distinct, js.AverageWidth, js.MostCommonValues, js.MostCommonFrequencies, js.HistogramBounds)
+	return nil
+}
+
+type jsonGrant struct {
+	Grantee   string `json:"grantee"`
+	Privilege string `json:"privilege"`
+	Grantable bool   `json:"grantable"`
+}
+
+// MarshalJSON encodes grant as object.
+func (g Grant) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonGrant{
+		Grantee:   g.grantee,
+		Privilege: g.privilege,
+		Grantable: g.grantable,
+	})
+}
+
+// UnmarshalJSON decodes grant encoded by MarshalJSON.
+func (g *Grant) UnmarshalJSON(b []byte) error {
+	var jg jsonGrant
+	if err := json.Unmarshal(b, &jg); err != nil {
+		return err
+	}
+	*g = NewGrant(jg.Grantee, jg.Privilege, jg.Grantable)
+	return nil
+}
+
+type jsonRole struct {
+	Name       string   `json:"name"`
+	Superuser  bool     `json:"superuser"`
+	Inherit    bool     `json:"inherit"`
+	CreateRole bool     `json:"create_role"`
+	CreateDB   bool     `json:"create_db"`
+	CanLogin   bool     `json:"can_login"`
+	MemberOf   []string `json:"member_of"`
+}
+
+// MarshalJSON encodes role as object.
+func (r Role) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonRole{
+		Name:       r.name,
+		Superuser:  r.superuser,
+		Inherit:    r.inherit,
+		CreateRole: r.createRole,
+		CreateDB:   r.createDB,
+		CanLogin:   r.canLogin,
+		MemberOf:   r.memberOf,
+	})
+}
+
+// UnmarshalJSON decodes role encoded by MarshalJSON.
+func (r *Role) UnmarshalJSON(b []byte) error {
+	var jr jsonRole
+	if err := json.Unmarshal(b, &jr); err != nil {
+		return err
+	}
+	*r = NewRole(jr.Name, jr.Superuser, jr.Inherit, jr.CreateRole, jr.CreateDB, jr.CanLogin, jr.MemberOf)
+	return nil
+}
+
+type jsonPolicy struct {
+	Name       string   `json:"name"`
+	Command    string   `json:"command"`
+	Permissive bool     `json:"permissive"`
+	Roles      []string `json:"roles"`
+	Using      string   `json:"using"`
+	WithCheck  string   `json:"with_check"`
+}
+
+// MarshalJSON encodes policy as object.
+func (p Policy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonPolicy{
+		Name:       p.name,
+		Command:    p.command,
+		Permissive: p.permissive,
+		Roles:      p.roles,
+		Using:      p.using,
+		WithCheck:  p.withCheck,
+	})
+}
+
+// UnmarshalJSON decodes policy encoded by MarshalJSON.
+func (p *Policy) UnmarshalJSON(b []byte) error {
+	var jp jsonPolicy
+	if err := json.Unmarshal(b, &jp); err != nil {
+		return err
+	}
+	*p = NewPolicy(jp.Name, jp.Command, jp.Permissive, jp.Roles, jp.Using, jp.WithCheck)
+	return nil
+}
+
+type jsonExtension struct {
+	Name    string             `json:"name"`
+	Version string             `json:"version"`
+	Schema  string             `json:"schema"`
+	Comment string             `json:"comment"`
+	Objects []*ExtensionObject `json:"objects"`
+}
+
+// MarshalJSON encodes extension as object with objects that belong to it.
+func (e Extension) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonExtension{
+		Name:    e.name,
+		Version: e.version,
+		Schema:  e.schema,
+		Comment: e.comment,
+		Objects: e.objects,
+	})
+}
+
+// UnmarshalJSON decodes extension encoded by MarshalJSON.
+func (e *Extension) UnmarshalJSON(b []byte) error {
+	var je jsonExtension
+	if err := json.Unmarshal(b, &je); err != nil {
+		return err
+	}
+	*e = NewExtension(je.Name, je.Version, je.Schema, je.Comment)
+	for i, o := range je.Objects {
+		if o == nil {
+			return fmt.Errorf("Object is empty at index %v of extension '%v'.", i, je.Name)
+		}
+		e.AddObject(o)
+	}
+	return nil
+}
+
+type jsonExtensionObject struct {
+	Kind   string `json:"kind"`
+	Schema string `json:"schema"`
+	Name   string `json:"name"`
+}
+
+// MarshalJSON encodes extension object as object.
+func (o ExtensionObject) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonExtensionObject{
+		Kind:   o.kind,
+		Schema: o.schema,
+		Name:   o.name,
+	})
+}
+
+// UnmarshalJSON decodes extension object encoded by MarshalJSON.
+func (o *ExtensionObject) UnmarshalJSON(b []byte) error {
+	var jo jsonExtensionObject
+	if err := json.Unmarshal(b, &jo); err != nil {
+		return err
+	}
+	*o = NewExtensionObject(jo.Kind, jo.Schema, jo.Name)
+	return nil
+}
+
+type jsonSnapshot struct {
+	FormatVersion int          `json:"format_version"`
+	Database      string       `json:"database"`
+	ServerVersion string       `json:"server_version"`
+	Tables        []*Table     `json:"tables"`
+	Roles         []*Role      `json:"roles"`
+	Extensions    []*Extension `json:"extensions"`
+}
+
+// MarshalJSON encodes snapshot as object with format version.
+func (s Snapshot) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonSnapshot{
+		FormatVersion: s.formatVersion,
+		Database:      s.database,
+		ServerVersion: s.serverVersion,
+		Tables:        s.tables,
+		Roles:         s.roles,
+		Extensions:    s.extensions,
+	})
+}
+
+// UnmarshalJSON decodes snapshot encoded by MarshalJSON.
+// Format version is not checked. (ReadSnapshot checks it)
+func (s *Snapshot) UnmarshalJSON(b []byte) error {
+	var js jsonSnapshot
+	if err := json.Unmarshal(b, &js); err != nil {
+		return err
+	}
+	*s = NewSnapshot(js.Database, js.ServerVersion, js.Tables, js.Roles, js.Extensions)
+	s.formatVersion = js.FormatVersion
+	return nil
+}
+
+func columnName(col *Column) *jsonColumnName {
+	if col == nil {
+		return nil
+	}
+	return &jsonColumnName{
+		Schema:    col.Schema(),
+		TableName: col.TableName(),
+		Name:      col.Name(),
+	}
+}
+
+func (n *jsonColumnName) column() *Column {
+	if n == nil {
+		return nil
+	}
+	return placeholderColumn(n.Schema, n.TableName, n.Name)
+}
+
+func placeholderColumn(schema string, tableName string, name string) *Column {
+	return &Column{
+		schema:    schema,
+		tableName: tableName,
+		name:      name,
+	}
+}
+
+func int64Ptr(v sql.NullInt64) *int64 {
+	if !v.Valid {
+		return nil
+	}
+	i := v.Int64
+	return &i
+}
+
+func nullInt64(p *int64) sql.NullInt64 {
+	if p == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *p, Valid: true}
+}
+
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,192 @@
+package dbmodel
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestSizeMarshalJSON(t *testing.T) {
+	b, err := json.Marshal(NewSize(validInt(10), invalidInt(), invalidInt()))
+	if err != nil {
+		t.Error(err)
+	}
+	if expected, actual := `{"length":10,"precision":null,"scale":null}`, string(b); actual != expected {
+		t.Errorf("MarshalJSON() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestSizeUnmarshalJSON(t *testing.T) {
+	var s Size
+	if err := json.Unmarshal([]byte(`{"length":null,"precision":8,"scale":2}`), &s); err != nil {
+		t.Error(err)
+	}
+	if expected, actual := NewSize(invalidInt(), validInt(8), validInt(2)), s; actual != expected {
+		t.Errorf("UnmarshalJSON() decodes invalid value. expected: %#v, actual: %#v", expected, actual)
+	}
+}
+
+func TestColumnReferenceMarshalJSON(t *testing.T) {
+	cr := NewColumnReference(
+		&Column{schema: "foo", tableName: "posts", name: "user_id"},
+		&Column{schema: "foo", tableName: "users", name: "id"})
+	b, err := json.Marshal(cr)
+	if err != nil {
+		t.Error(err)
+	}
+	expected := `{"from":{"schema":"foo","table":"posts","column":"user_id"},"to":{"schema":"foo","table":"users","column":"id"}}`
+	if actual := string(b); actual != expected {
+		t.Errorf("MarshalJSON() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestTableJSONRoundTrip(t *testing.T) {
+	usr, pst := newLinkTestTables()
+	linkTables([]*Table{usr, pst})
+	idx := NewIndex("foo", "posts", "posts_user_id_idx", false)
+	col, _ := pst.FindColumn("user_id")
+	idx.AddColumn(col)
+	pst.AddIndex(&idx)
+	col.logicalType = LogicalBigInt
+	con := NewConstraint("foo", "posts", "posts_user_id_check", "CHECK", "CHECK (user_id > 0)")
+	pst.AddConstraint(&con)
+	stats := NewTableStats(10, 8192, 8192, 0, 0, time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC), time.Time{})
+	pst.SetStats(&stats)
+
+	b, err := json.Marshal([]*Table{usr, pst})
+	if err != nil {
+		t.Error(err)
+	}
+	var tbls []*Table
+	if err = json.Unmarshal(b, &tbls); err != nil {
+		t.Error(err)
+	}
+	LinkTables(tbls)
+	if len(tbls) != 2 {
+		t.Fatalf("Table count is invalid. expected: %v, actual: %v", 2, len(tbls))
+	}
+	dUsr, dPst := tbls[0], tbls[1]
+	if expected, actual := "posts", dPst.Name(); actual != expected {
+		t.Errorf("Name() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := KindTable, dPst.Kind(); actual != expected {
+		t.Errorf("Kind() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	dCol, ok := dPst.FindColumn("user_id")
+	if !ok {
+		t.Fatal("Decoded table should have user_id column.")
+	}
+	if !dCol.IsNullable() || dCol.DataType() != "integer" {
+		t.Errorf("Decoded column is invalid. (%#v)", dCol)
+	}
+	if expected, actual := LogicalBigInt, dCol.LogicalType(); actual != expected {
+		t.Errorf("LogicalType() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if dPst.Indices()[0].Columns()[0] != dCol {
+		t.Error("Index column should be linked to decoded table's column.")
+	}
+	fk := dPst.ForeignKeys()[0]
+	if fk.ColumnReferences()[0].From() != dCol {
+		t.Error("Foreign key's from column should be linked to decoded table's column.")
+	}
+	dID, _ := dUsr.FindColumn("id")
+	if fk.ColumnReferences()[0].To() != dID {
+		t.Error("Foreign key's to column should be linked to decoded referenced table's column.")
+	}
+	if fk.ReferencedTable() != dUsr {
+		t.Error("ReferencedTable() should return decoded referenced table.")
+	}
+	if dUsr.ReferencedKeys()[0] != fk {
+		t.Error("Referenced key should be same as decoded referencing table's foreign key.")
+	}
+	if expected, actual := "CHECK (user_id > 0)", dPst.Constraints()[0].Content(); actual != expected {
+		t.Errorf("Content() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if dPst.Stats() == nil || !dPst.Stats().LastVacuum().Equal(stats.LastVacuum()) || !dPst.Stats().LastAnalyze().IsZero() {
+		t.Errorf("Stats() returns invalid value. (%#v)", dPst.Stats())
+	}
+	if dUsr.Stats() != nil {
+		t.Error("Stats() should return nil when stats are not encoded.")
+	}
+}
+
+func TestTableMarshalJSONOmitsNotLoadedValues(t *testing.T) {
+	b, err := json.Marshal(newUserTable())
+	if err != nil {
+		t.Error(err)
+	}
+	var m map[string]interface{}
+	json.Unmarshal(b, &m)
+	for _, key := range []string{"stats", "owner", "grants", "row_security", "policies", "extension"} {
+		if _, ok := m[key]; ok {
+			t.Errorf("%v should be omitted. (%v)", key, string(b))
+		}
+	}
+	for _, key := range []string{"schema", "name", "comment", "kind", "columns", "indices", "foreign_keys", "referenced_keys", "constraints"} {
+		if _, ok := m[key]; !ok {
+			t.Errorf("%v should be encoded. (%v)", key, string(b))
+		}
+	}
+}
+
+func TestExtensionJSONRoundTrip(t *testing.T) {
+	ext := NewExtension("pg_trgm", "1.3", "public", "text similarity")
+	o := NewExtensionObject("FUNCTION", "public", "similarity(text, text)")
+	ext.AddObject(&o)
+
+	b, err := json.Marshal(ext)
+	if err != nil {
+		t.Error(err)
+	}
+	var decoded Extension
+	if err = json.Unmarshal(b, &decoded); err != nil {
+		t.Error(err)
+	}
+	if decoded.Name() != "pg_trgm" || decoded.Version() != "1.3" || len(decoded.Objects()) != 1 {
+		t.Errorf("UnmarshalJSON() decodes invalid value. (%#v)", decoded)
+	}
+	if expected, actual := o, *decoded.Objects()[0]; actual != expected {
+		t.Errorf("UnmarshalJSON() decodes invalid object. expected: %#v, actual: %#v", expected, actual)
+	}
+}
+
+func TestTableUnmarshalJSONRejectsNullElements(t *testing.T) {
+	cases := []struct {
+		json     string
+		expected string
+	}{
+		{`{"schema":"foo","name":"users","columns":[null]}`, "Column is empty at index 0 of table 'foo.users'."},
+		{`{"schema":"foo","name":"users","indices":[null]}`, "Index is empty at index 0 of table 'foo.users'."},
+		{`{"schema":"foo","name":"users","foreign_keys":[null]}`, "Foreign key is empty at index 0 of table 'foo.users'."},
+		{`{"schema":"foo","name":"users","referenced_keys":[null]}`, "Referenced key is empty at index 0 of table 'foo.users'."},
+		{`{"schema":"foo","name":"users","constraints":[null]}`, "Constraint is empty at index 0 of table 'foo.users'."},
+		{`{"schema":"foo","name":"users","grants":[null]}`, "Grant is empty at index 0 of table 'foo.users'."},
+		{`{"schema":"foo","name":"users","policies":[null]}`, "Policy is empty at index 0 of table 'foo.users'."},
+		{`{"schema":"foo","name":"users","columns":[{"name":"id","grants":[null]}]}`, "Grant is empty at index 0 of column 'id'."},
+		{`{"schema":"foo","name":"users","foreign_keys":[{"name":"fk","column_references":[null]}]}`, "Column reference is empty at index 0 of foreign key 'fk'."},
+		{`{"schema":"foo","name":"users","foreign_keys":[{"name":"fk","column_references":[{"from":null,"to":null}]}]}`, "Column reference requires both from and to."},
+	}
+	for _, c := range cases {
+		var tbl Table
+		err := json.Unmarshal([]byte(c.json), &tbl)
+		if err == nil {
+			t.Errorf("UnmarshalJSON() should return error for %v.", c.json)
+			continue
+		}
+		if actual := err.Error(); actual != c.expected {
+			t.Errorf("UnmarshalJSON() returns invalid error. expected: %v, actual: %v", c.expected, actual)
+		}
+	}
+}
+
+func TestExtensionUnmarshalJSONRejectsNullObject(t *testing.T) {
+	var ext Extension
+	err := json.Unmarshal([]byte(`{"name":"pg_trgm","objects":[null]}`), &ext)
+	if err == nil {
+		t.Error("UnmarshalJSON() should return error for null object.")
+		return
+	}
+	if expected, actual := "Object is empty at index 0 of extension 'pg_trgm'.", err.Error(); actual != expected {
+		t.Errorf("UnmarshalJSON() returns invalid error. expected: %v, actual: %v", expected, actual)
+	}
+}
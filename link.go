@@ -0,0 +1,60 @@
+package dbmodel
+
+// linkTables replaces placeholder columns in indices, foreign keys and referenced keys
+// with columns of given tables, so that loaded tables are navigable as object graph.
+// Columns and foreign keys of tables that are not given are left as they are.
+func linkTables(tbls []*Table) {
+	tblMap := make(map[string]*Table, len(tbls))
+	for _, tbl := range tbls {
+		tblMap[tableKey(tbl.Schema(), tbl.Name())] = tbl
+	}
+
+	for _, tbl := range tbls {
+		for _, idx := range tbl.indices {
+			for i, col := range idx.columns {
+				idx.columns[i] = findLinkedColumn(tblMap, col)
+			}
+		}
+		for _, fk := range tbl.foreignKeys {
+			linkForeignKey(tblMap, fk)
+		}
+		for i, rk := range tbl.refKeys {
+			if from, ok := tblMap[tableKey(rk.Schema(), rk.TableName())]; ok {
+				if fk, ok := from.FindForeignKey(rk.Name()); ok {
+					tbl.refKeys[i] = fk
+					rk = fk
+				}
+			}
+			linkForeignKey(tblMap, rk)
+		}
+	}
+}
+
+func linkForeignKey(tblMap map[string]*Table, fk *ForeignKey) {
+	for _, cr := range fk.colRefs {
+		cr.from = findLinkedColumn(tblMap, cr.from)
+		cr.to = findLinkedColumn(tblMap, cr.to)
+	}
+	if len(fk.colRefs) > 0 {
+		to := fk.colRefs[0].To()
+		fk.refTable = tblMap[tableKey(to.Schema(), to.TableName())]
+	}
+}
+
+func findLinkedColumn(tblMap map[string]*Table, col *Column) *Column {
+	if col == nil {
+		return nil
+	}
+	tbl, ok := tblMap[tableKey(col.Schema(), col.TableName())]
+	if !ok {
+		return col
+	}
+	if c, ok := tbl.FindColumn(col.Name()); ok {
+		return c
+	}
+	return col
+}
+
+func tableKey(schema string, name string) string {
+	return schema + "." + name
+}
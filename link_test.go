@@ -0,0 +1,81 @@
+package dbmodel
+
+import "testing"
+
+func TestLinkTablesIndexColumns(t *testing.T) {
+	usr := newUserTable()
+	id := NewColumn("foo", "users", "id", "", "integer", NewSize(invalidInt(), validInt(32), validInt(0)), false, "", 1)
+	usr.AddColumn(&id)
+	idx := NewIndex("foo", "users", "users_pkey", true)
+	idx.AddColumn(&Column{schema: "foo", tableName: "users", name: "id"})
+	usr.AddIndex(&idx)
+
+	linkTables([]*Table{usr})
+	if usr.Indices()[0].Columns()[0] != &id {
+		t.Error("Index column should be linked to table's column.")
+	}
+	if expected, actual := "integer", usr.Indices()[0].Columns()[0].DataType(); actual != expected {
+		t.Errorf("Linked index column has invalid data type. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestLinkTablesForeignKeys(t *testing.T) {
+	usr, pst := newLinkTestTables()
+	linkTables([]*Table{usr, pst})
+
+	fk := pst.ForeignKeys()[0]
+	from, _ := pst.FindColumn("user_id")
+	to, _ := usr.FindColumn("id")
+	if fk.ColumnReferences()[0].From() != from {
+		t.Error("Foreign key's from column should be linked to referencing table's column.")
+	}
+	if fk.ColumnReferences()[0].To() != to {
+		t.Error("Foreign key's to column should be linked to referenced table's column.")
+	}
+	if fk.ReferencedTable() != usr {
+		t.Errorf("ReferencedTable() should return referenced table. actual: %#v", fk.ReferencedTable())
+	}
+	if usr.ReferencedKeys()[0] != fk {
+		t.Error("Referenced key should be same as referencing table's foreign key.")
+	}
+}
+
+func TestLinkTablesLeavesUnloadedTable(t *testing.T) {
+	_, pst := newLinkTestTables()
+	linkTables([]*Table{pst})
+
+	fk := pst.ForeignKeys()[0]
+	from, _ := pst.FindColumn("user_id")
+	if fk.ColumnReferences()[0].From() != from {
+		t.Error("Foreign key's from column should be linked to referencing table's column.")
+	}
+	if expected, actual := "id", fk.ColumnReferences()[0].To().Name(); actual != expected {
+		t.Errorf("Foreign key's to column should be left. expected: %v, actual: %v", expected, actual)
+	}
+	if fk.ReferencedTable() != nil {
+		t.Error("ReferencedTable() should return nil when referenced table is not loaded.")
+	}
+}
+
+func newLinkTestTables() (*Table, *Table) {
+	usr := newUserTable()
+	usr.AddColumn(&Column{name: "id", dataType: "integer"})
+	pst := newPostTable()
+	pst.AddColumn(&Column{name: "id", dataType: "integer"})
+	pst.AddColumn(&Column{name: "user_id", dataType: "integer", nullable: true})
+
+	fk := NewForeignKey("foo", "posts", "posts_user_id")
+	cr := NewColumnReference(
+		&Column{schema: "foo", tableName: "posts", name: "user_id"},
+		&Column{schema: "foo", tableName: "users", name: "id"})
+	fk.AddColumnReference(&cr)
+	pst.AddForeignKey(&fk)
+
+	rk := NewForeignKey("foo", "posts", "posts_user_id")
+	rcr := NewColumnReference(
+		&Column{schema: "foo", tableName: "posts", name: "user_id"},
+		&Column{schema: "foo", tableName: "users", name: "id"})
+	rk.AddColumnReference(&rcr)
+	usr.AddReferencedKey(&rk)
+	return usr, pst
+}
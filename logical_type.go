@@ -0,0 +1,110 @@
+package dbmodel
+
+import "strings"
+
+// LogicalType is normalized type of column that does not depend on database.
+type LogicalType string
+
+const (
+	// LogicalUnknown is type that is not mapped.
+	LogicalUnknown LogicalType = "Unknown"
+	// LogicalInteger is integer type up to 32 bits.
+	LogicalInteger LogicalType = "Integer"
+	// LogicalBigInt is 64 bits integer type.
+	LogicalBigInt LogicalType = "BigInt"
+	// LogicalDecimal is fixed-point or floating-point number type.
+	LogicalDecimal LogicalType = "Decimal"
+	// LogicalString is character type that has max length.
+	LogicalString LogicalType = "String"
+	// LogicalText is character type without max length.
+	LogicalText LogicalType = "Text"
+	// LogicalBoolean is boolean type.
+	LogicalBoolean LogicalType = "Boolean"
+	// LogicalTimestamp is date and time type without time zone.
+	LogicalTimestamp LogicalType = "Timestamp"
+	// LogicalTimestampTZ is date and time type with time zone.
+	LogicalTimestampTZ LogicalType = "TimestampTZ"
+	// LogicalDate is date type.
+	LogicalDate LogicalType = "Date"
+	// LogicalUUID is UUID type.
+	LogicalUUID LogicalType = "UUID"
+	// LogicalJSON is JSON type.
+	LogicalJSON LogicalType = "JSON"
+	// LogicalBinary is binary type.
+	LogicalBinary LogicalType = "Binary"
+	// LogicalArray is array type.
+	LogicalArray LogicalType = "Array"
+	// LogicalEnum is enumerated type.
+	LogicalEnum LogicalType = "Enum"
+)
+
+// TypeMapping is mapping table from native data type to LogicalType.
+// Key is data type (eg. "int4"), or data type with size (eg. "tinyint(1)") that is prior to data type.
+type TypeMapping map[string]LogicalType
+
+// LogicalType returns logical type of column.
+// If data type is not in mapping, array type (eg. "_int4", "int4[]") is LogicalArray, and others are LogicalUnknown.
+func (m TypeMapping) LogicalType(col *Column) LogicalType {
+	if col.Size().IsValid() {
+		if t, ok := m[col.DataType()+"("+col.Size().String()+")"]; ok {
+			return t
+		}
+	}
+	if t, ok := m[col.DataType()]; ok {
+		return t
+	}
+	if strings.HasPrefix(col.DataType(), "_") || strings.HasSuffix(col.DataType(), "[]") {
+		return LogicalArray
+	}
+	return LogicalUnknown
+}
+
+// postgresTypeMapping is default mapping table of postgres.
+var postgresTypeMapping = TypeMapping{
+	"int2":        LogicalInteger,
+	"int4":        LogicalInteger,
+	"int8":        LogicalBigInt,
+	"float4":      LogicalDecimal,
+	"float8":      LogicalDecimal,
+	"numeric":     LogicalDecimal,
+	"money":       LogicalDecimal,
+	"varchar":     LogicalString,
+	"bpchar":      LogicalString,
+	"text":        LogicalText,
+	"citext":      LogicalText,
+	"bool":        LogicalBoolean,
+	"timestamp":   LogicalTimestamp,
+	"timestamptz": LogicalTimestampTZ,
+	"date":        LogicalDate,
+	"uuid":        LogicalUUID,
+	"json":        LogicalJSON,
+	"jsonb":       LogicalJSON,
+	"bytea":       LogicalBinary,
+}
+
+// defaultTypeMappings are default mapping tables per driver.
+// They are never modified, so that use DefaultTypeMapping to get modifiable copy.
+var defaultTypeMappings = map[string]TypeMapping{
+	"postgres": postgresTypeMapping,
+}
+
+// DefaultTypeMapping returns copy of default mapping table of driver.
+// If driver is unknown, returns empty mapping table.
+func DefaultTypeMapping(driver string) TypeMapping {
+	m := make(TypeMapping, len(defaultTypeMappings[driver]))
+	for k, v := range defaultTypeMappings[driver] {
+		m[k] = v
+	}
+	return m
+}
+
+// LogicalType returns normalized type of column.
+// Logical type of column loaded by Client is resolved with mapping of its DataSource when it is loaded,
+// and column of enum type that is not in mapping is LogicalEnum.
+// Others (eg. columns built by LoadYAML) are resolved with default mapping of postgres.
+func (c Column) LogicalType() LogicalType {
+	if c.logicalType != "" {
+		return c.logicalType
+	}
+	return postgresTypeMapping.LogicalType(&c)
+}
@@ -0,0 +1,102 @@
+package dbmodel
+
+import "testing"
+
+func newLogicalTypeTestColumn(dataType string, size Size) *Column {
+	col := NewColumn("foo", "bar", "baz", "", dataType, size, true, "", 0)
+	return &col
+}
+
+func TestTypeMappingLogicalType(t *testing.T) {
+	noSize := NewSize(invalidInt(), invalidInt(), invalidInt())
+	m := TypeMapping{
+		"tinyint":    LogicalInteger,
+		"tinyint(1)": LogicalBoolean,
+	}
+	tests := []struct {
+		dataType string
+		size     Size
+		expected LogicalType
+	}{
+		{"tinyint", NewSize(invalidInt(), validInt(3), invalidInt()), LogicalInteger},
+		{"tinyint", NewSize(invalidInt(), validInt(1), invalidInt()), LogicalBoolean},
+		{"tinyint", noSize, LogicalInteger},
+		{"_int4", noSize, LogicalArray},
+		{"int[]", noSize, LogicalArray},
+		{"geometry", noSize, LogicalUnknown},
+	}
+	for _, test := range tests {
+		if actual := m.LogicalType(newLogicalTypeTestColumn(test.dataType, test.size)); actual != test.expected {
+			t.Errorf("LogicalType() returns invalid value for %v. expected: %v, actual: %v", test.dataType, test.expected, actual)
+		}
+	}
+}
+
+func TestColumnLogicalType(t *testing.T) {
+	noSize := NewSize(invalidInt(), invalidInt(), invalidInt())
+	tests := []struct {
+		dataType string
+		expected LogicalType
+	}{
+		{"int4", LogicalInteger},
+		{"int8", LogicalBigInt},
+		{"numeric", LogicalDecimal},
+		{"varchar", LogicalString},
+		{"text", LogicalText},
+		{"bool", LogicalBoolean},
+		{"timestamp", LogicalTimestamp},
+		{"timestamptz", LogicalTimestampTZ},
+		{"date", LogicalDate},
+		{"uuid", LogicalUUID},
+		{"jsonb", LogicalJSON},
+		{"bytea", LogicalBinary},
+		{"_text", LogicalArray},
+		{"schm.domain1", LogicalUnknown},
+	}
+	for _, test := range tests {
+		if actual := newLogicalTypeTestColumn(test.dataType, noSize).LogicalType(); actual != test.expected {
+			t.Errorf("LogicalType() returns invalid value for %v. expected: %v, actual: %v", test.dataType, test.expected, actual)
+		}
+	}
+}
+
+func TestDefaultTypeMapping(t *testing.T) {
+	m := DefaultTypeMapping("postgres")
+	m["int4"] = LogicalBigInt
+	if expected, actual := LogicalInteger, DefaultTypeMapping("postgres")["int4"]; actual != expected {
+		t.Errorf("DefaultTypeMapping() should return copy. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := 0, len(DefaultTypeMapping("unknown")); actual != expected {
+		t.Errorf("DefaultTypeMapping() returns invalid mapping for unknown driver. expected size: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestNewClientMergesTypeMapping(t *testing.T) {
+	ds := createPostgresDataSource("postgres", "9.4")
+	ds.TypeMapping = TypeMapping{"int4": LogicalBigInt, "schm.domain1": LogicalInteger}
+	c := NewClient(ds)
+	ds.TypeMapping["text"] = LogicalString
+
+	tests := []struct {
+		dataType string
+		expected LogicalType
+	}{
+		{"int4", LogicalBigInt},
+		{"schm.domain1", LogicalInteger},
+		{"int8", LogicalBigInt},
+		{"text", LogicalText},
+	}
+	for _, test := range tests {
+		if actual := c.typeMapping.LogicalType(newLogicalTypeTestColumn(test.dataType, NewSize(invalidInt(), invalidInt(), invalidInt()))); actual != test.expected {
+			t.Errorf("LogicalType() returns invalid value for %v. expected: %v, actual: %v", test.dataType, test.expected, actual)
+		}
+	}
+}
+
+func TestColumnLogicalTypeResolvedOnLoad(t *testing.T) {
+	col := newLogicalTypeTestColumn("schm.domain1", NewSize(invalidInt(), invalidInt(), invalidInt()))
+	col.logicalType = LogicalInteger
+	if expected, actual := LogicalInteger, col.LogicalType(); actual != expected {
+		t.Errorf("LogicalType() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
@@ -0,0 +1,143 @@
+package dbmodel
+
+import (
+	"regexp"
+	"strings"
+)
+
+// MatchMode is how NameMatcher compares name with pattern.
+type MatchMode int
+
+const (
+	// MatchExact matches name that equals pattern.
+	MatchExact MatchMode = iota
+	// MatchPrefix matches name that starts with pattern.
+	MatchPrefix
+	// MatchSuffix matches name that ends with pattern.
+	MatchSuffix
+	// MatchContains matches name that contains pattern.
+	MatchContains
+	// MatchLike matches name with SQL LIKE pattern.
+	// '%' and '_' are wildcards, and they are escaped by '\'.
+	MatchLike
+	// MatchRegexp matches name with regular expression.
+	MatchRegexp
+)
+
+// NameMatcher is condition for matching database object names.
+// In MatchExact, MatchPrefix, MatchSuffix and MatchContains, pattern is compared literally.
+type NameMatcher struct {
+	Mode       MatchMode
+	Pattern    string
+	IgnoreCase bool
+}
+
+// ExactName returns NameMatcher that matches name equals given name.
+func ExactName(name string) NameMatcher {
+	return NameMatcher{Mode: MatchExact, Pattern: name}
+}
+
+// NamePrefix returns NameMatcher that matches name starts with given prefix.
+func NamePrefix(prefix string) NameMatcher {
+	return NameMatcher{Mode: MatchPrefix, Pattern: prefix}
+}
+
+// NameSuffix returns NameMatcher that matches name ends with given suffix.
+func NameSuffix(suffix string) NameMatcher {
+	return NameMatcher{Mode: MatchSuffix, Pattern: suffix}
+}
+
+// NameContains returns NameMatcher that matches name contains given string.
+func NameContains(s string) NameMatcher {
+	return NameMatcher{Mode: MatchContains, Pattern: s}
+}
+
+// NameLike returns NameMatcher that matches name with SQL LIKE pattern.
+func NameLike(pattern string) NameMatcher {
+	return NameMatcher{Mode: MatchLike, Pattern: pattern}
+}
+
+// NameRegexp returns NameMatcher that matches name with regular expression.
+func NameRegexp(pattern string) NameMatcher {
+	return NameMatcher{Mode: MatchRegexp, Pattern: pattern}
+}
+
+// IgnoringCase returns copy of NameMatcher that ignores case.
+func (m NameMatcher) IgnoringCase() NameMatcher {
+	m.IgnoreCase = true
+	return m
+}
+
+// Match returns true if given name matches.
+// Match is used for matching names in memory, and providers should match names in same manner.
+// Pattern is compiled on each call and invalid pattern matches nothing,
+// so that use Matcher for matching many names or detecting invalid pattern.
+func (m NameMatcher) Match(name string) bool {
+	match, err := m.Matcher()
+	return err == nil && match(name)
+}
+
+// Matcher returns function that returns true if given name matches.
+// Pattern is compiled only once, and error is returned if pattern is invalid regular expression.
+func (m NameMatcher) Matcher() (func(string) bool, error) {
+	pattern := m.Pattern
+	if m.IgnoreCase && m.Mode != MatchRegexp {
+		pattern = strings.ToLower(pattern)
+	}
+	normalize := func(name string) string {
+		if m.IgnoreCase && m.Mode != MatchRegexp {
+			return strings.ToLower(name)
+		}
+		return name
+	}
+
+	switch m.Mode {
+	case MatchExact:
+		return func(name string) bool { return normalize(name) == pattern }, nil
+	case MatchPrefix:
+		return func(name string) bool { return strings.HasPrefix(normalize(name), pattern) }, nil
+	case MatchSuffix:
+		return func(name string) bool { return strings.HasSuffix(normalize(name), pattern) }, nil
+	case MatchContains:
+		return func(name string) bool { return strings.Contains(normalize(name), pattern) }, nil
+	case MatchLike:
+		re, err := regexp.Compile(likeToRegexp(pattern))
+		if err != nil {
+			return nil, err
+		}
+		return func(name string) bool { return re.MatchString(normalize(name)) }, nil
+	case MatchRegexp:
+		if m.IgnoreCase {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString, nil
+	}
+	return func(string) bool { return false }, nil
+}
+
+func likeToRegexp(pattern string) string {
+	buf := make([]string, 0, len(pattern)+2)
+	buf = append(buf, "^")
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			buf = append(buf, regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			buf = append(buf, "(?s:.*)")
+		case r == '_':
+			buf = append(buf, "(?s:.)")
+		default:
+			buf = append(buf, regexp.QuoteMeta(string(r)))
+		}
+	}
+	buf = append(buf, "$")
+	return strings.Join(buf, "")
+}
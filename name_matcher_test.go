@@ -0,0 +1,56 @@
+package dbmodel
+
+import "testing"
+
+func TestNameMatcherMatch(t *testing.T) {
+	tests := []struct {
+		m        NameMatcher
+		name     string
+		expected bool
+	}{
+		{ExactName("users"), "users", true},
+		{ExactName("users"), "users_archive", false},
+		{ExactName("users"), "USERS", false},
+		{ExactName("users").IgnoringCase(), "USERS", true},
+		{NamePrefix("user_"), "user_roles", true},
+		{NamePrefix("user_"), "userxroles", false},
+		{NameSuffix("_log"), "access_log", true},
+		{NameSuffix("_log"), "access_logs", false},
+		{NameContains("ser"), "users", true},
+		{NameContains("ser"), "roles", false},
+		{NameLike("user%"), "users_archive", true},
+		{NameLike("user_"), "users", true},
+		{NameLike(`user\_`), "users", false},
+		{NameLike(`user\_`), "user_", true},
+		{NameLike("USER%").IgnoringCase(), "users", true},
+		{NameRegexp("^users?$"), "user", true},
+		{NameRegexp("^users?$"), "users_archive", false},
+		{NameRegexp("^USERS$").IgnoringCase(), "users", true},
+		{NameRegexp("("), "users", false},
+	}
+	for _, test := range tests {
+		if actual := test.m.Match(test.name); actual != test.expected {
+			t.Errorf("Match(%v) returns invalid value on %#v. expected: %v, actual: %v", test.name, test.m, test.expected, actual)
+		}
+	}
+}
+
+func TestNameMatcherMatcher(t *testing.T) {
+	match, err := NameLike("USER%").IgnoringCase().Matcher()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !match("users") || match("roles") {
+		t.Error("Matcher returns function that matches invalid names.")
+	}
+	if _, err := NameRegexp("(").Matcher(); err == nil {
+		t.Error("Matcher should raise error when pattern is invalid regular expression.")
+	}
+}
+
+func TestExactNameIsZeroMode(t *testing.T) {
+	var m NameMatcher
+	if m.Mode != MatchExact {
+		t.Errorf("Zero value of NameMatcher should be exact match. actual: %v", m.Mode)
+	}
+}
@@ -1,15 +1,148 @@
 package dbmodel
 
+import "strings"
+
 // Option is table loding option for Table and AllTables function.
 type Option struct {
 	Indices        bool
 	ForeignKeys    bool
 	ReferencedKeys bool
 	Constraints    bool
+	// Statistics loads TableStats and IndexStats.
+	Statistics bool
+	// ColumnStatistics loads ColumnStats.
+	ColumnStatistics bool
+	// Privileges loads table owner and privileges granted on tables and columns.
+	Privileges bool
+	// Policies loads row level security settings and policies.
+	Policies bool
+	// Extensions loads extension name that table belongs to.
+	Extensions bool
+	// Filter restricts tables loaded by AllTables.
+	Filter TableFilter
+}
+
+// TableFilter restricts tables loaded by AllTables.
+// Conditions are pushed down into provider's SQL.
+type TableFilter struct {
+	// Names are table names to load. If empty, any name is loaded.
+	Names []string
+	// Includes are table name patterns to load. Table that matches any pattern is loaded.
+	// If empty, any name is loaded.
+	Includes []string
+	// Excludes are table name patterns not to load.
+	Excludes []string
+	// Regexp treats Includes and Excludes as regular expressions.
+	// If false, they are glob patterns. ('*' matches any characters, '?' matches a character)
+	Regexp bool
+	// Kinds are table kinds to load. If empty, only KindTable is loaded.
+	Kinds []TableKind
+	// ExcludeExtensionMembers excludes tables that belong to extension.
+	ExcludeExtensionMembers bool
+}
+
+// IsEmpty returns true if filter has no condition.
+func (f TableFilter) IsEmpty() bool {
+	return len(f.Names) == 0 && len(f.Includes) == 0 && len(f.Excludes) == 0 && len(f.Kinds) == 0 && !f.ExcludeExtensionMembers
+}
+
+// Match returns true if table satisfies all conditions of filter.
+// This is used for tables that are not loaded from database, such as tables in snapshot.
+// Patterns are compiled on each call and invalid pattern matches nothing,
+// so that use Matcher for matching many tables or detecting invalid pattern.
+func (f TableFilter) Match(tbl *Table) bool {
+	match, err := f.Matcher()
+	return err == nil && match(tbl)
+}
+
+// Matcher returns function that returns true if table satisfies all conditions of filter.
+// Patterns are compiled only once, and error is returned if a pattern is invalid.
+func (f TableFilter) Matcher() (func(*Table) bool, error) {
+	incs, err := filterMatchers(f.Includes, f.Regexp)
+	if err != nil {
+		return nil, err
+	}
+	excs, err := filterMatchers(f.Excludes, f.Regexp)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(tbl *Table) bool {
+		if len(f.Kinds) == 0 {
+			if tbl.Kind() != KindTable {
+				return false
+			}
+		} else if !containsKind(f.Kinds, tbl.Kind()) {
+			return false
+		}
+		if len(f.Names) > 0 && !containsString(f.Names, tbl.Name()) {
+			return false
+		}
+		if len(incs) > 0 {
+			matched := false
+			for _, match := range incs {
+				if match(tbl.Name()) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return false
+			}
+		}
+		for _, match := range excs {
+			if match(tbl.Name()) {
+				return false
+			}
+		}
+		return !f.ExcludeExtensionMembers || tbl.Extension() == ""
+	}, nil
+}
+
+// filterMatchers returns compiled matchers of TableFilter's patterns.
+func filterMatchers(patterns []string, regexp bool) ([]func(string) bool, error) {
+	matchers := make([]func(string) bool, 0, len(patterns))
+	for _, pattern := range patterns {
+		match, err := filterMatcher(pattern, regexp).Matcher()
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, match)
+	}
+	return matchers, nil
+}
+
+// filterMatcher returns NameMatcher for TableFilter's pattern.
+// Glob pattern is converted to LIKE pattern. ('*' matches any characters, '?' matches a character)
+func filterMatcher(pattern string, regexp bool) NameMatcher {
+	if regexp {
+		return NameRegexp(pattern)
+	}
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`, `*`, `%`, `?`, `_`)
+	return NameLike(r.Replace(pattern))
+}
+
+func containsKind(kinds []TableKind, kind TableKind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
 }
 
 var (
 	// RequireAll is loading option for loading all meta data.
+	// Options that require extra queries, such as Statistics, are not contained. Set them explicitly to load.
 	RequireAll = Option{
 		Indices:        true,
 		ForeignKeys:    true,
@@ -18,9 +151,14 @@ var (
 	}
 	// RequireNone is loading option for loading only columns.
 	RequireNone = Option{
-		Indices:        false,
-		ForeignKeys:    false,
-		ReferencedKeys: false,
-		Constraints:    false,
+		Indices:          false,
+		ForeignKeys:      false,
+		ReferencedKeys:   false,
+		Constraints:      false,
+		Statistics:       false,
+		ColumnStatistics: false,
+		Privileges:       false,
+		Policies:         false,
+		Extensions:       false,
 	}
 )
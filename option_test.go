@@ -7,6 +7,9 @@ import (
 
 func TestTableWithOptionRequireNone(t *testing.T) {
 	tbl := loadPostgresTableWithOpt(RequireNone)
+	if tbl.Stats() != nil {
+		t.Error("Statistics options is false, but Stats loaded.")
+	}
 	if len(tbl.Indices()) > 0 {
 		t.Error("Indices options is false, but Indices loaded.")
 	}
@@ -243,6 +246,243 @@ func TestAllTablesWithOptionConstraints(t *testing.T) {
 	}
 }
 
+func TestTableWithOptionStatistics(t *testing.T) {
+	tbl := loadPostgresTableWithOpt(Option{Indices: true, Statistics: true})
+	if tbl.Stats() == nil {
+		t.Error("Statistics options is true, but Stats not loaded.")
+		return
+	}
+	if tbl.Stats().TotalBytes() < tbl.Stats().IndexBytes() {
+		t.Errorf("TotalBytes should contain IndexBytes. (%#v)", tbl.Stats())
+	}
+	for _, idx := range tbl.Indices() {
+		if idx.Stats() == nil {
+			t.Errorf("Statistics options is true, but Stats of '%v' not loaded.", idx.Name())
+		}
+	}
+}
+
+func TestAllTablesWithOptionStatistics(t *testing.T) {
+	tbls := loadPostgresAllTablesWithOpt(Option{Indices: true, Statistics: true})
+	for _, tbl := range tbls {
+		if tbl.Stats() == nil {
+			t.Errorf("Statistics options is true, but Stats of '%v' not loaded.", tbl.Name())
+		}
+		for _, idx := range tbl.Indices() {
+			if idx.Stats() == nil || idx.Stats().SizeBytes() == 0 {
+				t.Errorf("Statistics options is true, but Stats of '%v' not loaded.", idx.Name())
+			}
+		}
+	}
+}
+
+func TestTableWithOptionColumnStatistics(t *testing.T) {
+	c := createPostgresClient()
+	defer c.Disconnect()
+	c.Connect()
+	if !createPostgresStatsResources(t, c) {
+		return
+	}
+
+	tbl, err := c.Table("stats", "samples", Option{ColumnStatistics: true})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	col, _ := tbl.FindColumn("category")
+	if col.Stats() == nil {
+		t.Error("ColumnStatistics options is true, but Stats not loaded.")
+		return
+	}
+	if actual, expected := len(col.Stats().MostCommonValues()), 3; actual != expected {
+		t.Errorf("MostCommonValues count is invalid. expected: %v, actual: %v", expected, actual)
+	}
+	if actual, expected := len(col.Stats().MostCommonFrequencies()), 3; actual != expected {
+		t.Errorf("MostCommonFrequencies count is invalid. expected: %v, actual: %v", expected, actual)
+	}
+	if actual, expected := col.Stats().NullFraction(), float64(0); actual != expected {
+		t.Errorf("NullFraction is invalid. expected: %v, actual: %v", expected, actual)
+	}
+	if actual, expected := col.Stats().Distinct(), float64(3); actual != expected {
+		t.Errorf("Distinct is invalid. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func createPostgresStatsResources(t *testing.T, c *Client) bool {
+	bytes, err := readSQLFile("create_postgres_stats_resources")
+	if err != nil {
+		t.Error(err)
+		return false
+	}
+	if _, err = c.db.Exec("DROP SCHEMA IF EXISTS stats CASCADE"); err != nil {
+		t.Error(err)
+		return false
+	}
+	if _, err = c.db.Exec(string(bytes)); err != nil {
+		t.Error(err)
+		return false
+	}
+	if _, err = c.db.Exec("ANALYZE stats.samples"); err != nil {
+		t.Error(err)
+		return false
+	}
+	return true
+}
+
+func TestTableWithOptionPrivileges(t *testing.T) {
+	tbl := loadPostgresTableWithOpt(Option{Privileges: true})
+	if actual, expected := tbl.Owner(), "postgres"; actual != expected {
+		t.Errorf("Owner is invalid. expected: %v, actual: %v", expected, actual)
+	}
+	if len(tbl.Grants()) == 0 {
+		t.Error("Privileges options is true, but Grants not loaded.")
+	}
+	col, _ := tbl.FindColumn("idx_key")
+	if actual, expected := len(col.Grants()), 2; actual != expected {
+		t.Errorf("Column grants count is invalid. expected: %v, actual: %v", expected, actual)
+		return
+	}
+	if actual, expected := col.Grants()[0].Grantee(), "PUBLIC"; actual != expected {
+		t.Errorf("Grantee is invalid. expected: %v, actual: %v", expected, actual)
+	}
+	if actual, expected := col.Grants()[0].Privilege(), "SELECT"; actual != expected {
+		t.Errorf("Privilege is invalid. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestAllTablesWithOptionPrivileges(t *testing.T) {
+	tbls := loadPostgresAllTablesWithOpt(Option{Privileges: true})
+	for _, tbl := range tbls {
+		if tbl.Owner() == "" {
+			t.Errorf("Privileges options is true, but Owner of '%v' not loaded.", tbl.Name())
+		}
+	}
+	found := false
+	for _, g := range tbls[0].Grants() {
+		if g.Grantee() == "PUBLIC" && g.Privilege() == "SELECT" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("SELECT granted to PUBLIC is not loaded. (%v)", tbls[0].Grants())
+	}
+	if actual, expected := len(tbls[2].Grants()), 0; actual != expected {
+		t.Errorf("Grants of table that all privileges are revoked from is invalid. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestAllTablesWithFilterNames(t *testing.T) {
+	tbls := loadPostgresAllTablesWithOpt(Option{Filter: TableFilter{Names: []string{"tbl1", "tbl3"}}})
+	assertTableNames(t, tbls, "tbl1", "tbl3")
+}
+
+func TestAllTablesWithFilterGlob(t *testing.T) {
+	tbls := loadPostgresAllTablesWithOpt(Option{Filter: TableFilter{Includes: []string{"tbl*"}, Excludes: []string{"*2"}}})
+	assertTableNames(t, tbls, "tbl1", "tbl3")
+}
+
+func TestAllTablesWithFilterGlobEscapesWildcard(t *testing.T) {
+	tbls := loadPostgresAllTablesWithOpt(Option{Filter: TableFilter{Includes: []string{"tbl_"}}})
+	assertTableNames(t, tbls)
+}
+
+func TestAllTablesWithFilterRegexp(t *testing.T) {
+	tbls := loadPostgresAllTablesWithOpt(Option{Filter: TableFilter{Includes: []string{"^tbl[12]$"}, Regexp: true}})
+	assertTableNames(t, tbls, "tbl1", "tbl2")
+}
+
+func TestAllTablesWithFilterKinds(t *testing.T) {
+	tbls := loadPostgresAllTablesWithOpt(Option{Filter: TableFilter{Kinds: []TableKind{KindView}}})
+	assertTableNames(t, tbls, "view1")
+	if len(tbls) == 1 && tbls[0].Kind() != KindView {
+		t.Errorf("Table kind is invalid. expected: %v, actual: %v", KindView, tbls[0].Kind())
+	}
+}
+
+func TestAllTablesWithFilterLoadsMetadataOfFilteredTables(t *testing.T) {
+	tbls := loadPostgresAllTablesWithOpt(Option{Indices: true, ForeignKeys: true, Constraints: true, Filter: TableFilter{Names: []string{"tbl3"}}})
+	assertTableNames(t, tbls, "tbl3")
+	if len(tbls) != 1 {
+		return
+	}
+	if _, ok := tbls[0].FindIndex("tbl3_idx1"); !ok {
+		t.Error("Indices of filtered table should be loaded.")
+	}
+	if actual, expected := len(tbls[0].ForeignKeys()), 2; actual != expected {
+		t.Errorf("Foreign key count is invalid. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestAllTablesWithFilterExcludeExtensionMembers(t *testing.T) {
+	tbls := loadPostgresAllTablesWithOpt(Option{Filter: TableFilter{ExcludeExtensionMembers: true}})
+	assertTableNames(t, tbls, "tbl1", "tbl2", "tbl3")
+}
+
+func TestAllTablesWithOptionExtensions(t *testing.T) {
+	tbls := loadPostgresAllTablesWithOpt(Option{Extensions: true})
+	for _, tbl := range tbls {
+		if tbl.Extension() != "" {
+			t.Errorf("%v should not belong to extension. (%v)", tbl.Name(), tbl.Extension())
+		}
+	}
+}
+
+func TestTableFilterMatch(t *testing.T) {
+	tbl := NewTable("foo", "user_posts", "")
+	view := NewTable("foo", "user_view", "")
+	view.SetKind(KindView)
+	ext := NewTable("foo", "user_ext", "")
+	ext.SetExtension("postgis")
+
+	cases := []struct {
+		f        TableFilter
+		tbl      *Table
+		expected bool
+	}{
+		{TableFilter{}, &tbl, true},
+		{TableFilter{}, &view, false},
+		{TableFilter{Kinds: []TableKind{KindView}}, &view, true},
+		{TableFilter{Names: []string{"user_posts"}}, &tbl, true},
+		{TableFilter{Names: []string{"user"}}, &tbl, false},
+		{TableFilter{Includes: []string{"user_*"}}, &tbl, true},
+		{TableFilter{Includes: []string{"user?posts"}}, &tbl, true},
+		{TableFilter{Includes: []string{"*_view"}}, &tbl, false},
+		{TableFilter{Excludes: []string{"*posts"}}, &tbl, false},
+		{TableFilter{Includes: []string{"^user_p"}, Regexp: true}, &tbl, true},
+		{TableFilter{ExcludeExtensionMembers: true}, &ext, false},
+		{TableFilter{ExcludeExtensionMembers: true}, &tbl, true},
+	}
+	for _, c := range cases {
+		if actual := c.f.Match(c.tbl); actual != c.expected {
+			t.Errorf("Match() returns invalid value for %v with %#v. expected: %v, actual: %v", c.tbl.Name(), c.f, c.expected, actual)
+		}
+	}
+}
+
+func TestTableFilterMatcherWithInvalidPattern(t *testing.T) {
+	if _, err := (TableFilter{Includes: []string{"("}, Regexp: true}).Matcher(); err == nil {
+		t.Error("Matcher should raise error when include pattern is invalid.")
+	}
+	if _, err := (TableFilter{Excludes: []string{"["}, Regexp: true}).Matcher(); err == nil {
+		t.Error("Matcher should raise error when exclude pattern is invalid.")
+	}
+	if _, err := (TableFilter{Includes: []string{"("}}).Matcher(); err != nil {
+		t.Errorf("Matcher should not raise error for glob pattern, but got %v", err)
+	}
+}
+
+func assertTableNames(t *testing.T, tbls []*Table, names ...string) {
+	if len(tbls) != len(names) {
+		t.Errorf("Table count is invalid. expected: %v, actual: %v", len(names), len(tbls))
+		return
+	}
+	for i, expected := range names {
+		if actual := tbls[i].Name(); actual != expected {
+			t.Errorf("Table name is invalid. expected: %v, actual: %v", expected, actual)
+		}
+	}
+}
+
 func loadPostgresAllTablesWithOpt(opt Option) []*Table {
 	c := createPostgresClient()
 	defer c.Disconnect()
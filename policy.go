@@ -0,0 +1,55 @@
+package dbmodel
+
+// Policy is row level security policy's meta data.
+type Policy struct {
+	name       string
+	command    string
+	permissive bool
+	roles      []string
+	using      string
+	withCheck  string
+}
+
+// Name returns policy name.
+func (p Policy) Name() string {
+	return p.name
+}
+
+// Command returns command that policy applies to.
+// Command returns 'ALL', 'SELECT', 'INSERT', 'UPDATE' or 'DELETE'.
+func (p Policy) Command() string {
+	return p.command
+}
+
+// IsPermissive returns true if policy is permissive, false if restrictive.
+func (p Policy) IsPermissive() bool {
+	return p.permissive
+}
+
+// Roles returns role names that policy applies to.
+// If policy applies to all roles, Roles contains 'PUBLIC'.
+func (p Policy) Roles() []string {
+	return p.roles
+}
+
+// Using returns USING expression.
+func (p Policy) Using() string {
+	return p.using
+}
+
+// WithCheck returns WITH CHECK expression.
+func (p Policy) WithCheck() string {
+	return p.withCheck
+}
+
+// NewPolicy returns new Policy initialized with arguments.
+func NewPolicy(name string, command string, permissive bool, roles []string, using string, withCheck string) Policy {
+	return Policy{
+		name:       name,
+		command:    command,
+		permissive: permissive,
+		roles:      roles,
+		using:      using,
+		withCheck:  withCheck,
+	}
+}
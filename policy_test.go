@@ -0,0 +1,28 @@
+package dbmodel
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewPolicy(t *testing.T) {
+	p := NewPolicy("tenant_isolation", "SELECT", true, []string{"app"}, "(tenant_id = 1)", "")
+	if expected, actual := "tenant_isolation", p.Name(); actual != expected {
+		t.Errorf("Name() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := "SELECT", p.Command(); actual != expected {
+		t.Errorf("Command() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if !p.IsPermissive() {
+		t.Error("Given true, IsPermissive should return true.")
+	}
+	if expected, actual := []string{"app"}, p.Roles(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Roles() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := "(tenant_id = 1)", p.Using(); actual != expected {
+		t.Errorf("Using() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := "", p.WithCheck(); actual != expected {
+		t.Errorf("WithCheck() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
@@ -34,6 +34,10 @@ ORDER BY t.tablename`
 }
 
 func (p postgres) TableNamesSQL() string {
+	return p.MatchedTableNamesSQL(NameLike(""))
+}
+
+func (p postgres) MatchedTableNamesSQL(m NameMatcher) string {
 	return `
 SELECT t.schemaname AS schema
      , t.tablename AS table_name
@@ -45,71 +49,77 @@ LEFT OUTER JOIN pg_catalog.pg_description d
 ON  d.objoid = c1.oid
 AND d.objsubid = 0
 WHERE t.schemaname = $1
-AND   t.tablename LIKE '%' || $2 || '%'
+AND   ` + p.nameCondition(`t.tablename`, m, `$2`) + `
 ORDER BY t.tablename`
 }
 
 func (p postgres) TableSQL() string {
-	return `
-SELECT ns.nspname AS schema
-     , cls.relname AS table_name
-     , td.description AS table_comment
-     , att.attname AS column_name
-     , cd.description AS column_comment
-     , att.data_type
-     , information_schema._pg_char_max_length(att.typid, att.typmod) AS length
-     , COALESCE(
-           information_schema._pg_numeric_precision(att.typid, att.typmod)
-         , information_schema._pg_datetime_precision(att.typid, att.typmod)) AS precision
-     , information_schema._pg_numeric_scale(att.typid, att.typmod) AS scale
-     , CASE WHEN att.attnotnull THEN 'NO' ELSE 'YES' END AS nullable
-     , def.adsrc AS defaul_value
-     , pk.pos AS primary_key_position
-FROM pg_catalog.pg_class cls
-INNER JOIN pg_catalog.pg_namespace ns
-ON  cls.relnamespace = ns.oid
-LEFT OUTER JOIN pg_catalog.pg_description td
-ON  cls.oid = td.objoid
-AND td.objsubid = 0
-INNER JOIN (
-    SELECT a.attrelid
-         , a.attname
-         , a.attnum
-         , a.attnotnull
-         , CASE WHEN t.typtype = 'd' THEN tn.nspname || '.' || t.typname ELSE t.typname END AS data_type
-         , information_schema._pg_truetypid(a.*, t.*) AS typid
-         , information_schema._pg_truetypmod(a.*, t.*) AS typmod
-    FROM pg_catalog.pg_attribute a
-    INNER JOIN pg_catalog.pg_type t
-    ON t.oid = a.atttypid
-    INNER JOIN pg_catalog.pg_namespace tn
-    ON t.typnamespace = tn.oid
-    WHERE a.attnum > 0
-) att
-ON  att.attrelid = cls.oid
-LEFT OUTER JOIN pg_catalog.pg_attrdef def
-ON  def.adrelid = att.attrelid
-AND def.adnum = att.attnum
-LEFT OUTER JOIN pg_catalog.pg_description cd
-ON  cls.oid = cd.objoid
-AND att.attnum = cd.objsubid
-LEFT OUTER JOIN (
-    SELECT conrelid
-         , conname
-         , conkey AS colnums
-         , generate_series(1, length(array_to_string(conkey, ' ')) - length(array_to_string(conkey, '')) + 1) AS pos
-    FROM pg_catalog.pg_constraint
-    WHERE contype = 'p'
-) pk
-ON  pk.conrelid = cls.oid
-AND att.attnum = pk.colnums[pk.pos]
-WHERE cls.relkind = 'r'
-AND   ns.nspname = $1
-AND   cls.relname = $2
-ORDER BY cls.relname, att.attnum`
+	return p.tablesSQL(`cls.relkind = 'r'`, `ns.nspname = $1`, `cls.relname = $2`)
 }
 
 func (p postgres) AllTablesSQL() string {
+	return p.tablesSQL(`cls.relkind = 'r'`, `ns.nspname = $1`)
+}
+
+func (p postgres) FilteredTablesSQL(f TableFilter) (string, []interface{}) {
+	conds, args := p.filterConditions(f)
+	return p.tablesSQL(conds...), args
+}
+
+// filterConditions returns conditions that table "cls" in schema "ns" matches given filter, and their parameters.
+// Parameters are numbered after schema.
+func (p postgres) filterConditions(f TableFilter) ([]string, []interface{}) {
+	conds := []string{p.relkindCondition(f.Kinds), `ns.nspname = $1`}
+	args := make([]interface{}, 0, len(f.Names)+len(f.Includes)+len(f.Excludes))
+	param := func(v string) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args)+1)
+	}
+
+	if len(f.Names) > 0 {
+		params := make([]string, len(f.Names))
+		for i, name := range f.Names {
+			params[i] = param(name)
+		}
+		conds = append(conds, `cls.relname IN (`+strings.Join(params, ", ")+`)`)
+	}
+	if len(f.Includes) > 0 {
+		incs := make([]string, len(f.Includes))
+		for i, pattern := range f.Includes {
+			m := filterMatcher(pattern, f.Regexp)
+			incs[i] = p.nameCondition(`cls.relname`, m, param(m.Pattern))
+		}
+		conds = append(conds, `(`+strings.Join(incs, " OR ")+`)`)
+	}
+	for _, pattern := range f.Excludes {
+		m := filterMatcher(pattern, f.Regexp)
+		conds = append(conds, `NOT `+p.nameCondition(`cls.relname`, m, param(m.Pattern)))
+	}
+	if f.ExcludeExtensionMembers {
+		conds = append(conds, `NOT EXISTS (
+    SELECT 1
+    FROM pg_catalog.pg_depend dep
+    WHERE dep.classid = 'pg_catalog.pg_class'::regclass
+    AND   dep.objid = cls.oid
+    AND   dep.deptype = 'e'
+)`)
+	}
+	return conds, args
+}
+
+// filteredTablesCondition returns condition that col is OID of table that matches given filter, and its parameters.
+func (p postgres) filteredTablesCondition(col string, f TableFilter) (string, []interface{}) {
+	conds, args := p.filterConditions(f)
+	return col + ` IN (
+    SELECT cls.oid
+    FROM pg_catalog.pg_class cls
+    INNER JOIN pg_catalog.pg_namespace ns
+    ON  cls.relnamespace = ns.oid
+    WHERE ` + strings.Join(conds, "\n    AND   ") + `
+)`, args
+}
+
+func (p postgres) tablesSQL(conds ...string) string {
 	return `
 SELECT ns.nspname AS schema
      , cls.relname AS table_name
@@ -125,6 +135,14 @@ SELECT ns.nspname AS schema
      , CASE WHEN att.attnotnull THEN 'NO' ELSE 'YES' END AS nullable
      , def.adsrc AS defaul_value
      , pk.pos AS primary_key_position
+     , CASE cls.relkind
+           WHEN 'v' THEN 'VIEW'
+           WHEN 'm' THEN 'MATERIALIZED VIEW'
+           WHEN 'f' THEN 'FOREIGN TABLE'
+           WHEN 'p' THEN 'PARTITIONED TABLE'
+           ELSE 'TABLE'
+       END AS table_kind
+     , CASE WHEN att.typtype = 'e' THEN 'YES' ELSE 'NO' END AS enum
 FROM pg_catalog.pg_class cls
 INNER JOIN pg_catalog.pg_namespace ns
 ON  cls.relnamespace = ns.oid
@@ -137,6 +155,7 @@ INNER JOIN (
          , a.attnum
          , a.attnotnull
          , CASE WHEN t.typtype = 'd' THEN tn.nspname || '.' || t.typname ELSE t.typname END AS data_type
+         , t.typtype
          , information_schema._pg_truetypid(a.*, t.*) AS typid
          , information_schema._pg_truetypmod(a.*, t.*) AS typmod
     FROM pg_catalog.pg_attribute a
@@ -163,41 +182,24 @@ LEFT OUTER JOIN (
 ) pk
 ON  pk.conrelid = cls.oid
 AND att.attnum = pk.colnums[pk.pos]
-WHERE cls.relkind = 'r'
-AND   ns.nspname = $1
+WHERE ` + strings.Join(conds, "\nAND   ") + `
 ORDER BY cls.relname, att.attnum`
 }
 
 func (p postgres) IndicesSQL() string {
-	return `
-SELECT ns.nspname AS schema
-     , tcls.relname AS table_name
-     , icls.relname AS index_name
-     , CASE WHEN idx.uniq THEN 'YES' ELSE 'NO' END AS uniq
-     , att.attname AS column_name
-FROM (
-    SELECT indexrelid AS index_oid
-         , indrelid AS table_oid
-         , indisunique AS uniq
-         , string_to_array(indkey::text, ' ')::int[] AS colnums
-         , generate_series(1, length(indkey::text) - length(replace(indkey::text, ' ', '')) + 1) AS pos
-    FROM pg_catalog.pg_index
-) idx
-INNER JOIN pg_catalog.pg_class tcls
-ON tcls.oid = idx.table_oid
-INNER JOIN pg_catalog.pg_namespace ns
-ON tcls.relnamespace = ns.oid
-INNER JOIN pg_catalog.pg_class icls
-ON icls.oid = idx.index_oid
-JOIN pg_catalog.pg_attribute att
-ON  att.attrelid = tcls.oid
-AND att.attnum = idx.colnums[idx.pos]
-WHERE ns.nspname = $1
-AND   tcls.relname = $2
-ORDER BY tcls.relname, icls.relname, idx.pos`
+	return p.indicesSQL(`ns.nspname = $1`, `tcls.relname = $2`)
 }
 
 func (p postgres) AllIndicesSQL() string {
+	return p.indicesSQL(`ns.nspname = $1`)
+}
+
+func (p postgres) FilteredIndicesSQL(f TableFilter) (string, []interface{}) {
+	cond, args := p.filteredTablesCondition(`tcls.oid`, f)
+	return p.indicesSQL(`ns.nspname = $1`, cond), args
+}
+
+func (p postgres) indicesSQL(conds ...string) string {
 	return `
 SELECT ns.nspname AS schema
      , tcls.relname AS table_name
@@ -221,57 +223,24 @@ ON icls.oid = idx.index_oid
 JOIN pg_catalog.pg_attribute att
 ON  att.attrelid = tcls.oid
 AND att.attnum = idx.colnums[idx.pos]
-WHERE ns.nspname = $1
+WHERE ` + strings.Join(conds, "\nAND   ") + `
 ORDER BY tcls.relname, icls.relname, idx.pos`
 }
 
 func (p postgres) ForeignKeysSQL() string {
-	return `
-SELECT cns.conname AS foreign_key_name
-     , ns.nspname AS schema
-     , cls.relname AS table_name
-     , att.attname AS column_name
-     , fns.nspname AS foreign_schema
-     , fcls.relname AS foreign_table_name
-     , fatt.attname AS foreign_column_name
-FROM (
-    SELECT conname
-         , conrelid AS relid
-         , conkey AS key
-         , generate_series(1, length(array_to_string(conkey, ' ')) - length(array_to_string(conkey, '')) + 1) AS pos
-    FROM pg_catalog.pg_constraint
-    WHERE contype = 'f'
-) AS cns
-INNER JOIN pg_catalog.pg_class cls
-ON cls.oid = cns.relid
-INNER JOIN pg_catalog.pg_namespace ns
-ON cls.relnamespace = ns.oid
-INNER JOIN pg_catalog.pg_attribute att
-ON  att.attrelid = cls.oid
-AND att.attnum = cns.key[cns.pos]
-JOIN (
-    SELECT conname
-         , confrelid AS relid
-         , confkey AS key
-         , generate_series(1, length(array_to_string(confkey, ' ')) - length(array_to_string(confkey, '')) + 1) AS pos
-    FROM pg_catalog.pg_constraint
-    WHERE contype = 'f'
-) AS fcns
-ON fcns.conname = cns.conname
-AND fcns.pos = cns.pos
-INNER JOIN pg_catalog.pg_class fcls
-ON fcls.oid = fcns.relid
-INNER JOIN pg_catalog.pg_namespace fns
-ON fcls.relnamespace = fns.oid
-INNER JOIN pg_catalog.pg_attribute fatt
-ON  fatt.attrelid = fcls.oid
-AND fatt.attnum = fcns.key[fcns.pos]
-WHERE ns.nspname = $1
-AND   cls.relname = $2
-ORDER BY cls.relname, cns.conname, cns.pos`
+	return p.foreignKeysSQL(`ns.nspname = $1`, `cls.relname = $2`)
 }
 
 func (p postgres) AllForeignKeysSQL() string {
+	return p.foreignKeysSQL(`ns.nspname = $1`)
+}
+
+func (p postgres) FilteredForeignKeysSQL(f TableFilter) (string, []interface{}) {
+	cond, args := p.filteredTablesCondition(`cls.oid`, f)
+	return p.foreignKeysSQL(`ns.nspname = $1`, cond), args
+}
+
+func (p postgres) foreignKeysSQL(conds ...string) string {
 	return `
 SELECT cns.conname AS foreign_key_name
      , ns.nspname AS schema
@@ -312,57 +281,24 @@ ON fcls.relnamespace = fns.oid
 INNER JOIN pg_catalog.pg_attribute fatt
 ON  fatt.attrelid = fcls.oid
 AND fatt.attnum = fcns.key[fcns.pos]
-WHERE ns.nspname = $1
+WHERE ` + strings.Join(conds, "\nAND   ") + `
 ORDER BY cls.relname, cns.conname, cns.pos`
 }
 
 func (p postgres) ReferencedKeysSQL() string {
-	return `
-SELECT cns.conname AS referenced_key_name
-     , ns.nspname AS schema
-     , cls.relname AS table_name
-     , att.attname AS column_name
-     , fns.nspname AS foreign_schema
-     , fcls.relname AS foreign_table_name
-     , fatt.attname AS foreign_column_name
-FROM (
-    SELECT conname
-         , conrelid AS relid
-         , conkey AS colnums
-         , generate_series(1, length(array_to_string(conkey, ' ')) - length(array_to_string(conkey, '')) + 1) AS pos
-    FROM pg_catalog.pg_constraint
-    WHERE contype = 'f'
-) AS cns
-INNER JOIN pg_catalog.pg_class cls
-ON cls.oid = cns.relid
-INNER JOIN pg_catalog.pg_namespace ns
-ON cls.relnamespace = ns.oid
-INNER JOIN pg_catalog.pg_attribute att
-ON  att.attrelid = cls.oid
-AND att.attnum = cns.colnums[cns.pos]
-JOIN (
-    SELECT conname
-         , confrelid AS relid
-         , confkey AS colnums
-         , generate_series(1, length(array_to_string(confkey, ' ')) - length(array_to_string(confkey, '')) + 1) AS pos
-    FROM pg_catalog.pg_constraint
-    WHERE contype = 'f'
-) AS fcns
-ON fcns.conname = cns.conname
-AND fcns.pos = cns.pos
-INNER JOIN pg_catalog.pg_class fcls
-ON fcls.oid = fcns.relid
-INNER JOIN pg_catalog.pg_namespace fns
-ON fcls.relnamespace = fns.oid
-INNER JOIN pg_catalog.pg_attribute fatt
-ON  fatt.attrelid = fcls.oid
-AND fatt.attnum = fcns.colnums[fcns.pos]
-WHERE fns.nspname = $1
-AND   fcls.relname = $2
-ORDER BY fcls.relname, fcns.conname, fcns.pos`
+	return p.referencedKeysSQL(`fns.nspname = $1`, `fcls.relname = $2`)
 }
 
 func (p postgres) AllReferencedKeysSQL() string {
+	return p.referencedKeysSQL(`fns.nspname = $1`)
+}
+
+func (p postgres) FilteredReferencedKeysSQL(f TableFilter) (string, []interface{}) {
+	cond, args := p.filteredTablesCondition(`fcls.oid`, f)
+	return p.referencedKeysSQL(`fns.nspname = $1`, cond), args
+}
+
+func (p postgres) referencedKeysSQL(conds ...string) string {
 	return `
 SELECT cns.conname AS referenced_key_name
      , ns.nspname AS schema
@@ -403,11 +339,25 @@ ON fcls.relnamespace = fns.oid
 INNER JOIN pg_catalog.pg_attribute fatt
 ON  fatt.attrelid = fcls.oid
 AND fatt.attnum = fcns.colnums[fcns.pos]
-WHERE fns.nspname = $1
+WHERE ` + strings.Join(conds, "\nAND   ") + `
 ORDER BY fcls.relname, fcns.conname, fcns.pos`
 }
 
 func (p postgres) ConstraintsSQL() string {
+	return p.constraintsSQL(`ns.nspname = $1`, `cls.relname = $2`)
+}
+
+func (p postgres) AllConstraintsSQL() string {
+	return p.constraintsSQL(`ns.nspname = $1`)
+}
+
+func (p postgres) FilteredConstraintsSQL(f TableFilter) (string, []interface{}) {
+	cond, args := p.filteredTablesCondition(`cls.oid`, f)
+	return p.constraintsSQL(`ns.nspname = $1`, cond), args
+}
+
+func (p postgres) constraintsSQL(conds ...string) string {
+	where := strings.Join(conds, "\nAND   ")
 	sql := `
 SELECT ns.nspname AS schema
      , cls.relname AS table_name
@@ -420,8 +370,7 @@ ON cls.oid = cns.conrelid
 JOIN pg_catalog.pg_namespace ns
 ON ns.oid = cls.relnamespace
 WHERE cns.contype = 'c'
-AND   ns.nspname = $1
-AND   cls.relname = $2
+AND   ` + where + `
 UNION
 SELECT ns.nspname AS schema
      , cls.relname AS table_name
@@ -443,14 +392,10 @@ ON ns.oid = cls.relnamespace
 JOIN pg_catalog.pg_attribute att
 ON att.attrelid = cls.oid
 AND att.attnum = cns.colnums[cns.pos]
-WHERE ns.nspname = $1
-AND   cls.relname = $2
+WHERE ` + where + `
 GROUP BY 1, 2, 3`
-	if p.ds.Version != "" {
-		v1, err1 := version.NewVersion("9.0")
-		v2, err2 := version.NewVersion(p.ds.Version)
-		if err1 == nil && err2 == nil && v2.Compare(v1) >= 0 {
-			sql += `
+	if p.versionAtLeast("9.0") {
+		sql += `
 UNION
 SELECT ns.nspname AS schema
      , cls.relname AS table_name
@@ -475,87 +420,464 @@ ON att.attrelid = cls.oid
 AND att.attnum = cns.colnums[cns.pos]
 JOIN pg_catalog.pg_operator op
 ON op.oid = cns.opids[cns.pos]
-WHERE ns.nspname = $1
-AND   cls.relname = $2
+WHERE ` + where + `
 GROUP BY 1, 2, 3`
-		}
 	}
 	return sql + `
 ORDER BY table_name, constraint_kind, constraint_name`
 }
 
-func (p postgres) AllConstraintsSQL() string {
-	sql := `
+func (p postgres) relkindCondition(kinds []TableKind) string {
+	if len(kinds) == 0 {
+		return `cls.relkind = 'r'`
+	}
+	relkinds := make([]string, 0, len(kinds))
+	for _, k := range kinds {
+		switch k {
+		case KindTable:
+			relkinds = append(relkinds, `'r'`)
+		case KindView:
+			relkinds = append(relkinds, `'v'`)
+		case KindMaterializedView:
+			relkinds = append(relkinds, `'m'`)
+		case KindForeignTable:
+			relkinds = append(relkinds, `'f'`)
+		case KindPartitionedTable:
+			relkinds = append(relkinds, `'p'`)
+		}
+	}
+	if len(relkinds) == 0 {
+		return `FALSE`
+	}
+	return `cls.relkind IN (` + strings.Join(relkinds, ", ") + `)`
+}
+
+func (p postgres) TableStatsSQL() string {
+	return p.tableStatsSQL() + `
+AND   cls.relname = $2
+ORDER BY cls.relname`
+}
+
+func (p postgres) AllTableStatsSQL() string {
+	return p.tableStatsSQL() + `
+ORDER BY cls.relname`
+}
+
+func (p postgres) FilteredTableStatsSQL(f TableFilter) (string, []interface{}) {
+	cond, args := p.filteredTablesCondition(`cls.oid`, f)
+	return p.tableStatsSQL() + `
+AND   ` + cond + `
+ORDER BY cls.relname`, args
+}
+
+func (p postgres) tableStatsSQL() string {
+	return `
 SELECT ns.nspname AS schema
      , cls.relname AS table_name
-     , cns.conname AS constraint_name
-     , 'CHECK' AS constraint_kind
-     , cns.consrc AS constraint_content
-FROM pg_catalog.pg_constraint cns
-JOIN pg_catalog.pg_class cls
-ON cls.oid = cns.conrelid
-JOIN pg_catalog.pg_namespace ns
-ON ns.oid = cls.relnamespace
-WHERE cns.contype = 'c'
-AND   ns.nspname = $1
-UNION
+     , cls.reltuples::bigint AS estimated_rows
+     , pg_catalog.pg_total_relation_size(cls.oid) AS total_bytes
+     , pg_catalog.pg_relation_size(cls.oid) AS heap_bytes
+     , COALESCE(pg_catalog.pg_total_relation_size(NULLIF(cls.reltoastrelid, 0)), 0) AS toast_bytes
+     , pg_catalog.pg_indexes_size(cls.oid) AS index_bytes
+     , GREATEST(st.last_vacuum, st.last_autovacuum) AS last_vacuum
+     , GREATEST(st.last_analyze, st.last_autoanalyze) AS last_analyze
+FROM pg_catalog.pg_class cls
+INNER JOIN pg_catalog.pg_namespace ns
+ON  cls.relnamespace = ns.oid
+LEFT OUTER JOIN pg_catalog.pg_stat_all_tables st
+ON  st.relid = cls.oid
+WHERE cls.relkind IN ('r', 'm', 'p')
+AND   ns.nspname = $1`
+}
+
+func (p postgres) IndexStatsSQL() string {
+	return p.indexStatsSQL() + `
+AND   tcls.relname = $2
+ORDER BY tcls.relname, icls.relname`
+}
+
+func (p postgres) AllIndexStatsSQL() string {
+	return p.indexStatsSQL() + `
+ORDER BY tcls.relname, icls.relname`
+}
+
+func (p postgres) FilteredIndexStatsSQL(f TableFilter) (string, []interface{}) {
+	cond, args := p.filteredTablesCondition(`tcls.oid`, f)
+	return p.indexStatsSQL() + `
+AND   ` + cond + `
+ORDER BY tcls.relname, icls.relname`, args
+}
+
+func (p postgres) indexStatsSQL() string {
+	return `
+SELECT ns.nspname AS schema
+     , tcls.relname AS table_name
+     , icls.relname AS index_name
+     , pg_catalog.pg_relation_size(icls.oid) AS index_bytes
+     , COALESCE(st.idx_scan, 0) AS scans
+     , COALESCE(st.idx_tup_read, 0) AS tuples_read
+     , COALESCE(st.idx_tup_fetch, 0) AS tuples_fetched
+FROM pg_catalog.pg_index idx
+INNER JOIN pg_catalog.pg_class tcls
+ON tcls.oid = idx.indrelid
+INNER JOIN pg_catalog.pg_namespace ns
+ON tcls.relnamespace = ns.oid
+INNER JOIN pg_catalog.pg_class icls
+ON icls.oid = idx.indexrelid
+LEFT OUTER JOIN pg_catalog.pg_stat_all_indexes st
+ON st.indexrelid = idx.indexrelid
+WHERE ns.nspname = $1`
+}
+
+func (p postgres) ColumnStatsSQL() string {
+	return p.columnStatsSQL() + `
+AND   cls.relname = $2
+ORDER BY cls.relname, att.attnum, st.inherited DESC`
+}
+
+func (p postgres) AllColumnStatsSQL() string {
+	return p.columnStatsSQL() + `
+ORDER BY cls.relname, att.attnum, st.inherited DESC`
+}
+
+func (p postgres) FilteredColumnStatsSQL(f TableFilter) (string, []interface{}) {
+	cond, args := p.filteredTablesCondition(`cls.oid`, f)
+	return p.columnStatsSQL() + `
+AND   ` + cond + `
+ORDER BY cls.relname, att.attnum, st.inherited DESC`, args
+}
+
+// columnStatsSQL requires PostgreSQL 9.2 or later for array_to_json.
+// When statistics with and without inheritance children exist, the latter is ordered last and wins.
+func (p postgres) columnStatsSQL() string {
+	return `
 SELECT ns.nspname AS schema
      , cls.relname AS table_name
-     , cns.conname AS constraint_name
-     , 'UNIQUE' AS constraint_kind
-     , array_to_string(array_agg(att.attname), ', ') AS constraint_content
-FROM (
-    SELECT conrelid
-         , conname
-         , conkey AS colnums
-         , generate_series(1, length(array_to_string(conkey, ' ')) - length(array_to_string(conkey, '')) + 1) AS pos
-    FROM pg_catalog.pg_constraint
-    WHERE contype = 'u'
-) cns
-JOIN pg_catalog.pg_class cls
-ON cls.oid = cns.conrelid
-JOIN pg_catalog.pg_namespace ns
-ON ns.oid = cls.relnamespace
-JOIN pg_catalog.pg_attribute att
-ON att.attrelid = cls.oid
-AND att.attnum = cns.colnums[cns.pos]
-WHERE ns.nspname = $1
-GROUP BY 1, 2, 3`
-	if p.ds.Version != "" {
-		v1, err1 := version.NewVersion("9.0")
-		v2, err2 := version.NewVersion(p.ds.Version)
-		if err1 == nil && err2 == nil && v2.Compare(v1) >= 0 {
-			sql += `
-UNION
+     , att.attname AS column_name
+     , st.null_frac
+     , st.n_distinct
+     , st.avg_width
+     , array_to_json(st.most_common_vals::text::text[])::text AS most_common_vals
+     , array_to_json(st.most_common_freqs)::text AS most_common_freqs
+     , array_to_json(st.histogram_bounds::text::text[])::text AS histogram_bounds
+FROM pg_catalog.pg_stats st
+INNER JOIN pg_catalog.pg_namespace ns
+ON  ns.nspname = st.schemaname
+INNER JOIN pg_catalog.pg_class cls
+ON  cls.relnamespace = ns.oid
+AND cls.relname = st.tablename
+INNER JOIN pg_catalog.pg_attribute att
+ON  att.attrelid = cls.oid
+AND att.attname = st.attname
+WHERE ns.nspname = $1`
+}
+
+func (p postgres) TablePrivilegesSQL() string {
+	return p.tablePrivilegesSQL() + `
+AND   cls.relname = $2
+ORDER BY cls.relname, grantee, privilege`
+}
+
+func (p postgres) AllTablePrivilegesSQL() string {
+	return p.tablePrivilegesSQL() + `
+ORDER BY cls.relname, grantee, privilege`
+}
+
+func (p postgres) FilteredTablePrivilegesSQL(f TableFilter) (string, []interface{}) {
+	cond, args := p.filteredTablesCondition(`cls.oid`, f)
+	return p.tablePrivilegesSQL() + `
+AND   ` + cond + `
+ORDER BY cls.relname, grantee, privilege`, args
+}
+
+// tablePrivilegesSQL requires PostgreSQL 9.3 or later for LATERAL.
+// If relacl is NULL, table has default privileges that owner has all privileges.
+// Table that all privileges are revoked from is returned with NULL privilege columns, so that its owner is loaded.
+func (p postgres) tablePrivilegesSQL() string {
+	return `
 SELECT ns.nspname AS schema
      , cls.relname AS table_name
-     , cns.conname AS constraint_name
-     , 'EXCLUDE' AS constraint_kind
-     , array_to_string(array_agg(att.attname || ' WITH ' || op.oprname), ', ') AS constraint_content
+     , pg_catalog.pg_get_userbyid(cls.relowner) AS owner
+     , CASE WHEN acl.grantee = 0 THEN 'PUBLIC' ELSE pg_catalog.pg_get_userbyid(acl.grantee) END AS grantee
+     , acl.privilege_type AS privilege
+     , CASE WHEN acl.is_grantable THEN 'YES' ELSE 'NO' END AS grantable
+FROM pg_catalog.pg_class cls
+INNER JOIN pg_catalog.pg_namespace ns
+ON  cls.relnamespace = ns.oid
+LEFT OUTER JOIN LATERAL pg_catalog.aclexplode(COALESCE(cls.relacl, pg_catalog.acldefault('r', cls.relowner))) acl
+ON  TRUE
+WHERE cls.relkind IN ('r', 'v', 'm', 'f', 'p')
+AND   ns.nspname = $1`
+}
+
+func (p postgres) ColumnPrivilegesSQL() string {
+	return p.columnPrivilegesSQL() + `
+AND   cls.relname = $2
+ORDER BY cls.relname, att.attnum, grantee, privilege`
+}
+
+func (p postgres) AllColumnPrivilegesSQL() string {
+	return p.columnPrivilegesSQL() + `
+ORDER BY cls.relname, att.attnum, grantee, privilege`
+}
+
+func (p postgres) FilteredColumnPrivilegesSQL(f TableFilter) (string, []interface{}) {
+	cond, args := p.filteredTablesCondition(`cls.oid`, f)
+	return p.columnPrivilegesSQL() + `
+AND   ` + cond + `
+ORDER BY cls.relname, att.attnum, grantee, privilege`, args
+}
+
+func (p postgres) columnPrivilegesSQL() string {
+	return `
+SELECT ns.nspname AS schema
+     , cls.relname AS table_name
+     , att.attname AS column_name
+     , CASE WHEN (att.acl).grantee = 0 THEN 'PUBLIC' ELSE pg_catalog.pg_get_userbyid((att.acl).grantee) END AS grantee
+     , (att.acl).privilege_type AS privilege
+     , CASE WHEN (att.acl).is_grantable THEN 'YES' ELSE 'NO' END AS grantable
 FROM (
-    SELECT conrelid
-         , conname
-         , conkey AS colnums
-         , conexclop AS opids
-         , generate_series(1, length(array_to_string(conkey, ' ')) - length(array_to_string(conkey, '')) + 1) AS pos
-    FROM pg_catalog.pg_constraint
-    WHERE contype = 'x'
-) cns
-JOIN pg_catalog.pg_class cls
-ON cls.oid = cns.conrelid
-JOIN pg_catalog.pg_namespace ns
-ON ns.oid = cls.relnamespace
-JOIN pg_catalog.pg_attribute att
-ON att.attrelid = cls.oid
-AND att.attnum = cns.colnums[cns.pos]
-JOIN pg_catalog.pg_operator op
-ON op.oid = cns.opids[cns.pos]
-WHERE ns.nspname = $1
-GROUP BY 1, 2, 3`
+    SELECT a.attrelid
+         , a.attname
+         , a.attnum
+         , pg_catalog.aclexplode(a.attacl) AS acl
+    FROM pg_catalog.pg_attribute a
+    WHERE a.attnum > 0
+    AND   NOT a.attisdropped
+    AND   a.attacl IS NOT NULL
+) att
+INNER JOIN pg_catalog.pg_class cls
+ON  cls.oid = att.attrelid
+INNER JOIN pg_catalog.pg_namespace ns
+ON  cls.relnamespace = ns.oid
+WHERE ns.nspname = $1`
+}
+
+func (p postgres) RolesSQL() string {
+	return `
+SELECT r.rolname AS role_name
+     , CASE WHEN r.rolsuper THEN 'YES' ELSE 'NO' END AS superuser
+     , CASE WHEN r.rolinherit THEN 'YES' ELSE 'NO' END AS inherit
+     , CASE WHEN r.rolcreaterole THEN 'YES' ELSE 'NO' END AS create_role
+     , CASE WHEN r.rolcreatedb THEN 'YES' ELSE 'NO' END AS create_db
+     , CASE WHEN r.rolcanlogin THEN 'YES' ELSE 'NO' END AS can_login
+     , array_to_json(ARRAY(
+           SELECT b.rolname
+           FROM pg_catalog.pg_auth_members m
+           INNER JOIN pg_catalog.pg_roles b
+           ON b.oid = m.roleid
+           WHERE m.member = r.oid
+           ORDER BY b.rolname))::text AS member_of
+FROM pg_catalog.pg_roles r
+ORDER BY r.rolname`
+}
+
+func (p postgres) PoliciesSQL() string {
+	return p.policiesSQL() + `
+AND   cls.relname = $2
+ORDER BY cls.relname, policy_name`
+}
+
+func (p postgres) AllPoliciesSQL() string {
+	return p.policiesSQL() + `
+ORDER BY cls.relname, policy_name`
+}
+
+func (p postgres) FilteredPoliciesSQL(f TableFilter) (string, []interface{}) {
+	cond, args := p.filteredTablesCondition(`cls.oid`, f)
+	return p.policiesSQL() + `
+AND   ` + cond + `
+ORDER BY cls.relname, policy_name`, args
+}
+
+// policiesSQL requires PostgreSQL 9.5 or later for row level security.
+// On older version, every table is returned without row level security.
+func (p postgres) policiesSQL() string {
+	if !p.versionAtLeast("9.5") {
+		return `
+SELECT ns.nspname AS schema
+     , cls.relname AS table_name
+     , 'NO' AS row_security
+     , 'NO' AS force_row_security
+     , NULL AS policy_name
+     , NULL AS command
+     , NULL AS permissive
+     , NULL AS roles
+     , NULL AS using_expression
+     , NULL AS check_expression
+FROM pg_catalog.pg_class cls
+INNER JOIN pg_catalog.pg_namespace ns
+ON  cls.relnamespace = ns.oid
+WHERE cls.relkind IN ('r', 'p')
+AND   ns.nspname = $1`
+	}
+	permissive := `'PERMISSIVE'`
+	if p.versionAtLeast("10") {
+		permissive = `CASE WHEN pol.polpermissive THEN 'PERMISSIVE' ELSE 'RESTRICTIVE' END`
+	}
+	return `
+SELECT ns.nspname AS schema
+     , cls.relname AS table_name
+     , CASE WHEN cls.relrowsecurity THEN 'YES' ELSE 'NO' END AS row_security
+     , CASE WHEN cls.relforcerowsecurity THEN 'YES' ELSE 'NO' END AS force_row_security
+     , pol.polname AS policy_name
+     , CASE pol.polcmd
+           WHEN 'r' THEN 'SELECT'
+           WHEN 'a' THEN 'INSERT'
+           WHEN 'w' THEN 'UPDATE'
+           WHEN 'd' THEN 'DELETE'
+           ELSE 'ALL'
+       END AS command
+     , ` + permissive + ` AS permissive
+     , array_to_json(ARRAY(
+           SELECT CASE WHEN r.oid = 0 THEN 'PUBLIC' ELSE pg_catalog.pg_get_userbyid(r.oid) END
+           FROM unnest(pol.polroles) AS r(oid)
+           ORDER BY 1))::text AS roles
+     , pg_catalog.pg_get_expr(pol.polqual, pol.polrelid) AS using_expression
+     , pg_catalog.pg_get_expr(pol.polwithcheck, pol.polrelid) AS check_expression
+FROM pg_catalog.pg_class cls
+INNER JOIN pg_catalog.pg_namespace ns
+ON  cls.relnamespace = ns.oid
+LEFT OUTER JOIN pg_catalog.pg_policy pol
+ON  pol.polrelid = cls.oid
+WHERE cls.relkind IN ('r', 'p')
+AND   ns.nspname = $1`
+}
+
+// ExtensionsSQL requires PostgreSQL 9.1 or later.
+func (p postgres) ExtensionsSQL() string {
+	return `
+SELECT ext.extname AS extension_name
+     , ext.extversion AS version
+     , ns.nspname AS schema
+     , d.description AS comment
+FROM pg_catalog.pg_extension ext
+INNER JOIN pg_catalog.pg_namespace ns
+ON  ns.oid = ext.extnamespace
+LEFT OUTER JOIN pg_catalog.pg_description d
+ON  d.objoid = ext.oid
+AND d.classoid = 'pg_catalog.pg_extension'::regclass
+ORDER BY ext.extname`
+}
+
+// ExtensionObjectsSQL requires PostgreSQL 9.1 or later.
+func (p postgres) ExtensionObjectsSQL() string {
+	return `
+SELECT ext.extname AS extension_name
+     , CASE cls.relkind
+           WHEN 'r' THEN 'TABLE'
+           WHEN 'v' THEN 'VIEW'
+           WHEN 'm' THEN 'MATERIALIZED VIEW'
+           WHEN 'S' THEN 'SEQUENCE'
+           WHEN 'f' THEN 'FOREIGN TABLE'
+           WHEN 'p' THEN 'PARTITIONED TABLE'
+           ELSE 'RELATION'
+       END AS object_kind
+     , ns.nspname AS object_schema
+     , cls.relname AS object_name
+FROM pg_catalog.pg_depend dep
+INNER JOIN pg_catalog.pg_extension ext
+ON  ext.oid = dep.refobjid
+INNER JOIN pg_catalog.pg_class cls
+ON  cls.oid = dep.objid
+INNER JOIN pg_catalog.pg_namespace ns
+ON  ns.oid = cls.relnamespace
+WHERE dep.deptype = 'e'
+AND   dep.refclassid = 'pg_catalog.pg_extension'::regclass
+AND   dep.classid = 'pg_catalog.pg_class'::regclass
+UNION ALL
+SELECT ext.extname AS extension_name
+     , 'TYPE' AS object_kind
+     , ns.nspname AS object_schema
+     , typ.typname AS object_name
+FROM pg_catalog.pg_depend dep
+INNER JOIN pg_catalog.pg_extension ext
+ON  ext.oid = dep.refobjid
+INNER JOIN pg_catalog.pg_type typ
+ON  typ.oid = dep.objid
+INNER JOIN pg_catalog.pg_namespace ns
+ON  ns.oid = typ.typnamespace
+WHERE dep.deptype = 'e'
+AND   dep.refclassid = 'pg_catalog.pg_extension'::regclass
+AND   dep.classid = 'pg_catalog.pg_type'::regclass
+UNION ALL
+SELECT ext.extname AS extension_name
+     , 'FUNCTION' AS object_kind
+     , ns.nspname AS object_schema
+     , pro.proname || '(' || pg_catalog.pg_get_function_identity_arguments(pro.oid) || ')' AS object_name
+FROM pg_catalog.pg_depend dep
+INNER JOIN pg_catalog.pg_extension ext
+ON  ext.oid = dep.refobjid
+INNER JOIN pg_catalog.pg_proc pro
+ON  pro.oid = dep.objid
+INNER JOIN pg_catalog.pg_namespace ns
+ON  ns.oid = pro.pronamespace
+WHERE dep.deptype = 'e'
+AND   dep.refclassid = 'pg_catalog.pg_extension'::regclass
+AND   dep.classid = 'pg_catalog.pg_proc'::regclass
+ORDER BY extension_name, object_kind, object_schema, object_name`
+}
+
+// detectVersion loads server_version_num if version of data source is empty.
+func (p postgres) detectVersion(db *sql.DB) (Provider, string, error) {
+	if p.ds.Version != "" {
+		return p, p.ds.Version, nil
+	}
+	var num int
+	if err := db.QueryRow(`SELECT current_setting('server_version_num')::int`).Scan(&num); err != nil {
+		return nil, "", err
+	}
+	p.ds.Version = postgresVersion(num)
+	return p, p.ds.Version, nil
+}
+
+// postgresVersion returns version from server_version_num. (eg. 90603 -> "9.6.3", 100004 -> "10.4")
+func postgresVersion(num int) string {
+	if num >= 100000 {
+		return strconv.Itoa(num/10000) + "." + strconv.Itoa(num%10000)
+	}
+	return strconv.Itoa(num/10000) + "." + strconv.Itoa(num/100%100) + "." + strconv.Itoa(num%100)
+}
+
+// versionAtLeast returns true if data source's version is given version or later.
+// If version of data source is unknown, versionAtLeast returns false.
+func (p postgres) versionAtLeast(v string) bool {
+	if p.ds.Version == "" {
+		return false
+	}
+	v1, err1 := version.NewVersion(v)
+	v2, err2 := version.NewVersion(p.ds.Version)
+	return err1 == nil && err2 == nil && v2.Compare(v1) >= 0
+}
+
+// nameCondition returns condition that col matches NameMatcher's pattern given as param.
+func (p postgres) nameCondition(col string, m NameMatcher, param string) string {
+	like, re := `LIKE`, `~`
+	if m.IgnoreCase {
+		like, re = `ILIKE`, `~*`
+	}
+	switch m.Mode {
+	case MatchExact:
+		if m.IgnoreCase {
+			return `lower(` + col + `) = lower(` + param + `)`
 		}
+		return col + ` = ` + param
+	case MatchPrefix:
+		return col + ` ` + like + ` ` + p.escapeLike(param) + ` || '%'`
+	case MatchSuffix:
+		return col + ` ` + like + ` '%' || ` + p.escapeLike(param)
+	case MatchContains:
+		return col + ` ` + like + ` '%' || ` + p.escapeLike(param) + ` || '%'`
+	case MatchLike:
+		return col + ` ` + like + ` ` + param
+	case MatchRegexp:
+		return col + ` ` + re + ` ` + param
 	}
-	return sql + `
-ORDER BY table_name, constraint_kind, constraint_name`
+	return `FALSE`
+}
+
+func (p postgres) escapeLike(param string) string {
+	return `replace(replace(replace(` + param + `, E'\\', E'\\\\'), '%', E'\\%'), '_', E'\\_')`
 }
 
 func (p postgres) connStr() string {
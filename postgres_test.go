@@ -1,6 +1,7 @@
 package dbmodel
 
 import (
+	"bytes"
 	"fmt"
 	"log"
 	"strings"
@@ -45,6 +46,73 @@ func TestPostgresConnectionString(t *testing.T) {
 	}
 }
 
+func TestPostgresFilteredTablesSQL(t *testing.T) {
+	p := newPostgres(InitDataSource())
+	sql, args := p.FilteredTablesSQL(TableFilter{
+		Names:    []string{"users"},
+		Includes: []string{"user_*"},
+		Excludes: []string{"*_bk?"},
+		Kinds:    []TableKind{KindTable, KindView},
+	})
+	conds := []string{
+		"cls.relkind IN ('r', 'v')",
+		"cls.relname IN ($2)",
+		"(cls.relname LIKE $3)",
+		"NOT cls.relname LIKE $4",
+	}
+	for _, cond := range conds {
+		if !strings.Contains(sql, cond) {
+			t.Errorf("FilteredTablesSQL should contain '%v'.", cond)
+		}
+	}
+	expected := []interface{}{"users", `user\_%`, `%\_bk_`}
+	if fmt.Sprint(args) != fmt.Sprint(expected) {
+		t.Errorf("FilteredTablesSQL returns invalid parameters. expected: %v, actual: %v", expected, args)
+	}
+}
+
+func TestPostgresFilteredTablesSQLWithRegexp(t *testing.T) {
+	p := newPostgres(InitDataSource())
+	sql, args := p.FilteredTablesSQL(TableFilter{Includes: []string{"^users?$"}, Regexp: true})
+	if !strings.Contains(sql, "(cls.relname ~ $2)") {
+		t.Error("FilteredTablesSQL should use regular expression match.")
+	}
+	if !strings.Contains(sql, "cls.relkind = 'r'") {
+		t.Error("FilteredTablesSQL should load only tables when kinds is empty.")
+	}
+	if len(args) != 1 || args[0] != "^users?$" {
+		t.Errorf("FilteredTablesSQL returns invalid parameters. actual: %v", args)
+	}
+}
+
+func TestPostgresFilteredMetadataSQL(t *testing.T) {
+	p := newPostgres(InitDataSource())
+	queries := map[string]func(TableFilter) (string, []interface{}){
+		"FilteredIndicesSQL":          p.FilteredIndicesSQL,
+		"FilteredForeignKeysSQL":      p.FilteredForeignKeysSQL,
+		"FilteredReferencedKeysSQL":   p.FilteredReferencedKeysSQL,
+		"FilteredConstraintsSQL":      p.FilteredConstraintsSQL,
+		"FilteredTableStatsSQL":       p.FilteredTableStatsSQL,
+		"FilteredIndexStatsSQL":       p.FilteredIndexStatsSQL,
+		"FilteredColumnStatsSQL":      p.FilteredColumnStatsSQL,
+		"FilteredTablePrivilegesSQL":  p.FilteredTablePrivilegesSQL,
+		"FilteredColumnPrivilegesSQL": p.FilteredColumnPrivilegesSQL,
+		"FilteredPoliciesSQL":         p.FilteredPoliciesSQL,
+	}
+	for name, fn := range queries {
+		sql, args := fn(TableFilter{Names: []string{"users"}, Excludes: []string{"*_bk"}})
+		for _, cond := range []string{"cls.relname IN ($2)", "NOT cls.relname LIKE $3"} {
+			if !strings.Contains(sql, cond) {
+				t.Errorf("%v should contain '%v'.", name, cond)
+			}
+		}
+		expected := []interface{}{"users", `%\_bk`}
+		if fmt.Sprint(args) != fmt.Sprint(expected) {
+			t.Errorf("%v returns invalid parameters. expected: %v, actual: %v", name, expected, args)
+		}
+	}
+}
+
 func TestPostgresAllTableNames(t *testing.T) {
 	c := createPostgresClient()
 	defer c.Disconnect()
@@ -95,7 +163,7 @@ func TestPostgresTableNames(t *testing.T) {
 	defer c.Disconnect()
 	c.Connect()
 
-	ts, err := c.TableNames("schm", "tbl1")
+	ts, err := c.TableNames("schm", NameContains("tbl1"))
 	if err != nil {
 		t.Error(err)
 	}
@@ -111,12 +179,63 @@ func TestPostgresTableNames(t *testing.T) {
 	}
 }
 
+func TestPostgresTableNamesWithNameMatcher(t *testing.T) {
+	c := createPostgresClient()
+	defer c.Disconnect()
+	c.Connect()
+
+	tests := []struct {
+		m     NameMatcher
+		count int
+	}{
+		{ExactName("tbl"), 0},
+		{ExactName("tbl1"), 1},
+		{ExactName("TBL1").IgnoringCase(), 1},
+		{NamePrefix("tbl"), 3},
+		{NamePrefix("tbl_"), 0},
+		{NameSuffix("2"), 1},
+		{NameLike("tbl_"), 3},
+		{NameLike(`tbl\_`), 0},
+		{NameRegexp("^tbl[12]$"), 2},
+		{NameRegexp("^TBL").IgnoringCase(), 3},
+	}
+	for _, test := range tests {
+		ts, err := c.TableNames("schm", test.m)
+		if err != nil {
+			t.Error(err)
+		}
+		if len(ts) != test.count {
+			t.Errorf("TableNames with %#v should return %v table names. but actual %v", test.m, test.count, len(ts))
+		}
+	}
+}
+
+func TestPostgresMatchedTableNamesSQL(t *testing.T) {
+	p := newPostgres(InitDataSource())
+	tests := []struct {
+		m        NameMatcher
+		expected string
+	}{
+		{ExactName("users"), "t.tablename = $2"},
+		{ExactName("users").IgnoringCase(), "lower(t.tablename) = lower($2)"},
+		{NamePrefix("users"), `t.tablename LIKE replace(replace(replace($2, E'\\', E'\\\\'), '%', E'\\%'), '_', E'\\_') || '%'`},
+		{NameLike("users%").IgnoringCase(), "t.tablename ILIKE $2"},
+		{NameRegexp("^users$"), "t.tablename ~ $2"},
+		{NameRegexp("^users$").IgnoringCase(), "t.tablename ~* $2"},
+	}
+	for _, test := range tests {
+		if sql := p.MatchedTableNamesSQL(test.m); !strings.Contains(sql, test.expected) {
+			t.Errorf("MatchedTableNamesSQL with %#v should contain '%v'.", test.m, test.expected)
+		}
+	}
+}
+
 func TestPostgresTableNamesNoResultOnInvalidSchema(t *testing.T) {
 	c := createPostgresClient()
 	defer c.Disconnect()
 	c.Connect()
 
-	ts, err := c.TableNames("other", "tbl1")
+	ts, err := c.TableNames("other", NameContains("tbl1"))
 	if err != nil {
 		t.Error(err)
 	}
@@ -131,7 +250,7 @@ func TestPostgresTableNamesNoResultOnInvalidTalbeName(t *testing.T) {
 	defer c.Disconnect()
 	c.Connect()
 
-	ts, err := c.TableNames("schm", "sample")
+	ts, err := c.TableNames("schm", NameContains("sample"))
 	if err != nil {
 		t.Error(err)
 	}
@@ -160,7 +279,7 @@ func TestPostgresTableNamesWithoutSchema(t *testing.T) {
 	defer c.Disconnect()
 	c.Connect()
 
-	_, err := c.TableNames("", "tbl1")
+	_, err := c.TableNames("", NameContains("tbl1"))
 	if err == nil {
 		t.Errorf("Client should raise error when empty schema given.")
 	}
@@ -169,6 +288,130 @@ func TestPostgresTableNamesWithoutSchema(t *testing.T) {
 	}
 }
 
+func TestPostgresRoles(t *testing.T) {
+	c := createPostgresClient()
+	defer c.Disconnect()
+	c.Connect()
+
+	roles, err := c.Roles()
+	if err != nil {
+		t.Error(err)
+	}
+	found := false
+	for _, r := range roles {
+		if r.Name() == "postgres" {
+			found = true
+			if !r.IsSuperuser() || !r.CanLogin() {
+				t.Errorf("postgres role should be superuser and can login. (%#v)", r)
+			}
+		}
+	}
+	if !found {
+		t.Error("Roles should contain postgres role.")
+	}
+}
+
+func TestPostgresExtensions(t *testing.T) {
+	c := createPostgresClient()
+	defer c.Disconnect()
+	c.Connect()
+
+	exts, err := c.Extensions()
+	if err != nil {
+		t.Error(err)
+	}
+	for _, ext := range exts {
+		if ext.Name() != "plpgsql" {
+			continue
+		}
+		if ext.Schema() != "pg_catalog" || ext.Version() == "" {
+			t.Errorf("plpgsql extension is invalid. (%#v)", ext)
+		}
+		for _, obj := range ext.Objects() {
+			if obj.Kind() == "FUNCTION" && obj.Name() == "plpgsql_call_handler()" {
+				return
+			}
+		}
+		t.Errorf("plpgsql extension should contain plpgsql_call_handler(). (%v)", ext.Objects())
+		return
+	}
+	t.Error("Extensions should contain plpgsql.")
+}
+
+func TestPostgresExtensionMemberTables(t *testing.T) {
+	c := createPostgresClient()
+	defer c.Disconnect()
+	c.Connect()
+	if !createPostgresExtensionResources(t, c) {
+		return
+	}
+
+	tbls, err := c.AllTables("extmembers", Option{Extensions: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertTableNames(t, tbls, "members", "others")
+	if len(tbls) == 2 {
+		if actual, expected := tbls[0].Extension(), "plpgsql"; actual != expected {
+			t.Errorf("Extension of member table is invalid. expected: %v, actual: %v", expected, actual)
+		}
+		if actual, expected := tbls[1].Extension(), ""; actual != expected {
+			t.Errorf("Extension of non member table is invalid. expected: %v, actual: %v", expected, actual)
+		}
+	}
+
+	tbl, err := c.Table("extmembers", "members", Option{Extensions: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual, expected := tbl.Extension(), "plpgsql"; actual != expected {
+		t.Errorf("Extension of member table is invalid. expected: %v, actual: %v", expected, actual)
+	}
+
+	tbls, err = c.AllTables("extmembers", Option{Filter: TableFilter{ExcludeExtensionMembers: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertTableNames(t, tbls, "others")
+}
+
+func TestPostgresSnapshot(t *testing.T) {
+	c := createPostgresClient()
+	defer c.Disconnect()
+	c.Connect()
+
+	s, err := c.Snapshot("schm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err = WriteSnapshot(&buf, s); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadSnapshot(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := c.AllTables("schm", RequireAll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sc := NewSnapshotClient(read)
+	actual, err := sc.AllTables("schm", RequireAll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actual) != len(expected) {
+		t.Fatalf("Table count is invalid. expected: %v, actual: %v", len(expected), len(actual))
+	}
+	for i, tbl := range expected {
+		if actual[i].Name() != tbl.Name() || len(actual[i].Columns()) != len(tbl.Columns()) || len(actual[i].ForeignKeys()) != len(tbl.ForeignKeys()) {
+			t.Errorf("Table in snapshot is invalid. expected: %#v, actual: %#v", tbl, actual[i])
+		}
+	}
+}
+
 func TestPostgresTableValid(t *testing.T) {
 	c := createPostgresClient()
 	defer c.Disconnect()
@@ -232,6 +475,52 @@ func TestPostgresTableColumnsOrder(t *testing.T) {
 	}
 }
 
+func TestPostgresTableColumnLogicalType(t *testing.T) {
+	tbls := loadPostgresTableBy2Way("schm", "tbl1")
+	for _, tbl := range tbls {
+		types := []LogicalType{LogicalInteger, LogicalDecimal, LogicalString, LogicalDecimal, LogicalDecimal, LogicalInteger, LogicalTimestamp}
+		for i, expected := range types {
+			if actual := tbl.Columns()[i].LogicalType(); actual != expected {
+				t.Errorf("LogicalType() returns invalid value. expected: %v, actual: %v", expected, actual)
+			}
+		}
+	}
+}
+
+func TestPostgresEnumColumnLogicalType(t *testing.T) {
+	c := createPostgresClient()
+	c.Connect()
+	defer c.Disconnect()
+	if !createPostgresEnumResources(t, c) {
+		return
+	}
+
+	tbl, err := c.Table("enums", "diaries", RequireNone)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	col, _ := tbl.FindColumn("mood")
+	if actual, expected := col.LogicalType(), LogicalEnum; actual != expected {
+		t.Errorf("LogicalType() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+
+	ds := createPostgresDataSource("postgres", "9.4")
+	ds.TypeMapping = TypeMapping{"mood": LogicalString}
+	mc := NewClient(ds)
+	mc.Connect()
+	defer mc.Disconnect()
+	tbl, err = mc.Table("enums", "diaries", RequireNone)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	col, _ = tbl.FindColumn("mood")
+	if actual, expected := col.LogicalType(), LogicalString; actual != expected {
+		t.Errorf("LogicalType() should prefer TypeMapping of DataSource. expected: %v, actual: %v", expected, actual)
+	}
+}
+
 func TestPostgresTableColumnComment(t *testing.T) {
 	tbls := loadPostgresTableBy2Way("schm", "tbl2")
 	for _, tbl := range tbls {
@@ -588,6 +877,217 @@ func TestPostgresAllTablesTableComment(t *testing.T) {
 	}
 }
 
+func TestPostgresAllTablesIndexColumnsLinked(t *testing.T) {
+	tbl := loadPostgresTableByAllTables("schm", "tbl2")
+	col, _ := tbl.FindColumn("idx_key")
+	idx, _ := tbl.FindIndex("tbl2_idx1")
+	if idx.Columns()[0] != col {
+		t.Error("Index column should be linked to table's column.")
+	}
+	if actual, expected := idx.Columns()[0].DataType(), "text"; actual != expected {
+		t.Errorf("Index column's data type is invalid. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestPostgresAllTablesForeignKeysLinked(t *testing.T) {
+	tbls := loadPostgresAllTables("schm")
+	tbl1, tbl2, tbl3 := tbls[0], tbls[1], tbls[2]
+	fk, _ := tbl3.FindForeignKey("tbl3_fk2")
+	from, _ := tbl3.FindColumn("tbl2_id")
+	to, _ := tbl2.FindColumn("id")
+	if fk.ColumnReferences()[0].From() != from {
+		t.Error("Foreign key's from column should be linked to table's column.")
+	}
+	if fk.ColumnReferences()[0].To() != to {
+		t.Error("Foreign key's to column should be linked to referenced table's column.")
+	}
+	if fk.ReferencedTable() != tbl2 {
+		t.Errorf("ReferencedTable() is invalid. expected: %v, actual: %v", tbl2.Name(), fk.ReferencedTable())
+	}
+	if rk, _ := tbl2.FindReferencedKey("tbl3_fk2"); rk != fk {
+		t.Error("Referenced key should be same as referencing table's foreign key.")
+	}
+	targets := tbl3.ForeignKeyTargets()
+	if len(targets) != 2 || targets[0] != tbl1 || targets[1] != tbl2 {
+		t.Errorf("ForeignKeyTargets() is invalid. (%v)", targets)
+	}
+}
+
+func TestPostgresPolicies(t *testing.T) {
+	c := NewClient(createPostgresDataSource("postgres", "9.5"))
+	defer c.Disconnect()
+	c.Connect()
+	if !createPostgresRLSResources(t, c) {
+		return
+	}
+
+	tbls, err := c.AllTables("rls", Option{Policies: true})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	plain, tenants := tbls[0], tbls[1]
+	if plain.RowSecurityEnabled() || plain.RowSecurityForced() || len(plain.Policies()) != 0 {
+		t.Errorf("Table without row level security is loaded invalidly. (%#v)", plain)
+	}
+	if !tenants.RowSecurityEnabled() || !tenants.RowSecurityForced() {
+		t.Error("Row level security should be enabled and forced.")
+	}
+	if actual, expected := len(tenants.Policies()), 2; actual != expected {
+		t.Errorf("Policy count is invalid. expected: %v, actual: %v", expected, actual)
+		return
+	}
+	ins, sel := tenants.Policies()[0], tenants.Policies()[1]
+	if actual, expected := ins.Command(), "INSERT"; actual != expected {
+		t.Errorf("Policy command is invalid. expected: %v, actual: %v", expected, actual)
+	}
+	if actual, expected := ins.WithCheck(), "(tenant_id > 0)"; actual != expected {
+		t.Errorf("Policy WITH CHECK is invalid. expected: %v, actual: %v", expected, actual)
+	}
+	if actual, expected := sel.Using(), "(tenant_id = 1)"; actual != expected {
+		t.Errorf("Policy USING is invalid. expected: %v, actual: %v", expected, actual)
+	}
+	if !sel.IsPermissive() || len(sel.Roles()) != 1 || sel.Roles()[0] != "PUBLIC" {
+		t.Errorf("Policy is loaded invalidly. (%#v)", sel)
+	}
+
+	tbl, err := c.Table("rls", "tenants", Option{Policies: true})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, ok := tbl.FindPolicy("tenants_select"); !ok {
+		t.Error("Table should load policies.")
+	}
+}
+
+func TestPostgresPoliciesWithoutVersion(t *testing.T) {
+	c := NewClient(createPostgresDataSource("postgres", ""))
+	defer c.Disconnect()
+	c.Connect()
+	if c.err != nil {
+		t.Error(c.err)
+		return
+	}
+	if c.dataSource.Version == "" {
+		t.Error("Connect should detect version of database server.")
+	}
+	if !createPostgresRLSResources(t, c) {
+		return
+	}
+
+	tbl, err := c.Table("rls", "tenants", Option{Policies: true})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !tbl.RowSecurityEnabled() || len(tbl.Policies()) != 2 {
+		t.Errorf("Policies should be loaded with detected version. (%#v)", tbl)
+	}
+}
+
+func TestPostgresVersion(t *testing.T) {
+	cases := []struct {
+		num      int
+		expected string
+	}{
+		{90405, "9.4.5"},
+		{90500, "9.5.0"},
+		{100004, "10.4"},
+		{120001, "12.1"},
+	}
+	for _, c := range cases {
+		if actual := postgresVersion(c.num); actual != c.expected {
+			t.Errorf("postgresVersion() returns invalid value. expected: %v, actual: %v", c.expected, actual)
+		}
+	}
+}
+
+func TestPostgresPoliciesSQLOnOldVersion(t *testing.T) {
+	p := newPostgres(createPostgresDataSource("postgres", "9.4"))
+	if strings.Contains(p.AllPoliciesSQL(), "pg_policy") {
+		t.Error("AllPoliciesSQL should not use pg_policy before 9.5.")
+	}
+	p = newPostgres(createPostgresDataSource("postgres", "9.6"))
+	if strings.Contains(p.AllPoliciesSQL(), "polpermissive") {
+		t.Error("AllPoliciesSQL should not use polpermissive before 10.")
+	}
+	p = newPostgres(createPostgresDataSource("postgres", "10"))
+	if !strings.Contains(p.AllPoliciesSQL(), "polpermissive") {
+		t.Error("AllPoliciesSQL should use polpermissive on 10 or later.")
+	}
+}
+
+func createPostgresRLSResources(t *testing.T, c *Client) bool {
+	var ver int
+	if err := c.db.QueryRow("SELECT current_setting('server_version_num')::int").Scan(&ver); err != nil {
+		t.Error(err)
+		return false
+	}
+	if ver < 90500 {
+		t.Skip("Row level security requires PostgreSQL 9.5 or later.")
+		return false
+	}
+	bytes, err := readSQLFile("create_postgres_rls_resources")
+	if err != nil {
+		t.Error(err)
+		return false
+	}
+	if _, err = c.db.Exec("DROP SCHEMA IF EXISTS rls CASCADE"); err != nil {
+		t.Error(err)
+		return false
+	}
+	if _, err = c.db.Exec(string(bytes)); err != nil {
+		t.Error(err)
+		return false
+	}
+	return true
+}
+
+func createPostgresEnumResources(t *testing.T, c *Client) bool {
+	bytes, err := readSQLFile("create_postgres_enum_resources")
+	if err != nil {
+		t.Error(err)
+		return false
+	}
+	if _, err = c.db.Exec("DROP SCHEMA IF EXISTS enums CASCADE"); err != nil {
+		t.Error(err)
+		return false
+	}
+	if _, err = c.db.Exec(string(bytes)); err != nil {
+		t.Error(err)
+		return false
+	}
+	return true
+}
+
+func createPostgresExtensionResources(t *testing.T, c *Client) bool {
+	bytes, err := readSQLFile("create_postgres_extension_resources")
+	if err != nil {
+		t.Error(err)
+		return false
+	}
+	// Member table can not be dropped until it is removed from extension.
+	if _, err = c.db.Exec(`DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_catalog.pg_tables WHERE schemaname = 'extmembers' AND tablename = 'members') THEN
+        ALTER EXTENSION plpgsql DROP TABLE extmembers.members;
+    END IF;
+END $$`); err != nil {
+		t.Error(err)
+		return false
+	}
+	if _, err = c.db.Exec("DROP SCHEMA IF EXISTS extmembers CASCADE"); err != nil {
+		t.Error(err)
+		return false
+	}
+	if _, err = c.db.Exec(string(bytes)); err != nil {
+		t.Error(err)
+		return false
+	}
+	return true
+}
+
 func createPostgresClient() *Client {
 	return NewClient(createPostgresDataSource("postgres", "9.4"))
 }
@@ -3,6 +3,9 @@ package dbmodel
 import "database/sql"
 
 // Provider is interface to absorbe difference of each database.
+// Optional features are provided by implementing other interfaces (eg. StatisticsProvider).
+// Client checks them with type assertion, and returns ErrNotSupported
+// when option that requires unimplemented interface is given.
 type Provider interface {
 	// Connect open connection to DataSouce.
 	Connect() (*sql.DB, error)
@@ -39,6 +42,9 @@ type Provider interface {
 	//     10. nullable ("YES" or "NO")
 	//     11. default value (as text)
 	//     12. primary key position
+	//     13. table kind (eg. "TABLE", "VIEW") (optional)
+	//     14. enum type ("YES" or "NO") (optional)
+	// Optional columns can be omitted, and then table is KindTable and column is not enum.
 	// Order:
 	//     1. table name
 	//     2. column position
@@ -154,3 +160,339 @@ type Provider interface {
 	//     3. constraint name
 	ConstraintsSQL() string
 }
+
+// NameMatchingProvider is implemented by provider that matches table names with NameMatcher in SQL.
+// If provider does not implement it, Client matches names loaded by AllTableNamesSQL.
+type NameMatchingProvider interface {
+	// MatchedTableNamesSQL should return SQL for loading table names that match given NameMatcher.
+	// Parameters:
+	//     1. schema
+	//     2. pattern of NameMatcher
+	// Return columns:
+	//     same as AllTableNamesSQL
+	// Order: table name
+	MatchedTableNamesSQL(m NameMatcher) string
+}
+
+// FilteringProvider is implemented by provider that pushes TableFilter down into SQL.
+// If provider does not implement it, Client filters tables loaded by AllTablesSQL.
+type FilteringProvider interface {
+	// FilteredTablesSQL should return SQL and parameters for loading tables that match given filter.
+	// Parameters:
+	//     1. schema
+	//     2... returned parameters
+	// Return columns:
+	//     same as AllTableSQL
+	// Order:
+	//     same as AllTableSQL
+	FilteredTablesSQL(f TableFilter) (string, []interface{})
+	// FilteredIndicesSQL should return SQL and parameters for loading indices of tables that match given filter.
+	// Parameters:
+	//     1. schema
+	//     2... returned parameters
+	// Return columns:
+	//     same as AllIndicesSQL
+	// Order:
+	//     same as AllIndicesSQL
+	FilteredIndicesSQL(f TableFilter) (string, []interface{})
+	// FilteredForeignKeysSQL should return SQL and parameters for loading foreign keys of tables that match given filter.
+	// Parameters:
+	//     1. schema
+	//     2... returned parameters
+	// Return columns:
+	//     same as AllForeignKeysSQL
+	// Order:
+	//     same as AllForeignKeysSQL
+	FilteredForeignKeysSQL(f TableFilter) (string, []interface{})
+	// FilteredReferencedKeysSQL should return SQL and parameters for loading foreign keys that reference tables that match given filter.
+	// Parameters:
+	//     1. schema
+	//     2... returned parameters
+	// Return columns:
+	//     same as AllReferencedKeysSQL
+	// Order:
+	//     same as AllReferencedKeysSQL
+	FilteredReferencedKeysSQL(f TableFilter) (string, []interface{})
+	// FilteredConstraintsSQL should return SQL and parameters for loading constraints of tables that match given filter.
+	// Parameters:
+	//     1. schema
+	//     2... returned parameters
+	// Return columns:
+	//     same as AllConstraintsSQL
+	// Order:
+	//     same as AllConstraintsSQL
+	FilteredConstraintsSQL(f TableFilter) (string, []interface{})
+}
+
+// StatisticsProvider is implemented by provider that loads table and index statistics. (Option.Statistics)
+type StatisticsProvider interface {
+	// AllTableStatsSQL should return SQL for loading all table statistics.
+	// Parameters:
+	//     1. schema
+	// Return columns:
+	//     1. schema
+	//     2. table name
+	//     3. estimated row count
+	//     4. total bytes (contains toast and indices)
+	//     5. heap bytes
+	//     6. toast bytes
+	//     7. index bytes
+	//     8. last vacuumed time
+	//     9. last analyzed time
+	// Order:
+	//     1. table name
+	AllTableStatsSQL() string
+	// FilteredTableStatsSQL should return SQL and parameters for loading statistics of tables that match given filter.
+	// Parameters:
+	//     1. schema
+	//     2... returned parameters
+	// Return columns:
+	//     same as AllTableStatsSQL
+	// Order:
+	//     same as AllTableStatsSQL
+	FilteredTableStatsSQL(f TableFilter) (string, []interface{})
+	// TableStatsSQL should return SQL for loading statistics of a table.
+	// Parameters:
+	//     1. schema
+	//     2. table name
+	// Return columns:
+	//     same as AllTableStatsSQL
+	TableStatsSQL() string
+	// AllIndexStatsSQL should return SQL for loading all index statistics.
+	// Parameters:
+	//     1. schema
+	// Return columns:
+	//     1. schema
+	//     2. table name
+	//     3. index name
+	//     4. index bytes
+	//     5. number of index scans
+	//     6. number of tuples read
+	//     7. number of tuples fetched
+	// Order:
+	//     1. table name
+	//     2. index name
+	AllIndexStatsSQL() string
+	// FilteredIndexStatsSQL should return SQL and parameters for loading index statistics of tables that match given filter.
+	// Parameters:
+	//     1. schema
+	//     2... returned parameters
+	// Return columns:
+	//     same as AllIndexStatsSQL
+	// Order:
+	//     same as AllIndexStatsSQL
+	FilteredIndexStatsSQL(f TableFilter) (string, []interface{})
+	// IndexStatsSQL should return SQL for loading index statistics in a table.
+	// Parameters:
+	//     1. schema
+	//     2. table name
+	// Return columns:
+	//     same as AllIndexStatsSQL
+	// Order:
+	//     1. index name
+	IndexStatsSQL() string
+}
+
+// ColumnStatisticsProvider is implemented by provider that loads column statistics. (Option.ColumnStatistics)
+type ColumnStatisticsProvider interface {
+	// AllColumnStatsSQL should return SQL for loading all column statistics.
+	// Parameters:
+	//     1. schema
+	// Return columns:
+	//     1. schema
+	//     2. table name
+	//     3. column name
+	//     4. fraction of NULL values
+	//     5. number of distinct values (negative value is ratio to row count)
+	//     6. average width in bytes
+	//     7. most common values (as JSON array of string)
+	//     8. frequencies of most common values (as JSON array of number)
+	//     9. histogram bounds (as JSON array of string)
+	// Order:
+	//     1. table name
+	//     2. column position
+	AllColumnStatsSQL() string
+	// FilteredColumnStatsSQL should return SQL and parameters for loading column statistics of tables that match given filter.
+	// Parameters:
+	//     1. schema
+	//     2... returned parameters
+	// Return columns:
+	//     same as AllColumnStatsSQL
+	// Order:
+	//     same as AllColumnStatsSQL
+	FilteredColumnStatsSQL(f TableFilter) (string, []interface{})
+	// ColumnStatsSQL should return SQL for loading column statistics in a table.
+	// Parameters:
+	//     1. schema
+	//     2. table name
+	// Return columns:
+	//     same as AllColumnStatsSQL
+	// Order:
+	//     1. column position
+	ColumnStatsSQL() string
+}
+
+// PrivilegesProvider is implemented by provider that loads owners, privileges and roles. (Option.Privileges and Client.Roles)
+type PrivilegesProvider interface {
+	// AllTablePrivilegesSQL should return SQL for loading all table owners and privileges.
+	// Parameters:
+	//     1. schema
+	// Return columns:
+	//     1. schema
+	//     2. table name
+	//     3. owner
+	//     4. grantee ("PUBLIC" if granted to all roles)
+	//     5. privilege (eg. "SELECT", "INSERT")
+	//     6. grantable ("YES" or "NO")
+	// Order:
+	//     1. table name
+	//     2. grantee
+	//     3. privilege
+	AllTablePrivilegesSQL() string
+	// FilteredTablePrivilegesSQL should return SQL and parameters for loading owners and privileges of tables that match given filter.
+	// Parameters:
+	//     1. schema
+	//     2... returned parameters
+	// Return columns:
+	//     same as AllTablePrivilegesSQL
+	// Order:
+	//     same as AllTablePrivilegesSQL
+	FilteredTablePrivilegesSQL(f TableFilter) (string, []interface{})
+	// TablePrivilegesSQL should return SQL for loading owner and privileges of a table.
+	// Parameters:
+	//     1. schema
+	//     2. table name
+	// Return columns:
+	//     same as AllTablePrivilegesSQL
+	// Order:
+	//     1. grantee
+	//     2. privilege
+	TablePrivilegesSQL() string
+	// AllColumnPrivilegesSQL should return SQL for loading all privileges granted on columns.
+	// Parameters:
+	//     1. schema
+	// Return columns:
+	//     1. schema
+	//     2. table name
+	//     3. column name
+	//     4. grantee ("PUBLIC" if granted to all roles)
+	//     5. privilege (eg. "SELECT", "INSERT")
+	//     6. grantable ("YES" or "NO")
+	// Order:
+	//     1. table name
+	//     2. column position
+	//     3. grantee
+	//     4. privilege
+	AllColumnPrivilegesSQL() string
+	// FilteredColumnPrivilegesSQL should return SQL and parameters for loading privileges granted on columns of tables that match given filter.
+	// Parameters:
+	//     1. schema
+	//     2... returned parameters
+	// Return columns:
+	//     same as AllColumnPrivilegesSQL
+	// Order:
+	//     same as AllColumnPrivilegesSQL
+	FilteredColumnPrivilegesSQL(f TableFilter) (string, []interface{})
+	// ColumnPrivilegesSQL should return SQL for loading privileges granted on columns in a table.
+	// Parameters:
+	//     1. schema
+	//     2. table name
+	// Return columns:
+	//     same as AllColumnPrivilegesSQL
+	// Order:
+	//     1. column position
+	//     2. grantee
+	//     3. privilege
+	ColumnPrivilegesSQL() string
+	// RolesSQL should return SQL for loading all roles.
+	// Parameters:
+	//     nothing
+	// Return columns:
+	//     1. role name
+	//     2. superuser ("YES" or "NO")
+	//     3. inherit ("YES" or "NO")
+	//     4. create role ("YES" or "NO")
+	//     5. create database ("YES" or "NO")
+	//     6. can login ("YES" or "NO")
+	//     7. role names that the role is member of (as JSON array of string)
+	// Order:
+	//     1. role name
+	RolesSQL() string
+}
+
+// PoliciesProvider is implemented by provider that loads row level security settings and policies. (Option.Policies)
+type PoliciesProvider interface {
+	// AllPoliciesSQL should return SQL for loading all row level security settings and policies.
+	// Table that has no policy should be returned with NULL policy columns.
+	// Parameters:
+	//     1. schema
+	// Return columns:
+	//      1. schema
+	//      2. table name
+	//      3. row level security enabled ("YES" or "NO")
+	//      4. row level security forced ("YES" or "NO")
+	//      5. policy name
+	//      6. command ("ALL", "SELECT", "INSERT", "UPDATE" or "DELETE")
+	//      7. permissive ("PERMISSIVE" or "RESTRICTIVE")
+	//      8. role names (as JSON array of string)
+	//      9. USING expression
+	//     10. WITH CHECK expression
+	// Order:
+	//     1. table name
+	//     2. policy name
+	AllPoliciesSQL() string
+	// FilteredPoliciesSQL should return SQL and parameters for loading row level security settings and policies of tables that match given filter.
+	// Parameters:
+	//     1. schema
+	//     2... returned parameters
+	// Return columns:
+	//     same as AllPoliciesSQL
+	// Order:
+	//     same as AllPoliciesSQL
+	FilteredPoliciesSQL(f TableFilter) (string, []interface{})
+	// PoliciesSQL should return SQL for loading row level security settings and policies of a table.
+	// Parameters:
+	//     1. schema
+	//     2. table name
+	// Return columns:
+	//     same as AllPoliciesSQL
+	// Order:
+	//     1. policy name
+	PoliciesSQL() string
+}
+
+// ExtensionsProvider is implemented by provider that loads extensions. (Option.Extensions and Client.Extensions)
+type ExtensionsProvider interface {
+	// ExtensionsSQL should return SQL for loading installed extensions.
+	// Parameters:
+	//     nothing
+	// Return columns:
+	//     1. extension name
+	//     2. version
+	//     3. schema
+	//     4. comment
+	// Order:
+	//     1. extension name
+	ExtensionsSQL() string
+	// ExtensionObjectsSQL should return SQL for loading objects that belong to extensions.
+	// Parameters:
+	//     nothing
+	// Return columns:
+	//     1. extension name
+	//     2. object kind (eg. "TABLE", "TYPE", "FUNCTION")
+	//     3. object schema
+	//     4. object name
+	// Order:
+	//     1. extension name
+	//     2. object kind
+	//     3. object schema
+	//     4. object name
+	ExtensionObjectsSQL() string
+}
+
+// versionDetector is implemented by provider that detects version of database server.
+type versionDetector interface {
+	// detectVersion returns provider that uses detected version and the version.
+	// If version of data source is given, it is used as it is.
+	detectVersion(db *sql.DB) (Provider, string, error)
+}
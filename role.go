@@ -0,0 +1,60 @@
+package dbmodel
+
+// Role is database role (user or group) meta data.
+type Role struct {
+	name       string
+	superuser  bool
+	inherit    bool
+	createRole bool
+	createDB   bool
+	canLogin   bool
+	memberOf   []string
+}
+
+// Name returns role name.
+func (r Role) Name() string {
+	return r.name
+}
+
+// IsSuperuser returns true if role is superuser.
+func (r Role) IsSuperuser() bool {
+	return r.superuser
+}
+
+// Inherits returns true if role inherits privileges of roles it is a member of.
+func (r Role) Inherits() bool {
+	return r.inherit
+}
+
+// CanCreateRole returns true if role can create other roles.
+func (r Role) CanCreateRole() bool {
+	return r.createRole
+}
+
+// CanCreateDB returns true if role can create databases.
+func (r Role) CanCreateDB() bool {
+	return r.createDB
+}
+
+// CanLogin returns true if role can log in. (Role that can log in is user.)
+func (r Role) CanLogin() bool {
+	return r.canLogin
+}
+
+// MemberOf returns role names that this role is a member of.
+func (r Role) MemberOf() []string {
+	return r.memberOf
+}
+
+// NewRole returns new Role initialized with arguments.
+func NewRole(name string, superuser bool, inherit bool, createRole bool, createDB bool, canLogin bool, memberOf []string) Role {
+	return Role{
+		name:       name,
+		superuser:  superuser,
+		inherit:    inherit,
+		createRole: createRole,
+		createDB:   createDB,
+		canLogin:   canLogin,
+		memberOf:   memberOf,
+	}
+}
@@ -0,0 +1,31 @@
+package dbmodel
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewRole(t *testing.T) {
+	r := NewRole("app", false, true, false, true, true, []string{"readers", "writers"})
+	if expected, actual := "app", r.Name(); actual != expected {
+		t.Errorf("Name() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if r.IsSuperuser() {
+		t.Error("Given false, IsSuperuser should return false.")
+	}
+	if !r.Inherits() {
+		t.Error("Given true, Inherits should return true.")
+	}
+	if r.CanCreateRole() {
+		t.Error("Given false, CanCreateRole should return false.")
+	}
+	if !r.CanCreateDB() {
+		t.Error("Given true, CanCreateDB should return true.")
+	}
+	if !r.CanLogin() {
+		t.Error("Given true, CanLogin should return true.")
+	}
+	if expected, actual := []string{"readers", "writers"}, r.MemberOf(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("MemberOf() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
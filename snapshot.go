@@ -0,0 +1,274 @@
+package dbmodel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// SnapshotFormatVersion is format version of snapshot written by this package.
+// It is incremented when format is changed incompatibly.
+const SnapshotFormatVersion = 1
+
+// ErrUnsupportedSnapshot is raised when snapshot is written in newer format than this package supports.
+var ErrUnsupportedSnapshot = errors.New("Snapshot format version is not supported")
+
+// SnapshotOption is loading option that Client.Snapshot uses.
+// Snapshot contains tables of all kinds,
+// but ColumnStats are not contained, because they contain sampled values of columns.
+var SnapshotOption = Option{
+	Indices:        true,
+	ForeignKeys:    true,
+	ReferencedKeys: true,
+	Constraints:    true,
+	Statistics:     true,
+	Privileges:     true,
+	Policies:       true,
+	Extensions:     true,
+	Filter: TableFilter{
+		Kinds: []TableKind{KindTable, KindView, KindMaterializedView, KindForeignTable, KindPartitionedTable},
+	},
+}
+
+// Snapshot is saved meta data of database.
+// Client created by NewSnapshotClient returns meta data from snapshot without database connection.
+type Snapshot struct {
+	formatVersion int
+	database      string
+	serverVersion string
+	tables        []*Table
+	roles         []*Role
+	extensions    []*Extension
+}
+
+// FormatVersion returns format version that snapshot is written in.
+func (s Snapshot) FormatVersion() int {
+	return s.formatVersion
+}
+
+// Database returns database name.
+func (s Snapshot) Database() string {
+	return s.database
+}
+
+// ServerVersion returns database server version given to DataSource.
+func (s Snapshot) ServerVersion() string {
+	return s.serverVersion
+}
+
+// Tables returns tables in snapshot.
+func (s Snapshot) Tables() []*Table {
+	return s.tables
+}
+
+// Roles returns roles in snapshot.
+func (s Snapshot) Roles() []*Role {
+	return s.roles
+}
+
+// Extensions returns extensions in snapshot.
+func (s Snapshot) Extensions() []*Extension {
+	return s.extensions
+}
+
+// NewSnapshot returns new Snapshot in current format version initialized with arguments.
+func NewSnapshot(database string, serverVersion string, tables []*Table, roles []*Role, extensions []*Extension) Snapshot {
+	return Snapshot{
+		formatVersion: SnapshotFormatVersion,
+		database:      database,
+		serverVersion: serverVersion,
+		tables:        tables,
+		roles:         roles,
+		extensions:    extensions,
+	}
+}
+
+// WriteSnapshot writes snapshot as indented JSON.
+func WriteSnapshot(w io.Writer, s *Snapshot) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// ReadSnapshot reads snapshot written by WriteSnapshot.
+// If snapshot is written in newer format, raise ErrUnsupportedSnapshot.
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	var s Snapshot
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, err
+	}
+	if s.formatVersion < 1 {
+		return nil, fmt.Errorf("Snapshot format version '%v' is invalid.", s.formatVersion)
+	}
+	if s.formatVersion > SnapshotFormatVersion {
+		return nil, ErrUnsupportedSnapshot
+	}
+	for i, tbl := range s.tables {
+		if tbl == nil {
+			return nil, fmt.Errorf("Table is empty at index %v of snapshot.", i)
+		}
+	}
+	for i, role := range s.roles {
+		if role == nil {
+			return nil, fmt.Errorf("Role is empty at index %v of snapshot.", i)
+		}
+	}
+	for i, ext := range s.extensions {
+		if ext == nil {
+			return nil, fmt.Errorf("Extension is empty at index %v of snapshot.", i)
+		}
+	}
+	LinkTables(s.tables)
+	return &s, nil
+}
+
+// NewSnapshotClient returns new Client that returns meta data from given snapshot.
+// Returned client does not need Connect, and Disconnect does nothing.
+func NewSnapshotClient(s *Snapshot) *Client {
+	return &Client{
+		snapshot:    s,
+		concurrency: DefaultConcurrency,
+	}
+}
+
+// Snapshot loads tables in given schemas with SnapshotOption, roles and extensions.
+// If schemas are not given, raise ErrSchemaEmpty.
+func (c *Client) Snapshot(schemas ...string) (*Snapshot, error) {
+	if len(schemas) == 0 {
+		return nil, ErrSchemaEmpty
+	}
+
+	tbls := make([]*Table, 0, 10)
+	for _, schema := range schemas {
+		ts, err := c.AllTables(schema, SnapshotOption)
+		if err != nil {
+			return nil, err
+		}
+		tbls = append(tbls, ts...)
+	}
+	roles, err := c.Roles()
+	if err != nil {
+		return nil, err
+	}
+	exts, err := c.Extensions()
+	if err != nil {
+		return nil, err
+	}
+
+	s := NewSnapshot(c.dataSource.Database, c.dataSource.Version, tbls, roles, exts)
+	if c.snapshot != nil {
+		s.database = c.snapshot.database
+		s.serverVersion = c.snapshot.serverVersion
+	}
+	return &s, nil
+}
+
+func (s *Snapshot) tableNames(schema string, m NameMatcher) ([]*Table, error) {
+	match, err := m.Matcher()
+	if err != nil {
+		return nil, err
+	}
+	tbls := make([]*Table, 0, len(s.tables))
+	for _, tbl := range s.tables {
+		if tbl.Schema() != schema || tbl.Kind() != KindTable {
+			continue
+		}
+		if m.Pattern != "" && !match(tbl.Name()) {
+			continue
+		}
+		t := NewTable(tbl.Schema(), tbl.Name(), tbl.Comment())
+		tbls = append(tbls, &t)
+	}
+	return tbls, nil
+}
+
+func (s *Snapshot) loadTables(schema string, opt Option) ([]*Table, error) {
+	match, err := opt.Filter.Matcher()
+	if err != nil {
+		return nil, err
+	}
+	found := make([]*Table, 0, len(s.tables))
+	for _, tbl := range s.tables {
+		if tbl.Schema() == schema && match(tbl) {
+			found = append(found, tbl)
+		}
+	}
+
+	b, err := json.Marshal(found)
+	if err != nil {
+		return nil, err
+	}
+	var tbls []*Table
+	if err = json.Unmarshal(b, &tbls); err != nil {
+		return nil, err
+	}
+	for _, tbl := range tbls {
+		restrictTable(tbl, opt)
+	}
+	linkTables(tbls)
+	return tbls, nil
+}
+
+func (s *Snapshot) extensionsCopy() ([]*Extension, error) {
+	b, err := json.Marshal(s.extensions)
+	if err != nil {
+		return nil, err
+	}
+	exts := make([]*Extension, 0, len(s.extensions))
+	err = json.Unmarshal(b, &exts)
+	return exts, err
+}
+
+func (s *Snapshot) rolesCopy() ([]*Role, error) {
+	b, err := json.Marshal(s.roles)
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]*Role, 0, len(s.roles))
+	err = json.Unmarshal(b, &roles)
+	return roles, err
+}
+
+// restrictTable clears meta data that is not required by opt,
+// so that tables from snapshot are same as tables loaded with opt.
+func restrictTable(tbl *Table, opt Option) {
+	if !opt.Indices {
+		tbl.indices = make([]*Index, 0, 5)
+	}
+	if !opt.ForeignKeys {
+		tbl.foreignKeys = make([]*ForeignKey, 0, 5)
+	}
+	if !opt.ReferencedKeys {
+		tbl.refKeys = make([]*ForeignKey, 0, 5)
+	}
+	if !opt.Constraints {
+		tbl.constraints = make([]*Constraint, 0, 5)
+	}
+	if !opt.Statistics {
+		tbl.stats = nil
+		for _, idx := range tbl.indices {
+			idx.stats = nil
+		}
+	}
+	for _, col := range tbl.columns {
+		if !opt.ColumnStatistics {
+			col.stats = nil
+		}
+		if !opt.Privileges {
+			col.grants = nil
+		}
+	}
+	if !opt.Privileges {
+		tbl.owner = ""
+		tbl.grants = nil
+	}
+	if !opt.Policies {
+		tbl.rowSecurity = false
+		tbl.forceRLS = false
+		tbl.policies = nil
+	}
+	if !opt.Extensions {
+		tbl.extension = ""
+	}
+}
@@ -0,0 +1,226 @@
+package dbmodel
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNewSnapshot(t *testing.T) {
+	s := newTestSnapshot()
+	if expected, actual := SnapshotFormatVersion, s.FormatVersion(); actual != expected {
+		t.Errorf("FormatVersion() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := "sample", s.Database(); actual != expected {
+		t.Errorf("Database() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := "9.6", s.ServerVersion(); actual != expected {
+		t.Errorf("ServerVersion() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if len(s.Tables()) != 3 || len(s.Roles()) != 1 || len(s.Extensions()) != 1 {
+		t.Errorf("Snapshot has invalid contents. (%#v)", s)
+	}
+}
+
+func TestSnapshotWriteAndRead(t *testing.T) {
+	s := newTestSnapshot()
+	var buf bytes.Buffer
+	if err := WriteSnapshot(&buf, s); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"format_version": 1`) {
+		t.Errorf("Written snapshot should contain format version.\n%v", buf.String())
+	}
+	read, err := ReadSnapshot(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Database() != "sample" || len(read.Tables()) != 3 || read.Roles()[0].Name() != "app" {
+		t.Errorf("ReadSnapshot returns invalid snapshot. (%#v)", read)
+	}
+	pst := read.Tables()[1]
+	if pst.ForeignKeys()[0].ReferencedTable() != read.Tables()[0] {
+		t.Error("Tables in read snapshot should be linked.")
+	}
+}
+
+func TestReadSnapshotWithUnsupportedVersion(t *testing.T) {
+	_, err := ReadSnapshot(strings.NewReader(`{"format_version": 2, "tables": []}`))
+	if err != ErrUnsupportedSnapshot {
+		t.Errorf("ReadSnapshot should raise ErrUnsupportedSnapshot. actual: %v", err)
+	}
+	if _, err = ReadSnapshot(strings.NewReader(`{"tables": []}`)); err == nil {
+		t.Error("ReadSnapshot should raise error when format version is missing.")
+	}
+}
+
+func TestReadSnapshotWithNullEntries(t *testing.T) {
+	cases := []struct {
+		src      string
+		expected string
+	}{
+		{`{"format_version": 1, "tables": [null]}`, "Table is empty at index 0 of snapshot."},
+		{`{"format_version": 1, "roles": [null]}`, "Role is empty at index 0 of snapshot."},
+		{`{"format_version": 1, "extensions": [null]}`, "Extension is empty at index 0 of snapshot."},
+		{`{"format_version": 1, "tables": [{"name": "t", "foreign_keys": [{"name": "f", "column_references": [null]}]}]}`, "Column reference is empty at index 0 of foreign key 'f'."},
+	}
+	for _, c := range cases {
+		_, err := ReadSnapshot(strings.NewReader(c.src))
+		if err == nil {
+			t.Errorf("ReadSnapshot should raise error on %v.", c.src)
+			continue
+		}
+		if actual := err.Error(); actual != c.expected {
+			t.Errorf("ReadSnapshot returns invalid error. expected: %v, actual: %v", c.expected, actual)
+		}
+	}
+}
+
+func TestSnapshotClientAllTables(t *testing.T) {
+	c := NewSnapshotClient(newTestSnapshot())
+	c.Connect()
+	defer c.Disconnect()
+
+	tbls, err := c.AllTables("foo", RequireAll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertTableNames(t, tbls, "users", "posts")
+	if tbls[1].ForeignKeys()[0].ReferencedTable() != tbls[0] {
+		t.Error("Returned tables should be linked.")
+	}
+	if tbls[0] == c.snapshot.Tables()[0] {
+		t.Error("Returned tables should be copied from snapshot.")
+	}
+}
+
+func TestSnapshotClientAllTablesRestrictsByOption(t *testing.T) {
+	c := NewSnapshotClient(newTestSnapshot())
+	tbls, err := c.AllTables("foo", Option{Filter: TableFilter{Names: []string{"posts"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertTableNames(t, tbls, "posts")
+	if len(tbls[0].ForeignKeys()) != 0 || len(tbls[0].Indices()) != 0 || tbls[0].Owner() != "" {
+		t.Errorf("Not required meta data should be cleared. (%#v)", tbls[0])
+	}
+	fks := c.snapshot.Tables()[1].ForeignKeys()
+	if len(fks) != 1 {
+		t.Error("Snapshot should not be changed.")
+	}
+}
+
+func TestSnapshotClientTable(t *testing.T) {
+	c := NewSnapshotClient(newTestSnapshot())
+	tbl, err := c.Table("foo", "users", RequireNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected, actual := "users", tbl.Name(); actual != expected {
+		t.Errorf("Name() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if _, err = c.Table("foo", "active_users", RequireNone); err == nil {
+		t.Error("Table should raise error when table is not found.")
+	}
+}
+
+func TestSnapshotClientTableNames(t *testing.T) {
+	c := NewSnapshotClient(newTestSnapshot())
+	tbls, err := c.AllTableNames("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertTableNames(t, tbls, "users", "posts")
+	tbls, err = c.TableNames("foo", NamePrefix("po"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertTableNames(t, tbls, "posts")
+	if len(tbls[0].Columns()) != 0 {
+		t.Error("TableNames should return only table names and comments.")
+	}
+}
+
+func TestSnapshotClientWithInvalidPattern(t *testing.T) {
+	c := NewSnapshotClient(newTestSnapshot())
+	if _, err := c.TableNames("foo", NameRegexp("(")); err == nil {
+		t.Error("TableNames should raise error when pattern is invalid.")
+	}
+	opt := Option{Filter: TableFilter{Includes: []string{"("}, Regexp: true}}
+	if _, err := c.AllTables("foo", opt); err == nil {
+		t.Error("AllTables should raise error when pattern of filter is invalid.")
+	}
+}
+
+func TestSnapshotClientRolesAndExtensions(t *testing.T) {
+	c := NewSnapshotClient(newTestSnapshot())
+	roles, err := c.Roles()
+	if err != nil || len(roles) != 1 || roles[0].Name() != "app" {
+		t.Errorf("Roles returns invalid value. (%v, %v)", roles, err)
+	}
+	exts, err := c.Extensions()
+	if err != nil || len(exts) != 1 || exts[0].Name() != "pg_trgm" {
+		t.Errorf("Extensions returns invalid value. (%v, %v)", exts, err)
+	}
+}
+
+func TestSnapshotClientSnapshot(t *testing.T) {
+	c := NewSnapshotClient(newTestSnapshot())
+	s, err := c.Snapshot("foo", "bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Database() != "sample" || s.ServerVersion() != "9.6" {
+		t.Errorf("Snapshot should keep database and server version. (%#v)", s)
+	}
+	if expected, actual := 3, len(s.Tables()); actual != expected {
+		t.Errorf("Table count is invalid. expected: %v, actual: %v", expected, actual)
+	}
+	if _, err = c.Snapshot(); err != ErrSchemaEmpty {
+		t.Errorf("Snapshot should raise ErrSchemaEmpty when schemas are not given. actual: %v", err)
+	}
+}
+
+func newTestSnapshot() *Snapshot {
+	db, err := LoadYAML(strings.NewReader(`
+schemas:
+  - name: foo
+    tables:
+      - name: users
+        columns:
+          - name: id
+            type: integer
+            primary_key: 1
+        indices:
+          - name: users_pkey
+            unique: true
+            columns: [id]
+      - name: posts
+        columns:
+          - name: id
+            type: integer
+            primary_key: 1
+          - name: user_id
+            type: integer
+        foreign_keys:
+          - name: posts_user_id_fkey
+            columns: [user_id]
+            ref_table: users
+            ref_columns: [id]
+  - name: bar
+    tables:
+      - name: active_users
+        kind: VIEW
+        columns:
+          - name: id
+            type: integer
+`))
+	if err != nil {
+		panic(err)
+	}
+	db.Tables()[1].SetOwner("app")
+	role := NewRole("app", false, true, false, false, true, nil)
+	ext := NewExtension("pg_trgm", "1.3", "public", "")
+	s := NewSnapshot("sample", "9.6", db.Tables(), []*Role{&role}, []*Extension{&ext})
+	return &s
+}
@@ -1,15 +1,39 @@
 package dbmodel
 
+// TableKind is kind of table.
+type TableKind string
+
+const (
+	// KindTable is ordinary table.
+	KindTable TableKind = "TABLE"
+	// KindView is view.
+	KindView TableKind = "VIEW"
+	// KindMaterializedView is materialized view.
+	KindMaterializedView TableKind = "MATERIALIZED VIEW"
+	// KindForeignTable is foreign table.
+	KindForeignTable TableKind = "FOREIGN TABLE"
+	// KindPartitionedTable is partitioned table.
+	KindPartitionedTable TableKind = "PARTITIONED TABLE"
+)
+
 // Table stores table meta data.
 type Table struct {
 	schema      string
 	name        string
 	comment     string
+	kind        TableKind
 	columns     []*Column
 	indices     []*Index
 	foreignKeys []*ForeignKey
 	refKeys     []*ForeignKey
 	constraints []*Constraint
+	stats       *TableStats
+	owner       string
+	grants      []*Grant
+	rowSecurity bool
+	forceRLS    bool
+	policies    []*Policy
+	extension   string
 }
 
 // Schema returns table schema.
@@ -27,6 +51,11 @@ func (t Table) Comment() string {
 	return t.comment
 }
 
+// Kind returns table kind.
+func (t Table) Kind() TableKind {
+	return t.kind
+}
+
 // Columns returns having columns.
 func (t Table) Columns() []*Column {
 	return t.columns
@@ -52,12 +81,74 @@ func (t Table) Constraints() []*Constraint {
 	return t.constraints
 }
 
+// ForeignKeyTargets returns tables that are referenced by having foreign keys.
+// Each table appears once in foreign key order, and tables that are not loaded together are not contained.
+func (t Table) ForeignKeyTargets() []*Table {
+	tbls := make([]*Table, 0, len(t.foreignKeys))
+	for _, fk := range t.foreignKeys {
+		ref := fk.ReferencedTable()
+		if ref == nil {
+			continue
+		}
+		found := false
+		for _, tbl := range tbls {
+			if tbl == ref {
+				found = true
+				break
+			}
+		}
+		if !found {
+			tbls = append(tbls, ref)
+		}
+	}
+	return tbls
+}
+
+// Owner returns role name that owns this table.
+// If privileges are not loaded, Owner returns empty.
+func (t Table) Owner() string {
+	return t.owner
+}
+
+// Grants returns privileges granted on this table.
+func (t Table) Grants() []*Grant {
+	return t.grants
+}
+
+// RowSecurityEnabled returns true if row level security is enabled on this table.
+func (t Table) RowSecurityEnabled() bool {
+	return t.rowSecurity
+}
+
+// RowSecurityForced returns true if row level security applies to table owner too.
+func (t Table) RowSecurityForced() bool {
+	return t.forceRLS
+}
+
+// Policies returns row level security policies.
+func (t Table) Policies() []*Policy {
+	return t.policies
+}
+
+// Extension returns extension name that this table belongs to.
+// If table does not belong to extension or extensions are not loaded, Extension returns empty.
+func (t Table) Extension() string {
+	return t.extension
+}
+
+// Stats returns table statistics.
+// If statistics are not loaded, Stats returns nil.
+func (t Table) Stats() *TableStats {
+	return t.stats
+}
+
 // NewTable returns new Table initialized with arguments.
 func NewTable(schema string, tableName string, comment string) Table {
 	return Table{
 		schema:      schema,
 		name:        tableName,
 		comment:     comment,
+		kind:        KindTable,
 		columns:     make([]*Column, 0, 10),
 		indices:     make([]*Index, 0, 5),
 		foreignKeys: make([]*ForeignKey, 0, 5),
@@ -66,6 +157,42 @@ func NewTable(schema string, tableName string, comment string) Table {
 	}
 }
 
+// SetKind sets table kind.
+func (t *Table) SetKind(kind TableKind) {
+	t.kind = kind
+}
+
+// SetStats sets table statistics.
+func (t *Table) SetStats(s *TableStats) {
+	t.stats = s
+}
+
+// SetOwner sets role name that owns this table.
+func (t *Table) SetOwner(owner string) {
+	t.owner = owner
+}
+
+// AddGrant appends privilege to Grants.
+func (t *Table) AddGrant(g *Grant) {
+	t.grants = append(t.grants, g)
+}
+
+// SetRowSecurity sets whether row level security is enabled and forced.
+func (t *Table) SetRowSecurity(enabled bool, forced bool) {
+	t.rowSecurity = enabled
+	t.forceRLS = forced
+}
+
+// AddPolicy appends row level security policy to Policies.
+func (t *Table) AddPolicy(p *Policy) {
+	t.policies = append(t.policies, p)
+}
+
+// SetExtension sets extension name that this table belongs to.
+func (t *Table) SetExtension(name string) {
+	t.extension = name
+}
+
 // AddColumn appends column to Columns.
 func (t *Table) AddColumn(col *Column) {
 	col.schema = t.schema
@@ -143,6 +270,17 @@ func (t *Table) FindReferencedKey(name string) (*ForeignKey, bool) {
 	return nil, false
 }
 
+// FindPolicy returns policy that has same name as argument.
+// If policy that has same name does not exist, return false as second value.
+func (t *Table) FindPolicy(name string) (*Policy, bool) {
+	for _, p := range t.Policies() {
+		if p.Name() == name {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
 // FindConstraint returns constraint that has same name as argument.
 // If constraint that has same name does not exist, return false as second value.
 func (t *Table) FindConstraint(name string) (*Constraint, bool) {
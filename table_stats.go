@@ -0,0 +1,64 @@
+package dbmodel
+
+import "time"
+
+// TableStats is table statistics estimated by database.
+type TableStats struct {
+	estimatedRows int64
+	totalBytes    int64
+	heapBytes     int64
+	toastBytes    int64
+	indexBytes    int64
+	lastVacuum    time.Time
+	lastAnalyze   time.Time
+}
+
+// EstimatedRows returns estimated row count.
+func (s TableStats) EstimatedRows() int64 {
+	return s.estimatedRows
+}
+
+// TotalBytes returns total size of table contains toast and indices.
+func (s TableStats) TotalBytes() int64 {
+	return s.totalBytes
+}
+
+// HeapBytes returns size of table's main data.
+func (s TableStats) HeapBytes() int64 {
+	return s.heapBytes
+}
+
+// ToastBytes returns size of table's toast data.
+func (s TableStats) ToastBytes() int64 {
+	return s.toastBytes
+}
+
+// IndexBytes returns total size of table's indices.
+func (s TableStats) IndexBytes() int64 {
+	return s.indexBytes
+}
+
+// LastVacuum returns last time that table is vacuumed manually or automatically.
+// If table has never been vacuumed, LastVacuum returns zero time.
+func (s TableStats) LastVacuum() time.Time {
+	return s.lastVacuum
+}
+
+// LastAnalyze returns last time that table is analyzed manually or automatically.
+// If table has never been analyzed, LastAnalyze returns zero time.
+func (s TableStats) LastAnalyze() time.Time {
+	return s.lastAnalyze
+}
+
+// NewTableStats returns new TableStats initialized with arguments.
+func NewTableStats(estimatedRows int64, totalBytes int64, heapBytes int64, toastBytes int64, indexBytes int64, lastVacuum time.Time, lastAnalyze time.Time) TableStats {
+	return TableStats{
+		estimatedRows: estimatedRows,
+		totalBytes:    totalBytes,
+		heapBytes:     heapBytes,
+		toastBytes:    toastBytes,
+		indexBytes:    indexBytes,
+		lastVacuum:    lastVacuum,
+		lastAnalyze:   lastAnalyze,
+	}
+}
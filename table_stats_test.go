@@ -0,0 +1,45 @@
+package dbmodel
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewTableStats(t *testing.T) {
+	vacuumed := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	analyzed := time.Date(2016, 10, 2, 12, 0, 0, 0, time.UTC)
+	s := NewTableStats(100, 65536, 16384, 8192, 40960, vacuumed, analyzed)
+	if expected, actual := int64(100), s.EstimatedRows(); actual != expected {
+		t.Errorf("EstimatedRows() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := int64(65536), s.TotalBytes(); actual != expected {
+		t.Errorf("TotalBytes() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := int64(16384), s.HeapBytes(); actual != expected {
+		t.Errorf("HeapBytes() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := int64(8192), s.ToastBytes(); actual != expected {
+		t.Errorf("ToastBytes() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := int64(40960), s.IndexBytes(); actual != expected {
+		t.Errorf("IndexBytes() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := vacuumed, s.LastVacuum(); !actual.Equal(expected) {
+		t.Errorf("LastVacuum() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := analyzed, s.LastAnalyze(); !actual.Equal(expected) {
+		t.Errorf("LastAnalyze() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestSetStatsToTable(t *testing.T) {
+	tbl := newUserTable()
+	if tbl.Stats() != nil {
+		t.Error("Stats() should return nil when statistics are not loaded.")
+	}
+	s := NewTableStats(1, 0, 0, 0, 0, time.Time{}, time.Time{})
+	tbl.SetStats(&s)
+	if tbl.Stats() != &s {
+		t.Error("Stats() should return set statistics.")
+	}
+}
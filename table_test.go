@@ -5,6 +5,17 @@ import (
 	"testing"
 )
 
+func TestTableKind(t *testing.T) {
+	tbl := newUserTable()
+	if tbl.Kind() != KindTable {
+		t.Errorf("Kind() should return KindTable by default. actual: %v", tbl.Kind())
+	}
+	tbl.SetKind(KindView)
+	if tbl.Kind() != KindView {
+		t.Errorf("Kind() returns invalid value. expected: %v, actual: %v", KindView, tbl.Kind())
+	}
+}
+
 func TestAddColumnToTable(t *testing.T) {
 	tbl := newUserTable()
 	if len(tbl.Columns()) != 0 {
@@ -219,6 +230,60 @@ func TestFindConstraint(t *testing.T) {
 	}
 }
 
+func TestForeignKeyTargets(t *testing.T) {
+	usr, pst := newLinkTestTables()
+	if len(pst.ForeignKeyTargets()) != 0 {
+		t.Error("ForeignKeyTargets() should be empty before tables are linked.")
+	}
+	fk := NewForeignKey("foo", "posts", "posts_user_id2")
+	cr := NewColumnReference(
+		&Column{schema: "foo", tableName: "posts", name: "user_id"},
+		&Column{schema: "foo", tableName: "users", name: "id"})
+	fk.AddColumnReference(&cr)
+	pst.AddForeignKey(&fk)
+	linkTables([]*Table{usr, pst})
+	if actual, expected := len(pst.ForeignKeyTargets()), 1; actual != expected {
+		t.Errorf("ForeignKeyTargets() should not contain duplicated table. expected: %v, actual: %v", expected, actual)
+		return
+	}
+	if pst.ForeignKeyTargets()[0] != usr {
+		t.Errorf("ForeignKeyTargets() returns invalid table. (%#v)", pst.ForeignKeyTargets()[0])
+	}
+}
+
+func TestTableOwnerAndGrants(t *testing.T) {
+	tbl := newUserTable()
+	tbl.SetOwner("admin")
+	if expected, actual := "admin", tbl.Owner(); actual != expected {
+		t.Errorf("Owner() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	g := NewGrant("app", "SELECT", false)
+	tbl.AddGrant(&g)
+	if len(tbl.Grants()) != 1 || tbl.Grants()[0] != &g {
+		t.Errorf("Failed to add grant. (%#v)", tbl.Grants())
+	}
+}
+
+func TestTableRowSecurity(t *testing.T) {
+	tbl := newUserTable()
+	if tbl.RowSecurityEnabled() || tbl.RowSecurityForced() {
+		t.Error("Row level security should be disabled by default.")
+	}
+	tbl.SetRowSecurity(true, false)
+	if !tbl.RowSecurityEnabled() || tbl.RowSecurityForced() {
+		t.Error("SetRowSecurity sets invalid value.")
+	}
+	p := NewPolicy("users_self", "ALL", true, []string{"PUBLIC"}, "(name = CURRENT_USER)", "")
+	tbl.AddPolicy(&p)
+	fp, ok := tbl.FindPolicy("users_self")
+	if !ok || fp != &p {
+		t.Error("FindPolicy should return users_self policy.")
+	}
+	if _, ok = tbl.FindPolicy("users_other"); ok {
+		t.Error("FindPolicy should return false as second value when given not having policy name.")
+	}
+}
+
 func newUserTable() *Table {
 	table := NewTable("foo", "users", "")
 	return &table
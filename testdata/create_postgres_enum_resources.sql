@@ -0,0 +1,9 @@
+-- Enum types
+CREATE SCHEMA enums;
+
+CREATE TYPE enums.mood AS ENUM ('happy', 'sad');
+
+CREATE TABLE enums.diaries (
+    id serial NOT NULL PRIMARY KEY
+  , mood enums.mood NOT NULL
+);
@@ -0,0 +1,13 @@
+-- Tables that belong to extension
+-- plpgsql is installed by default, so that table is added to it as its member.
+CREATE SCHEMA extmembers;
+
+CREATE TABLE extmembers.members (
+    id integer NOT NULL PRIMARY KEY
+);
+
+CREATE TABLE extmembers.others (
+    id integer NOT NULL PRIMARY KEY
+);
+
+ALTER EXTENSION plpgsql ADD TABLE extmembers.members;
@@ -43,6 +43,12 @@ CREATE TABLE tbl3 (
 );
 CREATE INDEX tbl3_idx1 ON tbl3(tbl2_id);
 
+GRANT SELECT ON tbl1 TO PUBLIC;
+GRANT SELECT (idx_key), UPDATE (idx_key) ON tbl2 TO PUBLIC;
+REVOKE ALL ON tbl3 FROM postgres;
+
+CREATE VIEW view1 AS SELECT id, tbl1_id FROM tbl2;
+
 CREATE TABLE other.tbl_other (
     col1 integer
   , col2 domain1
@@ -0,0 +1,19 @@
+-- Row level security (PostgreSQL 9.5 or later)
+CREATE SCHEMA rls;
+
+SET search_path TO rls;
+
+CREATE TABLE tenants (
+    id serial NOT NULL PRIMARY KEY
+  , tenant_id integer NOT NULL
+  , name text
+);
+ALTER TABLE tenants ENABLE ROW LEVEL SECURITY;
+ALTER TABLE tenants FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY tenants_insert ON tenants FOR INSERT TO PUBLIC WITH CHECK (tenant_id > 0);
+CREATE POLICY tenants_select ON tenants FOR SELECT USING (tenant_id = 1);
+
+CREATE TABLE plain (
+    id integer
+);
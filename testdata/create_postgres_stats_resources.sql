@@ -0,0 +1,9 @@
+-- Column statistics
+CREATE SCHEMA stats;
+
+CREATE TABLE stats.samples (
+    id serial NOT NULL PRIMARY KEY
+  , category integer NOT NULL
+);
+
+INSERT INTO stats.samples (category) SELECT i % 3 + 1 FROM generate_series(1, 100) i;
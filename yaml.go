@@ -0,0 +1,307 @@
+package dbmodel
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+type yamlDatabase struct {
+	Name    string        `yaml:"name,omitempty"`
+	Schemas []*yamlSchema `yaml:"schemas"`
+}
+
+type yamlSchema struct {
+	Name   string       `yaml:"name"`
+	Tables []*yamlTable `yaml:"tables"`
+}
+
+type yamlTable struct {
+	Name        string            `yaml:"name"`
+	Comment     string            `yaml:"comment,omitempty"`
+	Kind        TableKind         `yaml:"kind,omitempty"`
+	Columns     []*yamlColumn     `yaml:"columns"`
+	Indices     []*yamlIndex      `yaml:"indices,omitempty"`
+	ForeignKeys []*yamlForeignKey `yaml:"foreign_keys,omitempty"`
+	Constraints []*yamlConstraint `yaml:"constraints,omitempty"`
+}
+
+type yamlColumn struct {
+	Name         string `yaml:"name"`
+	DataType     string `yaml:"type"`
+	Length       *int64 `yaml:"length,omitempty"`
+	Precision    *int64 `yaml:"precision,omitempty"`
+	Scale        *int64 `yaml:"scale,omitempty"`
+	Nullable     *bool  `yaml:"nullable,omitempty"`
+	DefaultValue string `yaml:"default,omitempty"`
+	PrimaryKey   int64  `yaml:"primary_key,omitempty"`
+	Comment      string `yaml:"comment,omitempty"`
+}
+
+type yamlIndex struct {
+	Name    string   `yaml:"name"`
+	Unique  bool     `yaml:"unique,omitempty"`
+	Columns []string `yaml:"columns,flow"`
+}
+
+type yamlForeignKey struct {
+	Name       string   `yaml:"name"`
+	Columns    []string `yaml:"columns,flow"`
+	RefSchema  string   `yaml:"ref_schema,omitempty"`
+	RefTable   string   `yaml:"ref_table"`
+	RefColumns []string `yaml:"ref_columns,flow"`
+}
+
+type yamlConstraint struct {
+	Name    string `yaml:"name"`
+	Kind    string `yaml:"kind"`
+	Content string `yaml:"content"`
+}
+
+// LoadYAML reads schema definition written in YAML and builds tables.
+// Built tables are linked each other as well as tables returned by Client.AllTables,
+// and referenced keys are derived from foreign keys.
+//
+//	name: sample
+//	schemas:
+//	  - name: public
+//	    tables:
+//	      - name: users
+//	        comment: user accounts
+//	        columns:
+//	          - name: id
+//	            type: integer
+//	            precision: 32
+//	            scale: 0
+//	            primary_key: 1
+//	          - name: email
+//	            type: character varying
+//	            length: 255
+//	            nullable: false
+//	            comment: login id
+//	        indices:
+//	          - name: users_email_key
+//	            unique: true
+//	            columns: [email]
+//	      - name: posts
+//	        columns:
+//	          - name: id
+//	            type: integer
+//	            primary_key: 1
+//	          - name: user_id
+//	            type: integer
+//	        foreign_keys:
+//	          - name: posts_user_id_fkey
+//	            columns: [user_id]
+//	            ref_table: users
+//	            ref_columns: [id]
+//	        constraints:
+//	          - name: posts_user_id_check
+//	            kind: CHECK
+//	            content: (user_id > 0)
+//
+// Content of CHECK constraint is expression in parentheses as well as constraint loaded from database. (eg. "(user_id > 0)")
+// Leading CHECK keyword is removed, so that "CHECK (user_id > 0)" is loaded as "(user_id > 0)".
+// Column is nullable unless nullable is false or primary_key is given.
+// ref_schema of foreign key defaults to schema of table.
+// kind of table defaults to TABLE.
+func LoadYAML(r io.Reader) (*Database, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var yd yamlDatabase
+	if err = yaml.UnmarshalStrict(b, &yd); err != nil {
+		return nil, err
+	}
+
+	db := NewDatabase(yd.Name)
+	fks := make([]*ForeignKey, 0, 10)
+	for i, ys := range yd.Schemas {
+		if ys == nil {
+			return nil, fmt.Errorf("Schema is empty at index %v.", i)
+		}
+		if ys.Name == "" {
+			return nil, ErrSchemaEmpty
+		}
+		for j, yt := range ys.Tables {
+			if yt == nil {
+				return nil, fmt.Errorf("Table is empty at index %v of schema '%v'.", j, ys.Name)
+			}
+			tbl, err := yt.table(ys.Name)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := db.FindTable(tbl.Schema(), tbl.Name()); ok {
+				return nil, fmt.Errorf("Table '%v.%v' is duplicated.", tbl.Schema(), tbl.Name())
+			}
+			db.AddTable(tbl)
+			fks = append(fks, tbl.ForeignKeys()...)
+		}
+	}
+	for _, fk := range fks {
+		to := fk.ColumnReferences()[0].To()
+		ref, ok := db.FindTable(to.Schema(), to.TableName())
+		if !ok {
+			return nil, fmt.Errorf("Table '%v.%v' referenced by '%v' is not found.", to.Schema(), to.TableName(), fk.Name())
+		}
+		for _, cr := range fk.ColumnReferences() {
+			if _, ok := ref.FindColumn(cr.To().Name()); !ok {
+				return nil, fmt.Errorf("Column '%v' referenced by '%v' is not found.", cr.To().Name(), fk.Name())
+			}
+		}
+		ref.AddReferencedKey(fk)
+	}
+	linkTables(db.Tables())
+	return &db, nil
+}
+
+// WriteYAML writes tables of database in format that LoadYAML reads.
+// Values that are not in the format (statistics, privileges and so on) are not written.
+func WriteYAML(w io.Writer, db *Database) error {
+	yd := yamlDatabase{Name: db.Name()}
+	for _, schema := range db.Schemas() {
+		ys := &yamlSchema{Name: schema}
+		for _, tbl := range db.SchemaTables(schema) {
+			ys.Tables = append(ys.Tables, newYAMLTable(tbl))
+		}
+		yd.Schemas = append(yd.Schemas, ys)
+	}
+	b, err := yaml.Marshal(yd)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+func (yt *yamlTable) table(schema string) (*Table, error) {
+	if yt.Name == "" {
+		return nil, ErrTableNameEmpty
+	}
+	tbl := NewTable(schema, yt.Name, yt.Comment)
+	if yt.Kind != "" {
+		tbl.SetKind(yt.Kind)
+	}
+	for i, yc := range yt.Columns {
+		if yc == nil {
+			return nil, fmt.Errorf("Column is empty at index %v of table '%v.%v'.", i, schema, yt.Name)
+		}
+		nullable := yc.PrimaryKey == 0
+		if yc.Nullable != nil {
+			nullable = *yc.Nullable
+		}
+		size := NewSize(nullInt64(yc.Length), nullInt64(yc.Precision), nullInt64(yc.Scale))
+		col := NewColumn(schema, yt.Name, yc.Name, yc.Comment, yc.DataType, size, nullable, yc.DefaultValue, yc.PrimaryKey)
+		tbl.AddColumn(&col)
+	}
+	for i, yi := range yt.Indices {
+		if yi == nil {
+			return nil, fmt.Errorf("Index is empty at index %v of table '%v.%v'.", i, schema, yt.Name)
+		}
+		idx := NewIndex(schema, yt.Name, yi.Name, yi.Unique)
+		for _, name := range yi.Columns {
+			col, ok := tbl.FindColumn(name)
+			if !ok {
+				return nil, fmt.Errorf("Column '%v' of index '%v' is not found.", name, yi.Name)
+			}
+			idx.AddColumn(col)
+		}
+		tbl.AddIndex(&idx)
+	}
+	for i, yf := range yt.ForeignKeys {
+		if yf == nil {
+			return nil, fmt.Errorf("Foreign key is empty at index %v of table '%v.%v'.", i, schema, yt.Name)
+		}
+		if len(yf.Columns) == 0 || len(yf.Columns) != len(yf.RefColumns) {
+			return nil, fmt.Errorf("Columns and ref_columns of foreign key '%v' are not paired.", yf.Name)
+		}
+		refSchema := yf.RefSchema
+		if refSchema == "" {
+			refSchema = schema
+		}
+		fk := NewForeignKey(schema, yt.Name, yf.Name)
+		for i, name := range yf.Columns {
+			col, ok := tbl.FindColumn(name)
+			if !ok {
+				return nil, fmt.Errorf("Column '%v' of foreign key '%v' is not found.", name, yf.Name)
+			}
+			cr := NewColumnReference(col, placeholderColumn(refSchema, yf.RefTable, yf.RefColumns[i]))
+			fk.AddColumnReference(&cr)
+		}
+		tbl.AddForeignKey(&fk)
+	}
+	for i, yc := range yt.Constraints {
+		if yc == nil {
+			return nil, fmt.Errorf("Constraint is empty at index %v of table '%v.%v'.", i, schema, yt.Name)
+		}
+		con := NewConstraint(schema, yt.Name, yc.Name, yc.Kind, constraintContent(yc.Kind, yc.Content))
+		tbl.AddConstraint(&con)
+	}
+	return &tbl, nil
+}
+
+var checkKeyword = regexp.MustCompile(`(?i)^CHECK\s*\(`)
+
+// constraintContent returns content of CHECK constraint without leading CHECK keyword.
+// Content of other kinds is returned as it is.
+func constraintContent(kind string, content string) string {
+	content = strings.TrimSpace(content)
+	if strings.ToUpper(kind) != "CHECK" || !checkKeyword.MatchString(content) {
+		return content
+	}
+	return strings.TrimSpace(content[strings.Index(content, "("):])
+}
+
+func newYAMLTable(tbl *Table) *yamlTable {
+	yt := &yamlTable{
+		Name:    tbl.Name(),
+		Comment: tbl.Comment(),
+	}
+	if tbl.Kind() != KindTable {
+		yt.Kind = tbl.Kind()
+	}
+	for _, col := range tbl.Columns() {
+		yc := &yamlColumn{
+			Name:         col.Name(),
+			DataType:     col.DataType(),
+			Length:       int64Ptr(col.Size().Length()),
+			Precision:    int64Ptr(col.Size().Precision()),
+			Scale:        int64Ptr(col.Size().Scale()),
+			DefaultValue: col.DefaultValue(),
+			PrimaryKey:   col.PrimaryKeyPosition(),
+			Comment:      col.Comment(),
+		}
+		if nullable := col.IsNullable(); nullable != (col.PrimaryKeyPosition() == 0) {
+			yc.Nullable = &nullable
+		}
+		yt.Columns = append(yt.Columns, yc)
+	}
+	for _, idx := range tbl.Indices() {
+		yi := &yamlIndex{Name: idx.Name(), Unique: idx.IsUnique()}
+		for _, col := range idx.Columns() {
+			yi.Columns = append(yi.Columns, col.Name())
+		}
+		yt.Indices = append(yt.Indices, yi)
+	}
+	for _, fk := range tbl.ForeignKeys() {
+		yf := &yamlForeignKey{Name: fk.Name()}
+		for _, cr := range fk.ColumnReferences() {
+			yf.Columns = append(yf.Columns, cr.From().Name())
+			yf.RefColumns = append(yf.RefColumns, cr.To().Name())
+			yf.RefTable = cr.To().TableName()
+			if cr.To().Schema() != tbl.Schema() {
+				yf.RefSchema = cr.To().Schema()
+			}
+		}
+		yt.ForeignKeys = append(yt.ForeignKeys, yf)
+	}
+	for _, con := range tbl.Constraints() {
+		yt.Constraints = append(yt.Constraints, &yamlConstraint{Name: con.Name(), Kind: con.Kind(), Content: con.Content()})
+	}
+	return yt
+}
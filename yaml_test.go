@@ -0,0 +1,164 @@
+package dbmodel
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLoadYAML(t *testing.T) {
+	db := loadTestYAML(t)
+	if expected, actual := "sample", db.Name(); actual != expected {
+		t.Errorf("Name() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if expected, actual := 3, len(db.Tables()); actual != expected {
+		t.Fatalf("Table count is invalid. expected: %v, actual: %v", expected, actual)
+	}
+	usr, _ := db.FindTable("foo", "users")
+	if expected, actual := "user accounts", usr.Comment(); actual != expected {
+		t.Errorf("Comment() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	id, _ := usr.FindColumn("id")
+	if id.IsNullable() || id.PrimaryKeyPosition() != 1 || id.Size().String() != "32, 0" {
+		t.Errorf("Primary key column is invalid. (%#v)", id)
+	}
+	email, _ := usr.FindColumn("email")
+	if email.IsNullable() || email.Size().String() != "255" || email.Comment() != "login id" {
+		t.Errorf("Column is invalid. (%#v)", email)
+	}
+	name, _ := usr.FindColumn("name")
+	if !name.IsNullable() || name.Size().IsValid() {
+		t.Errorf("Column should be nullable and have no size. (%#v)", name)
+	}
+	if idx := usr.Indices()[0]; !idx.IsUnique() || idx.Columns()[0] != email {
+		t.Errorf("Index is invalid. (%#v)", idx)
+	}
+	view, _ := db.FindTable("bar", "active_users")
+	if expected, actual := KindView, view.Kind(); actual != expected {
+		t.Errorf("Kind() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestLoadYAMLRemovesCheckKeyword(t *testing.T) {
+	tests := []struct {
+		kind     string
+		content  string
+		expected string
+	}{
+		{"CHECK", "CHECK (user_id > 0)", "(user_id > 0)"},
+		{"CHECK", "check(user_id > 0)", "(user_id > 0)"},
+		{"CHECK", "(user_id > 0)", "(user_id > 0)"},
+		{"CHECK", "checked = true", "checked = true"},
+		{"UNIQUE", "CHECK (a)", "CHECK (a)"},
+	}
+	for _, test := range tests {
+		y := "schemas:\n  - name: foo\n    tables:\n      - name: t\n        constraints:\n          - name: c\n            kind: " + test.kind + "\n            content: \"" + test.content + "\"\n"
+		db, err := LoadYAML(strings.NewReader(y))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := db.Tables()[0].Constraints()[0].Content(); actual != test.expected {
+			t.Errorf("Content of %v is invalid. expected: %v, actual: %v", test.content, test.expected, actual)
+		}
+	}
+}
+
+func TestLoadYAMLLinksForeignKeys(t *testing.T) {
+	db := loadTestYAML(t)
+	usr, _ := db.FindTable("foo", "users")
+	pst, _ := db.FindTable("foo", "posts")
+	fk := pst.ForeignKeys()[0]
+	from, _ := pst.FindColumn("user_id")
+	to, _ := usr.FindColumn("id")
+	if fk.ColumnReferences()[0].From() != from || fk.ColumnReferences()[0].To() != to {
+		t.Error("Foreign key should be linked to columns.")
+	}
+	if fk.ReferencedTable() != usr {
+		t.Error("ReferencedTable() should return referenced table.")
+	}
+	if len(usr.ReferencedKeys()) != 1 || usr.ReferencedKeys()[0] != fk {
+		t.Error("Referenced key should be derived from foreign key.")
+	}
+}
+
+func TestLoadYAMLErrors(t *testing.T) {
+	cases := map[string]string{
+		"unknown key":        "schemas:\n  - name: foo\n    tabels: []\n",
+		"empty schema":       "schemas:\n  - tables: []\n",
+		"empty table":        "schemas:\n  - name: foo\n    tables:\n      - columns: []\n",
+		"duplicated table":   "schemas:\n  - name: foo\n    tables:\n      - name: t\n      - name: t\n",
+		"unknown index col":  "schemas:\n  - name: foo\n    tables:\n      - name: t\n        indices:\n          - name: i\n            columns: [x]\n",
+		"unpaired fk":        "schemas:\n  - name: foo\n    tables:\n      - name: t\n        columns:\n          - name: a\n        foreign_keys:\n          - name: f\n            columns: [a]\n            ref_table: t\n",
+		"unknown ref table":  "schemas:\n  - name: foo\n    tables:\n      - name: t\n        columns:\n          - name: a\n        foreign_keys:\n          - name: f\n            columns: [a]\n            ref_table: x\n            ref_columns: [a]\n",
+		"unknown ref column": "schemas:\n  - name: foo\n    tables:\n      - name: t\n        columns:\n          - name: a\n        foreign_keys:\n          - name: f\n            columns: [a]\n            ref_table: t\n            ref_columns: [b]\n",
+	}
+	for name, src := range cases {
+		if _, err := LoadYAML(strings.NewReader(src)); err == nil {
+			t.Errorf("LoadYAML should raise error on %v.", name)
+		}
+	}
+}
+
+func TestLoadYAMLNullEntries(t *testing.T) {
+	cases := []struct {
+		src      string
+		expected string
+	}{
+		{"schemas:\n  - ~\n", "Schema is empty at index 0."},
+		{"schemas:\n  - name: foo\n    tables:\n      - ~\n", "Table is empty at index 0 of schema 'foo'."},
+		{"schemas:\n  - name: foo\n    tables:\n      - name: t\n        columns:\n          - ~\n", "Column is empty at index 0 of table 'foo.t'."},
+		{"schemas:\n  - name: foo\n    tables:\n      - name: t\n        indices:\n          - ~\n", "Index is empty at index 0 of table 'foo.t'."},
+		{"schemas:\n  - name: foo\n    tables:\n      - name: t\n        foreign_keys:\n          - ~\n", "Foreign key is empty at index 0 of table 'foo.t'."},
+		{"schemas:\n  - name: foo\n    tables:\n      - name: t\n        constraints:\n          - ~\n", "Constraint is empty at index 0 of table 'foo.t'."},
+	}
+	for _, c := range cases {
+		_, err := LoadYAML(strings.NewReader(c.src))
+		if err == nil {
+			t.Errorf("LoadYAML should raise error on %q.", c.src)
+			continue
+		}
+		if actual := err.Error(); actual != c.expected {
+			t.Errorf("LoadYAML returns invalid error. expected: %v, actual: %v", c.expected, actual)
+		}
+	}
+}
+
+func TestWriteYAMLRoundTrip(t *testing.T) {
+	db := loadTestYAML(t)
+	var buf bytes.Buffer
+	if err := WriteYAML(&buf, db); err != nil {
+		t.Fatal(err)
+	}
+	written := buf.String()
+	reloaded, err := LoadYAML(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rebuf bytes.Buffer
+	WriteYAML(&rebuf, reloaded)
+	if written != rebuf.String() {
+		t.Errorf("WriteYAML should write same YAML for reloaded database.\n%v\n%v", written, rebuf.String())
+	}
+	pst, _ := reloaded.FindTable("foo", "posts")
+	body, _ := pst.FindColumn("body")
+	if expected, actual := "''::text", body.DefaultValue(); actual != expected {
+		t.Errorf("DefaultValue() returns invalid value. expected: %v, actual: %v", expected, actual)
+	}
+	if len(pst.ForeignKeys()) != 1 || pst.ForeignKeys()[0].ReferencedTable() == nil {
+		t.Error("Foreign key should be written and reloaded.")
+	}
+}
+
+func loadTestYAML(t *testing.T) *Database {
+	f, err := os.Open("testdata/schema.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	db, err := LoadYAML(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}